@@ -0,0 +1,115 @@
+package command
+
+import (
+	"context"
+	"strings"
+
+	"github.com/xtls/xray-core/app/dispatcher"
+	"github.com/xtls/xray-core/common"
+	"github.com/xtls/xray-core/common/errors"
+	"github.com/xtls/xray-core/core"
+	"github.com/xtls/xray-core/features/routing"
+	grpc "google.golang.org/grpc"
+)
+
+// connectionServer is an implementation of ConnectionService.
+type connectionServer struct {
+	dispatcher *dispatcher.DefaultDispatcher
+}
+
+func (f *ConnectionFilter) isEmpty() bool {
+	return f == nil || (f.InboundTag == "" && f.OutboundTag == "" && f.Email == "" && f.Source == "" && f.Target == "")
+}
+
+func (f *ConnectionFilter) match(c *dispatcher.TrackedConnection) bool {
+	if f == nil {
+		return true
+	}
+	if f.InboundTag != "" && f.InboundTag != c.InboundTag {
+		return false
+	}
+	if f.OutboundTag != "" && f.OutboundTag != c.OutboundTag {
+		return false
+	}
+	if f.Email != "" && !strings.EqualFold(f.Email, c.Email) {
+		return false
+	}
+	if f.Source != "" && !strings.Contains(c.Source.String(), f.Source) {
+		return false
+	}
+	if f.Target != "" && !strings.Contains(c.Target.String(), f.Target) && !strings.Contains(c.Domain, f.Target) {
+		return false
+	}
+	return true
+}
+
+func (s *connectionServer) ListConnections(ctx context.Context, request *ListConnectionsRequest) (*ListConnectionsResponse, error) {
+	if s.dispatcher == nil {
+		return nil, errors.New("dispatcher does not track connections")
+	}
+	response := &ListConnectionsResponse{}
+	for _, c := range s.dispatcher.Connections() {
+		if !request.Filter.match(c) {
+			continue
+		}
+		response.Connections = append(response.Connections, &Connection{
+			Id:          c.ID,
+			InboundTag:  c.InboundTag,
+			Email:       c.Email,
+			Source:      c.Source.String(),
+			Target:      c.Target.String(),
+			Domain:      c.Domain,
+			Protocol:    c.Protocol,
+			OutboundTag: c.OutboundTag,
+			Uplink:      c.Uplink(),
+			Downlink:    c.Downlink(),
+			StartTime:   c.Start.Unix(),
+		})
+	}
+	return response, nil
+}
+
+func (s *connectionServer) CloseConnections(ctx context.Context, request *CloseConnectionsRequest) (*CloseConnectionsResponse, error) {
+	if s.dispatcher == nil {
+		return nil, errors.New("dispatcher does not track connections")
+	}
+	response := &CloseConnectionsResponse{}
+	if len(request.Ids) > 0 {
+		for _, id := range request.Ids {
+			if s.dispatcher.CloseConnection(id) {
+				response.Closed++
+			}
+		}
+		return response, nil
+	}
+	if request.Filter.isEmpty() {
+		return nil, errors.New("no connection to close")
+	}
+	for _, c := range s.dispatcher.Connections() {
+		if request.Filter.match(c) && s.dispatcher.CloseConnection(c.ID) {
+			response.Closed++
+		}
+	}
+	return response, nil
+}
+
+func (s *connectionServer) mustEmbedUnimplementedConnectionServiceServer() {}
+
+type service struct {
+	v *core.Instance
+}
+
+func (s *service) Register(server *grpc.Server) {
+	cs := &connectionServer{}
+	common.Must(s.v.RequireFeatures(func(d routing.Dispatcher) {
+		cs.dispatcher, _ = d.(*dispatcher.DefaultDispatcher)
+	}, false))
+	RegisterConnectionServiceServer(server, cs)
+}
+
+func init() {
+	common.Must(common.RegisterConfig((*Config)(nil), func(ctx context.Context, cfg interface{}) (interface{}, error) {
+		s := core.MustFromContext(ctx)
+		return &service{v: s}, nil
+	}))
+}
@@ -0,0 +1,147 @@
+package command
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/xtls/xray-core/app/dispatcher"
+	"github.com/xtls/xray-core/app/proxyman"
+	_ "github.com/xtls/xray-core/app/proxyman/outbound"
+	"github.com/xtls/xray-core/common"
+	"github.com/xtls/xray-core/common/net"
+	"github.com/xtls/xray-core/common/protocol"
+	"github.com/xtls/xray-core/common/serial"
+	"github.com/xtls/xray-core/common/session"
+	"github.com/xtls/xray-core/core"
+	"github.com/xtls/xray-core/features/routing"
+	"github.com/xtls/xray-core/proxy/freedom"
+	"github.com/xtls/xray-core/testing/servers/tcp"
+	"github.com/xtls/xray-core/transport"
+)
+
+func TestConnectionFilter(t *testing.T) {
+	c := &dispatcher.TrackedConnection{
+		InboundTag:  "in",
+		Email:       "Love@xray.com",
+		Source:      net.TCPDestination(net.ParseAddress("10.0.0.1"), 1234),
+		Target:      net.TCPDestination(net.ParseAddress("1.2.3.4"), 443),
+		Domain:      "www.example.com",
+		OutboundTag: "out",
+	}
+	testCases := []struct {
+		filter *ConnectionFilter
+		match  bool
+	}{
+		{filter: nil, match: true},
+		{filter: &ConnectionFilter{}, match: true},
+		{filter: &ConnectionFilter{InboundTag: "in"}, match: true},
+		{filter: &ConnectionFilter{InboundTag: "i"}, match: false},
+		{filter: &ConnectionFilter{OutboundTag: "out"}, match: true},
+		{filter: &ConnectionFilter{OutboundTag: "direct"}, match: false},
+		{filter: &ConnectionFilter{Email: "love@xray.com"}, match: true},
+		{filter: &ConnectionFilter{Email: "xray@love.com"}, match: false},
+		{filter: &ConnectionFilter{Source: "10.0.0.1"}, match: true},
+		{filter: &ConnectionFilter{Source: "10.0.0.2"}, match: false},
+		{filter: &ConnectionFilter{Target: "1.2.3.4:443"}, match: true},
+		{filter: &ConnectionFilter{Target: "example.com"}, match: true},
+		{filter: &ConnectionFilter{Target: "example.org"}, match: false},
+		{filter: &ConnectionFilter{InboundTag: "in", Email: "xray@love.com"}, match: false},
+	}
+	for _, test := range testCases {
+		if r := test.filter.match(c); r != test.match {
+			t.Error("filter ", test.filter, ": expect ", test.match, ", but actually ", r)
+		}
+	}
+}
+
+func dispatchTestConnection(d routing.Dispatcher, destination net.Destination, inboundTag string, email string) *transport.Link {
+	ctx := session.ContextWithInbound(context.Background(), &session.Inbound{
+		Tag:    inboundTag,
+		Source: net.TCPDestination(net.LocalHostIP, 1234),
+		User:   &protocol.MemoryUser{Email: email},
+	})
+	link, err := d.Dispatch(ctx, destination)
+	common.Must(err)
+	return link
+}
+
+func waitConnections(t *testing.T, s *connectionServer, count int) {
+	for i := 0; i < 100; i++ {
+		resp, err := s.ListConnections(context.Background(), &ListConnectionsRequest{})
+		common.Must(err)
+		if len(resp.Connections) == count {
+			return
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+	t.Fatal("expect ", count, " connections")
+}
+
+func TestConnectionService(t *testing.T) {
+	tcpServer := tcp.Server{
+		MsgProcessor: func(b []byte) []byte { return b },
+	}
+	dest, err := tcpServer.Start()
+	common.Must(err)
+	defer tcpServer.Close()
+
+	v, err := core.New(&core.Config{
+		App: []*serial.TypedMessage{
+			serial.ToTypedMessage(&dispatcher.Config{}),
+			serial.ToTypedMessage(&proxyman.OutboundConfig{}),
+		},
+		Outbound: []*core.OutboundHandlerConfig{
+			{
+				Tag:           "direct",
+				ProxySettings: serial.ToTypedMessage(&freedom.Config{}),
+			},
+		},
+	})
+	common.Must(err)
+	common.Must(v.Start())
+	defer v.Close()
+
+	d := v.GetFeature(routing.DispatcherType()).(routing.Dispatcher)
+	s := &connectionServer{dispatcher: d.(*dispatcher.DefaultDispatcher)}
+
+	link1 := dispatchTestConnection(d, dest, "in1", "love@xray.com")
+	defer common.Interrupt(link1.Writer)
+	link2 := dispatchTestConnection(d, dest, "in2", "xray@love.com")
+	defer common.Interrupt(link2.Writer)
+	waitConnections(t, s, 2)
+
+	resp, err := s.ListConnections(context.Background(), &ListConnectionsRequest{
+		Filter: &ConnectionFilter{InboundTag: "in2"},
+	})
+	common.Must(err)
+	if len(resp.Connections) != 1 {
+		t.Fatal("expect 1 connection of in2, but actually ", len(resp.Connections))
+	}
+	c := resp.Connections[0]
+	if c.Email != "xray@love.com" || c.OutboundTag != "direct" || c.Target != dest.String() || c.Source != "tcp:127.0.0.1:1234" {
+		t.Error("unexpected connection: ", c)
+	}
+
+	if _, err := s.CloseConnections(context.Background(), &CloseConnectionsRequest{}); err == nil {
+		t.Error("expect error closing connections without filter")
+	}
+
+	closed, err := s.CloseConnections(context.Background(), &CloseConnectionsRequest{
+		Filter: &ConnectionFilter{Email: "love@xray.com"},
+	})
+	common.Must(err)
+	if closed.Closed != 1 {
+		t.Error("expect 1 connection closed, but actually ", closed.Closed)
+	}
+	waitConnections(t, s, 1)
+
+	closed, err = s.CloseConnections(context.Background(), &CloseConnectionsRequest{
+		Ids: []uint64{c.Id, c.Id + 100},
+	})
+	common.Must(err)
+	if closed.Closed != 1 {
+		t.Error("expect 1 connection closed, but actually ", closed.Closed)
+	}
+	waitConnections(t, s, 0)
+}
@@ -0,0 +1,211 @@
+package dispatcher
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/xtls/xray-core/common"
+	"github.com/xtls/xray-core/common/buf"
+	"github.com/xtls/xray-core/common/log"
+	"github.com/xtls/xray-core/common/net"
+	"github.com/xtls/xray-core/common/session"
+	"github.com/xtls/xray-core/transport"
+)
+
+// TrackedConnection is a session dispatched to an outbound handler.
+type TrackedConnection struct {
+	ID          uint64
+	InboundTag  string
+	Email       string
+	Source      net.Destination
+	Target      net.Destination
+	Domain      string // Sniffed domain, if any
+	Protocol    string // Sniffed protocol, if any
+	OutboundTag string
+	Start       time.Time
+
+	uplink   trafficCounter
+	downlink trafficCounter
+	cancel   context.CancelFunc
+	link     *transport.Link
+	done     sync.Once
+	access   *log.AccessMessage
+}
+
+// Uplink returns the bytes sent to the outbound so far.
+func (c *TrackedConnection) Uplink() int64 {
+	return c.uplink.Value()
+}
+
+// Downlink returns the bytes received from the outbound so far.
+func (c *TrackedConnection) Downlink() int64 {
+	return c.downlink.Value()
+}
+
+// trafficCounter is a stats.Counter of a single connection.
+type trafficCounter struct {
+	value atomic.Int64
+}
+
+func (c *trafficCounter) Value() int64 {
+	return c.value.Load()
+}
+
+func (c *trafficCounter) Set(v int64) int64 {
+	return c.value.Swap(v)
+}
+
+func (c *trafficCounter) Add(delta int64) int64 {
+	return c.value.Add(delta)
+}
+
+// trackedWriter is the downlink writer of a tracked connection. The outbound handler closes or interrupts it when
+// the connection ends, even if the handler dispatched it asynchronously, e.g. over mux.
+type trackedWriter struct {
+	buf.Writer
+	onClose func()
+}
+
+func (w *trackedWriter) Close() error {
+	defer w.onClose()
+	return common.Close(w.Writer)
+}
+
+func (w *trackedWriter) Interrupt() {
+	defer w.onClose()
+	common.Interrupt(w.Writer)
+}
+
+// connectionTracker keeps the table of live connections.
+type connectionTracker struct {
+	access      sync.RWMutex
+	lastID      uint64
+	connections map[uint64]*TrackedConnection
+}
+
+// track records the session in ctx as dispatched to the outbound handler with the given tag. It returns the context
+// and link to hand over to the handler, which count traffic, can be interrupted by the tracker, and remove the
+// connection from the table once the handler closes the link.
+func (t *connectionTracker) track(ctx context.Context, link *transport.Link, destination net.Destination, outboundTag string) (context.Context, *transport.Link) {
+	ctx, cancel := context.WithCancel(ctx)
+	c := &TrackedConnection{
+		Target:      destination,
+		OutboundTag: outboundTag,
+		Start:       time.Now(),
+		cancel:      cancel,
+		Domain:      targetDomain(ctx),
+		access:      log.AccessMessageFromContext(ctx),
+	}
+	if inbound := session.InboundFromContext(ctx); inbound != nil {
+		c.InboundTag = inbound.Tag
+		c.Source = inbound.Source
+		if inbound.User != nil {
+			c.Email = inbound.User.Email
+		}
+	}
+	if content := session.ContentFromContext(ctx); content != nil {
+		c.Protocol = content.Protocol
+	}
+	c.link = &transport.Link{
+		Reader: &SizeStatReader{
+			Counter: &c.uplink,
+			Reader:  link.Reader,
+		},
+		Writer: &trackedWriter{
+			Writer: &SizeStatWriter{
+				Counter: &c.downlink,
+				Writer:  link.Writer,
+			},
+			onClose: func() {
+				t.remove(c)
+			},
+		},
+	}
+
+	t.access.Lock()
+	if t.connections == nil {
+		t.connections = make(map[uint64]*TrackedConnection)
+	}
+	t.lastID++
+	c.ID = t.lastID
+	t.connections[c.ID] = c
+	t.access.Unlock()
+
+	return ctx, c.link
+}
+
+// targetDomain returns the domain of the target of the session in ctx, either requested or sniffed.
+func targetDomain(ctx context.Context) string {
+	outbounds := session.OutboundsFromContext(ctx)
+	if len(outbounds) == 0 {
+		return ""
+	}
+	ob := outbounds[len(outbounds)-1]
+	if ob.RouteTarget.Address != nil && ob.RouteTarget.Address.Family().IsDomain() {
+		return ob.RouteTarget.Address.Domain()
+	}
+	if ob.Target.Address != nil && ob.Target.Address.Family().IsDomain() {
+		return ob.Target.Address.Domain()
+	}
+	return ""
+}
+
+func (t *connectionTracker) remove(c *TrackedConnection) {
+	c.done.Do(func() {
+		c.cancel()
+
+		t.access.Lock()
+		delete(t.connections, c.ID)
+		t.access.Unlock()
+
+		if c.access != nil {
+			msg := *c.access
+			msg.Status = log.AccessClosed
+			msg.Reason = ""
+			msg.Uplink = c.Uplink()
+			msg.Downlink = c.Downlink()
+			log.Record(&msg)
+		}
+	})
+}
+
+func (t *connectionTracker) list() []*TrackedConnection {
+	t.access.RLock()
+	result := make([]*TrackedConnection, 0, len(t.connections))
+	for _, c := range t.connections {
+		result = append(result, c)
+	}
+	t.access.RUnlock()
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].ID < result[j].ID
+	})
+	return result
+}
+
+func (t *connectionTracker) close(id uint64) bool {
+	t.access.RLock()
+	c, found := t.connections[id]
+	t.access.RUnlock()
+	if !found {
+		return false
+	}
+
+	c.cancel()
+	common.Interrupt(c.link.Reader)
+	common.Interrupt(c.link.Writer)
+	return true
+}
+
+// Connections returns the live connections, ordered by ID.
+func (d *DefaultDispatcher) Connections() []*TrackedConnection {
+	return d.connections.list()
+}
+
+// CloseConnection forcibly closes the live connection with the given ID. It returns false if there is none.
+func (d *DefaultDispatcher) CloseConnection(id uint64) bool {
+	return d.connections.close(id)
+}
@@ -0,0 +1,119 @@
+package dispatcher
+
+import (
+	"context"
+	"testing"
+
+	"github.com/xtls/xray-core/common"
+	"github.com/xtls/xray-core/common/buf"
+	"github.com/xtls/xray-core/common/net"
+	"github.com/xtls/xray-core/common/protocol"
+	"github.com/xtls/xray-core/common/session"
+	"github.com/xtls/xray-core/transport"
+	"github.com/xtls/xray-core/transport/pipe"
+)
+
+func trackTestConnection(tracker *connectionTracker) (context.Context, *transport.Link, *pipe.Writer, *pipe.Reader) {
+	ctx := session.ContextWithInbound(context.Background(), &session.Inbound{
+		Tag:    "in",
+		Source: net.TCPDestination(net.LocalHostIP, 1234),
+		User:   &protocol.MemoryUser{Email: "love@xray.com"},
+	})
+	destination := net.TCPDestination(net.DomainAddress("example.com"), 443)
+	ctx = session.ContextWithOutbounds(ctx, []*session.Outbound{{Target: destination}})
+	ctx = session.ContextWithContent(ctx, &session.Content{Protocol: "tls"})
+
+	uplinkReader, uplinkWriter := pipe.New(pipe.WithoutSizeLimit())
+	downlinkReader, downlinkWriter := pipe.New(pipe.WithoutSizeLimit())
+	ctx, link := tracker.track(ctx, &transport.Link{Reader: uplinkReader, Writer: downlinkWriter}, destination, "out")
+	return ctx, link, uplinkWriter, downlinkReader
+}
+
+func TestConnectionTrackerTrack(t *testing.T) {
+	tracker := &connectionTracker{}
+	_, link, uplinkWriter, downlinkReader := trackTestConnection(tracker)
+
+	connections := tracker.list()
+	if len(connections) != 1 {
+		t.Fatal("expect 1 connection, but actually ", len(connections))
+	}
+	c := connections[0]
+	if c.ID != 1 || c.InboundTag != "in" || c.Email != "love@xray.com" || c.OutboundTag != "out" {
+		t.Error("unexpected connection: ", c.ID, " ", c.InboundTag, " ", c.Email, " ", c.OutboundTag)
+	}
+	if c.Source.String() != "tcp:127.0.0.1:1234" || c.Target.String() != "tcp:example.com:443" {
+		t.Error("unexpected source ", c.Source, " or target ", c.Target)
+	}
+	if c.Domain != "example.com" || c.Protocol != "tls" {
+		t.Error("unexpected domain ", c.Domain, " or protocol ", c.Protocol)
+	}
+
+	common.Must(uplinkWriter.WriteMultiBuffer(buf.MultiBuffer{buf.FromBytes([]byte("abc"))}))
+	mb, err := link.Reader.ReadMultiBuffer()
+	common.Must(err)
+	buf.ReleaseMulti(mb)
+	common.Must(link.Writer.WriteMultiBuffer(buf.MultiBuffer{buf.FromBytes([]byte("abcde"))}))
+	mb, err = downlinkReader.ReadMultiBuffer()
+	common.Must(err)
+	buf.ReleaseMulti(mb)
+	if c.Uplink() != 3 || c.Downlink() != 5 {
+		t.Error("expect uplink 3 and downlink 5, but actually ", c.Uplink(), " and ", c.Downlink())
+	}
+
+	_, _, _, _ = trackTestConnection(tracker)
+	connections = tracker.list()
+	if len(connections) != 2 || connections[0].ID != 1 || connections[1].ID != 2 {
+		t.Error("expect connections 1 and 2 in order")
+	}
+}
+
+func TestConnectionTrackerRemove(t *testing.T) {
+	tracker := &connectionTracker{}
+	ctx, link, _, _ := trackTestConnection(tracker)
+
+	common.Must(common.Close(link.Writer))
+	if len(tracker.list()) != 0 {
+		t.Error("connection is not removed after its link is closed")
+	}
+	if ctx.Err() == nil {
+		t.Error("context of the removed connection is not canceled")
+	}
+	// Closing again, e.g. both by the handler and by interruption, is harmless.
+	common.Interrupt(link.Writer)
+
+	ctx, link, _, _ = trackTestConnection(tracker)
+	common.Interrupt(link.Writer)
+	if len(tracker.list()) != 0 {
+		t.Error("connection is not removed after its link is interrupted")
+	}
+	if ctx.Err() == nil {
+		t.Error("context of the removed connection is not canceled")
+	}
+}
+
+func TestConnectionTrackerClose(t *testing.T) {
+	tracker := &connectionTracker{}
+	ctx, link, _, downlinkReader := trackTestConnection(tracker)
+
+	if tracker.close(2) {
+		t.Error("closed a connection not tracked")
+	}
+	if !tracker.close(1) {
+		t.Fatal("failed to close connection 1")
+	}
+	if ctx.Err() == nil {
+		t.Error("context of the closed connection is not canceled")
+	}
+	if _, err := link.Reader.ReadMultiBuffer(); err == nil {
+		t.Error("uplink of the closed connection is still readable")
+	}
+	if _, err := downlinkReader.ReadMultiBuffer(); err == nil {
+		t.Error("downlink of the closed connection is still readable")
+	}
+	if len(tracker.list()) != 0 {
+		t.Error("closed connection is still tracked")
+	}
+	if tracker.close(1) {
+		t.Error("closed connection 1 twice")
+	}
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/xtls/xray-core/common/log"
 	"github.com/xtls/xray-core/common/net"
 	"github.com/xtls/xray-core/common/protocol"
+	"github.com/xtls/xray-core/common/protocol/tls"
 	"github.com/xtls/xray-core/common/session"
 	"github.com/xtls/xray-core/core"
 	"github.com/xtls/xray-core/features/dns"
@@ -23,6 +24,7 @@ import (
 	"github.com/xtls/xray-core/features/stats"
 	"github.com/xtls/xray-core/transport"
 	"github.com/xtls/xray-core/transport/pipe"
+	"golang.org/x/time/rate"
 )
 
 var errSniffingTimeout = errors.New("timeout on sniffing")
@@ -99,6 +101,8 @@ type DefaultDispatcher struct {
 	policy policy.Manager
 	stats  stats.Manager
 	fdns   dns.FakeDNSEngine
+
+	connections connectionTracker
 }
 
 func init() {
@@ -161,22 +165,33 @@ func (d *DefaultDispatcher) getLink(ctx context.Context) (*transport.Link, *tran
 
 	if user != nil && len(user.Email) > 0 {
 		p := d.policy.ForLevel(user.Level)
-		if p.Stats.UserUplink {
-			name := "user>>>" + user.Email + ">>>traffic>>>uplink"
-			if c, _ := stats.GetOrRegisterCounter(d.stats, name); c != nil {
-				inboundLink.Writer = &SizeStatWriter{
-					Counter: c,
-					Writer:  inboundLink.Writer,
-				}
+		uplinkCounter, downlinkCounter := d.userCounters(user, p)
+		if uplinkCounter != nil {
+			inboundLink.Writer = &SizeStatWriter{
+				Counter: uplinkCounter,
+				Writer:  inboundLink.Writer,
 			}
 		}
-		if p.Stats.UserDownlink {
-			name := "user>>>" + user.Email + ">>>traffic>>>downlink"
-			if c, _ := stats.GetOrRegisterCounter(d.stats, name); c != nil {
-				outboundLink.Writer = &SizeStatWriter{
-					Counter: c,
-					Writer:  outboundLink.Writer,
-				}
+		if downlinkCounter != nil {
+			outboundLink.Writer = &SizeStatWriter{
+				Counter: downlinkCounter,
+				Writer:  outboundLink.Writer,
+			}
+		}
+		if p.Quota.CloseConnections && (user.Quota > 0 || user.Expire > 0) {
+			// Splice copy would bypass the checks.
+			sessionInbound.CanSpliceCopy = 3
+			inboundLink.Writer = &QuotaWriter{
+				User:     user,
+				Uplink:   uplinkCounter,
+				Downlink: downlinkCounter,
+				Writer:   inboundLink.Writer,
+			}
+			outboundLink.Writer = &QuotaWriter{
+				User:     user,
+				Uplink:   uplinkCounter,
+				Downlink: downlinkCounter,
+				Writer:   outboundLink.Writer,
 			}
 		}
 
@@ -193,9 +208,138 @@ func (d *DefaultDispatcher) getLink(ctx context.Context) (*transport.Link, *tran
 		}
 	}
 
+	if uplinkLimiters, downlinkLimiters := d.rateLimiters(ctx); len(uplinkLimiters)+len(downlinkLimiters) > 0 {
+		if len(uplinkLimiters) > 0 {
+			inboundLink.Writer = &RateLimitWriter{
+				Context:  ctx,
+				Limiters: uplinkLimiters,
+				Writer:   inboundLink.Writer,
+			}
+		}
+		if len(downlinkLimiters) > 0 {
+			outboundLink.Writer = &RateLimitWriter{
+				Context:  ctx,
+				Limiters: downlinkLimiters,
+				Writer:   outboundLink.Writer,
+			}
+		}
+	}
+
 	return inboundLink, outboundLink
 }
 
+// rateLimiters returns the limiters of the inbound and the user of the session.
+// Splice copy is disabled for limited sessions, as it bypasses the links.
+func (d *DefaultDispatcher) rateLimiters(ctx context.Context) (uplink []*rate.Limiter, downlink []*rate.Limiter) {
+	limiter, ok := d.policy.(policy.Limiter)
+	sessionInbound := session.InboundFromContext(ctx)
+	if !ok || sessionInbound == nil {
+		return nil, nil
+	}
+
+	add := func(up, down *rate.Limiter) {
+		if up != nil {
+			uplink = append(uplink, up)
+		}
+		if down != nil {
+			downlink = append(downlink, down)
+		}
+	}
+	if len(sessionInbound.Tag) > 0 {
+		add(limiter.ForInbound(sessionInbound.Tag))
+	}
+	if user := sessionInbound.User; user != nil && len(user.Email) > 0 {
+		add(limiter.ForUser(user.Level, user.Email))
+	}
+	if len(uplink)+len(downlink) > 0 {
+		sessionInbound.CanSpliceCopy = 3
+	}
+	return uplink, downlink
+}
+
+// userCounters returns the traffic counters of the user. They are registered if stats are enabled for the user,
+// or if the user has a quota that is checked against them.
+func (d *DefaultDispatcher) userCounters(user *protocol.MemoryUser, p policy.Session) (uplink stats.Counter, downlink stats.Counter) {
+	if p.Stats.UserUplink || user.Quota > 0 {
+		name := "user>>>" + user.Email + ">>>traffic>>>uplink"
+		uplink, _ = stats.GetOrRegisterCounter(d.stats, name)
+	}
+	if p.Stats.UserDownlink || user.Quota > 0 {
+		name := "user>>>" + user.Email + ">>>traffic>>>downlink"
+		downlink, _ = stats.GetOrRegisterCounter(d.stats, name)
+	}
+	return uplink, downlink
+}
+
+// checkUser rejects sessions of users that ran out of quota or expired.
+func (d *DefaultDispatcher) checkUser(ctx context.Context) error {
+	sessionInbound := session.InboundFromContext(ctx)
+	if sessionInbound == nil || sessionInbound.User == nil {
+		return nil
+	}
+	user := sessionInbound.User
+	if user.Quota == 0 && user.Expire == 0 {
+		return nil
+	}
+	if _, noop := d.stats.(stats.NoopManager); noop && user.Quota > 0 {
+		return errors.New("quota of user ", user.Email, " cannot be enforced without stats")
+	}
+	uplink := d.stats.GetCounter("user>>>" + user.Email + ">>>traffic>>>uplink")
+	downlink := d.stats.GetCounter("user>>>" + user.Email + ">>>traffic>>>downlink")
+	return checkQuota(user, uplink, downlink)
+}
+
+// wrapLink wraps a link passed to DispatchLink with the quota and rate limits of the session, as getLink does with
+// the pipes it creates. The traffic of the link is not counted, so the quota is checked against the traffic of the
+// other sessions of the user.
+func (d *DefaultDispatcher) wrapLink(ctx context.Context, link *transport.Link) *transport.Link {
+	wrapped := &transport.Link{
+		Reader: link.Reader,
+		Writer: link.Writer,
+	}
+
+	sessionInbound := session.InboundFromContext(ctx)
+	if sessionInbound != nil && sessionInbound.User != nil && len(sessionInbound.User.Email) > 0 {
+		user := sessionInbound.User
+		p := d.policy.ForLevel(user.Level)
+		if p.Quota.CloseConnections && (user.Quota > 0 || user.Expire > 0) {
+			// Splice copy would bypass the checks.
+			sessionInbound.CanSpliceCopy = 3
+			uplinkCounter := d.stats.GetCounter("user>>>" + user.Email + ">>>traffic>>>uplink")
+			downlinkCounter := d.stats.GetCounter("user>>>" + user.Email + ">>>traffic>>>downlink")
+			wrapped.Reader = &QuotaReader{
+				User:     user,
+				Uplink:   uplinkCounter,
+				Downlink: downlinkCounter,
+				Reader:   wrapped.Reader,
+			}
+			wrapped.Writer = &QuotaWriter{
+				User:     user,
+				Uplink:   uplinkCounter,
+				Downlink: downlinkCounter,
+				Writer:   wrapped.Writer,
+			}
+		}
+	}
+
+	uplinkLimiters, downlinkLimiters := d.rateLimiters(ctx)
+	if len(uplinkLimiters) > 0 {
+		wrapped.Reader = &RateLimitReader{
+			Context:  ctx,
+			Limiters: uplinkLimiters,
+			Reader:   wrapped.Reader,
+		}
+	}
+	if len(downlinkLimiters) > 0 {
+		wrapped.Writer = &RateLimitWriter{
+			Context:  ctx,
+			Limiters: downlinkLimiters,
+			Writer:   wrapped.Writer,
+		}
+	}
+	return wrapped
+}
+
 func (d *DefaultDispatcher) shouldOverride(ctx context.Context, result SniffResult, request session.SniffingRequest, destination net.Destination) bool {
 	domain := result.Domain()
 	if domain == "" {
@@ -246,6 +390,9 @@ func (d *DefaultDispatcher) Dispatch(ctx context.Context, destination net.Destin
 	if !destination.IsValid() {
 		panic("Dispatcher: Invalid destination.")
 	}
+	if err := d.checkUser(ctx); err != nil {
+		return nil, err
+	}
 	outbounds := session.OutboundsFromContext(ctx)
 	if len(outbounds) == 0 {
 		outbounds = []*session.Outbound{{}}
@@ -273,6 +420,7 @@ func (d *DefaultDispatcher) Dispatch(ctx context.Context, destination net.Destin
 			result, err := sniffer(ctx, cReader, sniffingRequest.MetadataOnly, destination.Network)
 			if err == nil {
 				content.Protocol = result.Protocol()
+				setSniffedAttributes(content, result)
 			}
 			if err == nil && d.shouldOverride(ctx, result, sniffingRequest, destination) {
 				domain := result.Domain()
@@ -303,6 +451,9 @@ func (d *DefaultDispatcher) DispatchLink(ctx context.Context, destination net.De
 	if !destination.IsValid() {
 		return errors.New("Dispatcher: Invalid destination.")
 	}
+	if err := d.checkUser(ctx); err != nil {
+		return err
+	}
 	outbounds := session.OutboundsFromContext(ctx)
 	if len(outbounds) == 0 {
 		outbounds = []*session.Outbound{{}}
@@ -318,7 +469,7 @@ func (d *DefaultDispatcher) DispatchLink(ctx context.Context, destination net.De
 	}
 	sniffingRequest := content.SniffingRequest
 	if !sniffingRequest.Enabled {
-		d.routedDispatch(ctx, outbound, destination)
+		d.routedDispatch(ctx, d.wrapLink(ctx, outbound), destination)
 	} else {
 		cReader := &cachedReader{
 			reader: outbound.Reader.(buf.TimeoutReader),
@@ -327,6 +478,7 @@ func (d *DefaultDispatcher) DispatchLink(ctx context.Context, destination net.De
 		result, err := sniffer(ctx, cReader, sniffingRequest.MetadataOnly, destination.Network)
 		if err == nil {
 			content.Protocol = result.Protocol()
+			setSniffedAttributes(content, result)
 		}
 		if err == nil && d.shouldOverride(ctx, result, sniffingRequest, destination) {
 			domain := result.Domain()
@@ -346,12 +498,41 @@ func (d *DefaultDispatcher) DispatchLink(ctx context.Context, destination net.De
 				ob.Target = destination
 			}
 		}
-		d.routedDispatch(ctx, outbound, destination)
+		d.routedDispatch(ctx, d.wrapLink(ctx, outbound), destination)
 	}
 
 	return nil
 }
 
+// setSniffedAttributes copies the attributes and the ALPN of the sniff result to content for routing.
+func setSniffedAttributes(content *session.Content, result SniffResult) {
+	if r, ok := result.(SnifferResultAttributes); ok {
+		for key, value := range r.Attributes() {
+			content.SetAttribute(key, value)
+		}
+	}
+	if r, ok := result.(SnifferResultALPN); ok {
+		content.ALPN = r.ALPN()
+	}
+}
+
+// setClientHelloAttributes fingerprints the TLS client hello in b for routing, if it is not sniffed, e.g. for lack of
+// server name.
+func setClientHelloAttributes(ctx context.Context, b []byte) {
+	content := session.ContentFromContext(ctx)
+	if content == nil {
+		return
+	}
+	hello, err := tls.SniffClientHello(b)
+	if err != nil {
+		return
+	}
+	for key, value := range hello.Attributes(false) {
+		content.SetAttribute(key, value)
+	}
+	content.ALPN = hello.ALPN
+}
+
 func sniffer(ctx context.Context, cReader *cachedReader, metadataOnly bool, network net.Network) (SniffResult, error) {
 	payload := buf.NewWithSize(32767)
 	defer payload.Release()
@@ -388,6 +569,9 @@ func sniffer(ctx context.Context, cReader *cachedReader, metadataOnly bool, netw
 					case protocol.ErrProtoNeedMoreData: // Protocol Need More Data: protocol matches, but need more data to complete sniffing
 						// in this case, do not add totalAttempt(allow to read until timeout)
 					default:
+						if err == errUnknownContent && network == net.Network_TCP {
+							setClientHelloAttributes(ctx, payload.Bytes())
+						}
 						return result, err
 					}
 				} else {
@@ -412,6 +596,7 @@ func (d *DefaultDispatcher) routedDispatch(ctx context.Context, link *transport.
 	ob := outbounds[len(outbounds)-1]
 
 	var handler outbound.Handler
+	var route routing.Route
 
 	routingLink := routing_session.AsRoutingContext(ctx)
 	inTag := routingLink.GetInboundTag()
@@ -429,14 +614,15 @@ func (d *DefaultDispatcher) routedDispatch(ctx context.Context, link *transport.
 			return
 		}
 	} else if d.router != nil {
-		if route, err := d.router.PickRoute(routingLink); err == nil {
-			outTag := route.GetOutboundTag()
+		if r, err := d.router.PickRoute(routingLink); err == nil {
+			outTag := r.GetOutboundTag()
 			if h := d.ohm.GetHandler(outTag); h != nil {
 				isPickRoute = 2
-				if route.GetRuleTag() == "" {
+				route = r
+				if r.GetRuleTag() == "" {
 					errors.LogInfo(ctx, "taking detour [", outTag, "] for [", destination, "]")
 				} else {
-					errors.LogInfo(ctx, "Hit route rule: [", route.GetRuleTag(), "] so taking detour [", outTag, "] for [", destination, "]")
+					errors.LogInfo(ctx, "Hit route rule: [", r.GetRuleTag(), "] so taking detour [", outTag, "] for [", destination, "]")
 				}
 				handler = h
 			} else {
@@ -460,6 +646,9 @@ func (d *DefaultDispatcher) routedDispatch(ctx context.Context, link *transport.
 
 	ob.Tag = handler.Tag()
 	if accessMessage := log.AccessMessageFromContext(ctx); accessMessage != nil {
+		accessMessage.InboundTag = inTag
+		accessMessage.OutboundTag = handler.Tag()
+		accessMessage.Domain = targetDomain(ctx)
 		if tag := handler.Tag(); tag != "" {
 			if inTag == "" {
 				accessMessage.Detour = tag
@@ -474,5 +663,10 @@ func (d *DefaultDispatcher) routedDispatch(ctx context.Context, link *transport.
 		log.Record(accessMessage)
 	}
 
+	if counted, ok := route.(routing.TrafficCountedRoute); ok {
+		link = countRouteTraffic(counted, link)
+	}
+
+	ctx, link = d.connections.track(ctx, link, destination, handler.Tag())
 	handler.Dispatch(ctx, link)
 }
@@ -0,0 +1,95 @@
+package dispatcher
+
+import (
+	"time"
+
+	"github.com/xtls/xray-core/common"
+	"github.com/xtls/xray-core/common/buf"
+	"github.com/xtls/xray-core/common/errors"
+	"github.com/xtls/xray-core/common/protocol"
+	"github.com/xtls/xray-core/features/stats"
+)
+
+// checkQuota returns an error if the user expired, or if its traffic counters reached its quota.
+func checkQuota(user *protocol.MemoryUser, uplink stats.Counter, downlink stats.Counter) error {
+	if user.Expired(time.Now()) {
+		return errors.New("user ", user.Email, " expired")
+	}
+	if user.Quota == 0 {
+		return nil
+	}
+	var used int64
+	if uplink != nil {
+		used += uplink.Value()
+	}
+	if downlink != nil {
+		used += downlink.Value()
+	}
+	if used >= 0 && uint64(used) >= user.Quota {
+		return errors.New("user ", user.Email, " ran out of quota")
+	}
+	return nil
+}
+
+// QuotaWriter fails once the user runs out of quota or expires, which closes the connection.
+type QuotaWriter struct {
+	User     *protocol.MemoryUser
+	Uplink   stats.Counter
+	Downlink stats.Counter
+	Writer   buf.Writer
+}
+
+func (w *QuotaWriter) WriteMultiBuffer(mb buf.MultiBuffer) error {
+	if err := checkQuota(w.User, w.Uplink, w.Downlink); err != nil {
+		buf.ReleaseMulti(mb)
+		return err
+	}
+	return w.Writer.WriteMultiBuffer(mb)
+}
+
+func (w *QuotaWriter) Close() error {
+	return common.Close(w.Writer)
+}
+
+func (w *QuotaWriter) Interrupt() {
+	common.Interrupt(w.Writer)
+}
+
+// QuotaReader fails once the user runs out of quota or expires, which closes the connection.
+type QuotaReader struct {
+	User     *protocol.MemoryUser
+	Uplink   stats.Counter
+	Downlink stats.Counter
+	Reader   buf.Reader
+}
+
+func (r *QuotaReader) ReadMultiBuffer() (buf.MultiBuffer, error) {
+	if err := checkQuota(r.User, r.Uplink, r.Downlink); err != nil {
+		return nil, err
+	}
+	return r.Reader.ReadMultiBuffer()
+}
+
+// ReadMultiBufferTimeout implements buf.TimeoutReader.
+func (r *QuotaReader) ReadMultiBufferTimeout(timeout time.Duration) (buf.MultiBuffer, error) {
+	if err := checkQuota(r.User, r.Uplink, r.Downlink); err != nil {
+		return nil, err
+	}
+	if tr, ok := r.Reader.(buf.TimeoutReader); ok {
+		return tr.ReadMultiBufferTimeout(timeout)
+	}
+	return r.Reader.ReadMultiBuffer()
+}
+
+func (r *QuotaReader) Interrupt() {
+	common.Interrupt(r.Reader)
+}
+
+func (r *QuotaReader) Close() error {
+	return common.Close(r.Reader)
+}
+
+// Unwrap returns the reader checked, for pipe.UnwrapReader.
+func (r *QuotaReader) Unwrap() buf.Reader {
+	return r.Reader
+}
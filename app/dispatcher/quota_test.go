@@ -0,0 +1,95 @@
+package dispatcher
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/xtls/xray-core/app/policy"
+	app_stats "github.com/xtls/xray-core/app/stats"
+	"github.com/xtls/xray-core/common"
+	"github.com/xtls/xray-core/common/net"
+	"github.com/xtls/xray-core/common/protocol"
+	"github.com/xtls/xray-core/common/session"
+	"github.com/xtls/xray-core/features/stats"
+	"github.com/xtls/xray-core/transport"
+	"github.com/xtls/xray-core/transport/pipe"
+)
+
+func newQuotaTestDispatcher(sm stats.Manager) *DefaultDispatcher {
+	pm, err := policy.New(context.Background(), &policy.Config{
+		Level: map[uint32]*policy.Policy{
+			0: {Quota: &policy.Policy_Quota{CloseConnections: true}},
+		},
+	})
+	common.Must(err)
+	return &DefaultDispatcher{
+		policy: pm,
+		stats:  sm,
+	}
+}
+
+func quotaTestContext(user *protocol.MemoryUser) (context.Context, *session.Inbound) {
+	inbound := &session.Inbound{
+		Tag:    "in",
+		Source: net.TCPDestination(net.LocalHostIP, 1234),
+		User:   user,
+	}
+	return session.ContextWithInbound(context.Background(), inbound), inbound
+}
+
+func TestCheckUserWithoutStats(t *testing.T) {
+	d := newQuotaTestDispatcher(stats.NoopManager{})
+
+	ctx, _ := quotaTestContext(&protocol.MemoryUser{Email: "love@xray.com", Quota: 1024})
+	if err := d.checkUser(ctx); err == nil {
+		t.Error("expected error checking quota without stats")
+	}
+
+	ctx, _ = quotaTestContext(&protocol.MemoryUser{Email: "love@xray.com", Expire: time.Now().Add(time.Hour).Unix()})
+	common.Must(d.checkUser(ctx))
+}
+
+func TestQuotaDisablesSplice(t *testing.T) {
+	sm, err := app_stats.NewManager(context.Background(), &app_stats.Config{})
+	common.Must(err)
+	d := newQuotaTestDispatcher(sm)
+
+	ctx, inbound := quotaTestContext(&protocol.MemoryUser{Email: "love@xray.com", Quota: 1024})
+	inboundLink, outboundLink := d.getLink(ctx)
+	if inbound.CanSpliceCopy != 3 {
+		t.Error("splice copy is not disabled for a user with quota")
+	}
+	if _, ok := inboundLink.Writer.(*QuotaWriter); !ok {
+		t.Error("uplink is not checked against the quota")
+	}
+	if _, ok := outboundLink.Writer.(*QuotaWriter); !ok {
+		t.Error("downlink is not checked against the quota")
+	}
+
+	ctx, inbound = quotaTestContext(&protocol.MemoryUser{Email: "xray@love.com", Expire: time.Now().Add(time.Hour).Unix()})
+	reader, writer := pipe.New(pipe.WithoutSizeLimit())
+	link := d.wrapLink(ctx, &transport.Link{Reader: reader, Writer: writer})
+	if inbound.CanSpliceCopy != 3 {
+		t.Error("splice copy is not disabled for a user with expiry")
+	}
+	if _, ok := link.Reader.(*QuotaReader); !ok {
+		t.Error("link is not checked against the expiry")
+	}
+	if r, ok := pipe.UnwrapReader(link.Reader); !ok || r != reader {
+		t.Error("failed to unwrap the pipe reader")
+	}
+}
+
+func TestWrapLinkDoesNotCount(t *testing.T) {
+	sm, err := app_stats.NewManager(context.Background(), &app_stats.Config{})
+	common.Must(err)
+	d := newQuotaTestDispatcher(sm)
+
+	ctx, _ := quotaTestContext(&protocol.MemoryUser{Email: "love@xray.com", Quota: 1024})
+	reader, writer := pipe.New(pipe.WithoutSizeLimit())
+	d.wrapLink(ctx, &transport.Link{Reader: reader, Writer: writer})
+	if c := sm.GetCounter("user>>>love@xray.com>>>traffic>>>uplink"); c != nil {
+		t.Error("unexpected counter registered for DispatchLink")
+	}
+}
@@ -0,0 +1,91 @@
+package dispatcher
+
+import (
+	"context"
+	"time"
+
+	"github.com/xtls/xray-core/common"
+	"github.com/xtls/xray-core/common/buf"
+	"golang.org/x/time/rate"
+)
+
+// waitN blocks until all limiters allow n bytes to pass. Requests larger than the burst of a limiter are split.
+func waitN(ctx context.Context, limiters []*rate.Limiter, n int) error {
+	for _, l := range limiters {
+		for remaining := n; remaining > 0; {
+			k := remaining
+			if burst := l.Burst(); burst > 0 && k > burst {
+				k = burst
+			}
+			if err := l.WaitN(ctx, k); err != nil {
+				return err
+			}
+			remaining -= k
+		}
+	}
+	return nil
+}
+
+type RateLimitWriter struct {
+	Context  context.Context
+	Limiters []*rate.Limiter
+	Writer   buf.Writer
+}
+
+func (w *RateLimitWriter) WriteMultiBuffer(mb buf.MultiBuffer) error {
+	if err := waitN(w.Context, w.Limiters, int(mb.Len())); err != nil {
+		buf.ReleaseMulti(mb)
+		return err
+	}
+	return w.Writer.WriteMultiBuffer(mb)
+}
+
+func (w *RateLimitWriter) Close() error {
+	return common.Close(w.Writer)
+}
+
+func (w *RateLimitWriter) Interrupt() {
+	common.Interrupt(w.Writer)
+}
+
+type RateLimitReader struct {
+	Context  context.Context
+	Limiters []*rate.Limiter
+	Reader   buf.Reader
+}
+
+func (r *RateLimitReader) ReadMultiBuffer() (buf.MultiBuffer, error) {
+	mb, err := r.Reader.ReadMultiBuffer()
+	return r.wait(mb, err)
+}
+
+// ReadMultiBufferTimeout implements buf.TimeoutReader. The timeout applies to reading only.
+func (r *RateLimitReader) ReadMultiBufferTimeout(timeout time.Duration) (buf.MultiBuffer, error) {
+	if tr, ok := r.Reader.(buf.TimeoutReader); ok {
+		return r.wait(tr.ReadMultiBufferTimeout(timeout))
+	}
+	return r.ReadMultiBuffer()
+}
+
+func (r *RateLimitReader) wait(mb buf.MultiBuffer, err error) (buf.MultiBuffer, error) {
+	if !mb.IsEmpty() {
+		if err := waitN(r.Context, r.Limiters, int(mb.Len())); err != nil {
+			buf.ReleaseMulti(mb)
+			return nil, err
+		}
+	}
+	return mb, err
+}
+
+func (r *RateLimitReader) Interrupt() {
+	common.Interrupt(r.Reader)
+}
+
+func (r *RateLimitReader) Close() error {
+	return common.Close(r.Reader)
+}
+
+// Unwrap returns the reader limited, for pipe.UnwrapReader.
+func (r *RateLimitReader) Unwrap() buf.Reader {
+	return r.Reader
+}
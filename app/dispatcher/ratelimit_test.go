@@ -0,0 +1,87 @@
+package dispatcher
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/xtls/xray-core/common"
+	"github.com/xtls/xray-core/common/buf"
+	"github.com/xtls/xray-core/transport/pipe"
+	"golang.org/x/time/rate"
+)
+
+// writeChunks writes n chunks of the given size, and returns how long it took.
+func writeChunks(w buf.Writer, n int, size int32) time.Duration {
+	start := time.Now()
+	for i := 0; i < n; i++ {
+		b := buf.New()
+		b.Extend(size)
+		common.Must(w.WriteMultiBuffer(buf.MultiBuffer{b}))
+	}
+	return time.Since(start)
+}
+
+func TestRateLimitWriter(t *testing.T) {
+	reader, writer := pipe.New(pipe.WithoutSizeLimit())
+	defer common.Interrupt(reader)
+	w := &RateLimitWriter{
+		Context:  context.Background(),
+		Limiters: []*rate.Limiter{rate.NewLimiter(10240, 1024)},
+		Writer:   writer,
+	}
+
+	// The first KiB passes with the burst, the other 4 KiB at 10 KiB/s.
+	if d := writeChunks(w, 5, 1024); d < 300*time.Millisecond {
+		t.Error("expect writing 5 KiB to take about 400ms, but it took ", d)
+	}
+}
+
+func TestRateLimitReader(t *testing.T) {
+	reader, writer := pipe.New(pipe.WithoutSizeLimit())
+	r := &RateLimitReader{
+		Context:  context.Background(),
+		Limiters: []*rate.Limiter{rate.NewLimiter(rate.Inf, 0), rate.NewLimiter(10240, 1024)},
+		Reader:   reader,
+	}
+	if u, ok := pipe.UnwrapReader(r); !ok || u != reader {
+		t.Error("failed to unwrap the pipe reader")
+	}
+
+	writeChunks(writer, 5, 1024)
+	common.Must(writer.Close())
+	start := time.Now()
+	var total int32
+	for {
+		mb, err := r.ReadMultiBuffer()
+		total += mb.Len()
+		buf.ReleaseMulti(mb)
+		if err != nil {
+			break
+		}
+	}
+	if total != 5*1024 {
+		t.Error("expect 5 KiB read, but got ", total)
+	}
+	if d := time.Since(start); d < 300*time.Millisecond {
+		t.Error("expect reading 5 KiB to take about 400ms, but it took ", d)
+	}
+}
+
+func TestRateLimitCanceled(t *testing.T) {
+	reader, writer := pipe.New(pipe.WithoutSizeLimit())
+	defer common.Interrupt(reader)
+	ctx, cancel := context.WithCancel(context.Background())
+	w := &RateLimitWriter{
+		Context:  ctx,
+		Limiters: []*rate.Limiter{rate.NewLimiter(1, 1)},
+		Writer:   writer,
+	}
+	cancel()
+
+	b := buf.New()
+	b.Extend(16)
+	if err := w.WriteMultiBuffer(buf.MultiBuffer{b}); err == nil {
+		t.Error("expect error writing with a canceled context")
+	}
+}
@@ -133,10 +133,35 @@ func (c compositeResult) ProtocolForDomainResult() string {
 	return c.domainResult.Protocol()
 }
 
+func (c compositeResult) Attributes() map[string]string {
+	if r, ok := c.protocolResult.(SnifferResultAttributes); ok {
+		return r.Attributes()
+	}
+	return nil
+}
+
 type SnifferResultComposite interface {
 	ProtocolForDomainResult() string
 }
 
+func (c compositeResult) ALPN() []string {
+	if r, ok := c.protocolResult.(SnifferResultALPN); ok {
+		return r.ALPN()
+	}
+	return nil
+}
+
+// SnifferResultAttributes is implemented by sniff results with attributes of the content for routing, such as
+// fingerprints of TLS client hello.
+type SnifferResultAttributes interface {
+	Attributes() map[string]string
+}
+
+// SnifferResultALPN is implemented by sniff results of TLS client hello, with the protocols offered by the client.
+type SnifferResultALPN interface {
+	ALPN() []string
+}
+
 type SnifferIsProtoSubsetOf interface {
 	IsProtoSubsetOf(protocolName string) bool
 }
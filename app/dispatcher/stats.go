@@ -1,9 +1,13 @@
 package dispatcher
 
 import (
+	"time"
+
 	"github.com/xtls/xray-core/common"
 	"github.com/xtls/xray-core/common/buf"
+	"github.com/xtls/xray-core/features/routing"
 	"github.com/xtls/xray-core/features/stats"
+	"github.com/xtls/xray-core/transport"
 )
 
 type SizeStatWriter struct {
@@ -23,3 +27,77 @@ func (w *SizeStatWriter) Close() error {
 func (w *SizeStatWriter) Interrupt() {
 	common.Interrupt(w.Writer)
 }
+
+type SizeStatReader struct {
+	Counter stats.Counter
+	Reader  buf.Reader
+}
+
+func (r *SizeStatReader) ReadMultiBuffer() (buf.MultiBuffer, error) {
+	mb, err := r.Reader.ReadMultiBuffer()
+	r.Counter.Add(int64(mb.Len()))
+	return mb, err
+}
+
+// ReadMultiBufferTimeout implements buf.TimeoutReader.
+func (r *SizeStatReader) ReadMultiBufferTimeout(timeout time.Duration) (buf.MultiBuffer, error) {
+	if tr, ok := r.Reader.(buf.TimeoutReader); ok {
+		mb, err := tr.ReadMultiBufferTimeout(timeout)
+		r.Counter.Add(int64(mb.Len()))
+		return mb, err
+	}
+	return r.ReadMultiBuffer()
+}
+
+func (r *SizeStatReader) Interrupt() {
+	common.Interrupt(r.Reader)
+}
+
+func (r *SizeStatReader) Close() error {
+	return common.Close(r.Reader)
+}
+
+// Unwrap returns the reader counted, for pipe.UnwrapReader.
+func (r *SizeStatReader) Unwrap() buf.Reader {
+	return r.Reader
+}
+
+// countRouteTraffic wraps the outbound link to count its traffic in the counters of the route.
+func countRouteTraffic(route routing.TrafficCountedRoute, link *transport.Link) *transport.Link {
+	uplink, downlink := route.GetTrafficCounters()
+	wrapped := &transport.Link{
+		Reader: link.Reader,
+		Writer: link.Writer,
+	}
+	if uplink != nil {
+		wrapped.Reader = &SizeStatReader{
+			Counter: uplink,
+			Reader:  wrapped.Reader,
+		}
+	}
+	if downlink != nil {
+		wrapped.Writer = &SizeStatWriter{
+			Counter: downlink,
+			Writer:  wrapped.Writer,
+		}
+	}
+	return wrapped
+}
+
+// AddSplicedSize adds n to the counters of the SizeStatWriters in the chain of writer, for the traffic that is
+// copied by splice, bypassing the writers.
+func AddSplicedSize(writer buf.Writer, n int64) {
+	for writer != nil {
+		switch w := writer.(type) {
+		case *SizeStatWriter:
+			w.Counter.Add(n)
+			writer = w.Writer
+		case *QuotaWriter:
+			writer = w.Writer
+		case *trackedWriter:
+			writer = w.Writer
+		default:
+			return
+		}
+	}
+}
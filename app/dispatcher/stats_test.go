@@ -2,10 +2,12 @@ package dispatcher_test
 
 import (
 	"testing"
+	"time"
 
 	. "github.com/xtls/xray-core/app/dispatcher"
 	"github.com/xtls/xray-core/common"
 	"github.com/xtls/xray-core/common/buf"
+	"github.com/xtls/xray-core/common/protocol"
 )
 
 type TestCounter int64
@@ -42,3 +44,35 @@ func TestStatsWriter(t *testing.T) {
 		t.Fatal("unexpected counter value. want 7, but got ", c.Value())
 	}
 }
+
+func TestQuotaWriter(t *testing.T) {
+	var uplink, downlink TestCounter
+	user := &protocol.MemoryUser{
+		Email: "test",
+		Quota: 8,
+	}
+	writer := &QuotaWriter{
+		User:     user,
+		Uplink:   &uplink,
+		Downlink: &downlink,
+		Writer: &SizeStatWriter{
+			Counter: &downlink,
+			Writer:  buf.Discard,
+		},
+	}
+
+	common.Must(writer.WriteMultiBuffer(buf.MergeBytes(nil, []byte("abcd"))))
+	uplink.Add(4)
+	if err := writer.WriteMultiBuffer(buf.MergeBytes(nil, []byte("efg"))); err == nil {
+		t.Fatal("expected quota error")
+	}
+
+	uplink.Set(0)
+	downlink.Set(0)
+	common.Must(writer.WriteMultiBuffer(buf.MergeBytes(nil, []byte("efg"))))
+
+	user.Expire = time.Now().Add(-time.Minute).Unix()
+	if err := writer.WriteMultiBuffer(buf.MergeBytes(nil, []byte("h"))); err == nil {
+		t.Fatal("expected expiry error")
+	}
+}
@@ -10,10 +10,34 @@ import (
 	"github.com/xtls/xray-core/common/task"
 	dns_feature "github.com/xtls/xray-core/features/dns"
 	"golang.org/x/net/dns/dnsmessage"
+	"strconv"
 	"sync"
+	"sync/atomic"
 	"time"
 )
 
+const (
+	// The TTL of answers from expired records, as recommended by RFC 8767.
+	staleAnswerTTL = 30
+	// The default of how long expired records are served, within the 1 to 3 days recommended by RFC 8767.
+	defaultMaxStale = 24 * time.Hour
+	// Records are prefetched when hit at least prefetchMinHits times, and less than 1/prefetchWindow of their TTL
+	// is left.
+	prefetchMinHits = 3
+	prefetchWindow  = 10
+	// The timeout of queries refreshing records in background.
+	refreshTimeout = 5 * time.Second
+)
+
+// CachePolicy is how a name server keeps records around their expiry.
+type CachePolicy struct {
+	// Serving expired records for at most MaxStale, while refreshing them in background.
+	ServeStale bool
+	MaxStale   time.Duration
+	// Refreshing records hit frequently in background shortly before they expire.
+	Prefetch bool
+}
+
 type CacheController struct {
 	sync.RWMutex
 	ips          map[string]*record
@@ -21,6 +45,20 @@ type CacheController struct {
 	cacheCleanup *task.Periodic
 	name         string
 	disableCache bool
+
+	policy CachePolicy
+	// refresh sends queries to refresh the records of a domain, and refreshing holds the domains being refreshed.
+	refresh    func(ctx context.Context, noResponseErrCh chan<- error, domain string, option dns_feature.IPOption)
+	refreshing map[string]bool
+
+	hits   atomic.Uint64
+	misses atomic.Uint64
+}
+
+// CacheStats contains the number of queries answered from and missing the cache of a name server.
+type CacheStats struct {
+	Hits   uint64
+	Misses uint64
 }
 
 func NewCacheController(name string, disableCache bool) *CacheController {
@@ -29,6 +67,7 @@ func NewCacheController(name string, disableCache bool) *CacheController {
 		disableCache: disableCache,
 		ips:          make(map[string]*record),
 		pub:          pubsub.NewService(),
+		refreshing:   make(map[string]bool),
 	}
 
 	c.cacheCleanup = &task.Periodic{
@@ -38,10 +77,35 @@ func NewCacheController(name string, disableCache bool) *CacheController {
 	return c
 }
 
+// setPolicy sets the cache policy, with refresh to send queries for refreshing records.
+func (c *CacheController) setPolicy(policy CachePolicy, refresh func(context.Context, chan<- error, string, dns_feature.IPOption)) {
+	if policy.ServeStale && policy.MaxStale <= 0 {
+		policy.MaxStale = defaultMaxStale
+	}
+	c.Lock()
+	defer c.Unlock()
+	c.policy = policy
+	c.refresh = refresh
+}
+
+// expiryHorizon returns the time before which expired records are no longer kept.
+func (c *CacheController) expiryHorizon() time.Time {
+	now := time.Now()
+	if c.policy.ServeStale {
+		now = now.Add(-c.policy.MaxStale)
+	}
+	return now
+}
+
+// Close stops the cleanup of the cache.
+func (c *CacheController) Close() error {
+	return c.cacheCleanup.Close()
+}
+
 // CacheCleanup clears expired items from cache
 func (c *CacheController) CacheCleanup() error {
-	now := time.Now()
 	c.Lock()
+	now := c.expiryHorizon()
 	defer c.Unlock()
 
 	if len(c.ips) == 0 {
@@ -55,8 +119,13 @@ func (c *CacheController) CacheCleanup() error {
 		if record.AAAA != nil && record.AAAA.Expire.Before(now) {
 			record.AAAA = nil
 		}
+		for t, r := range record.others {
+			if r.Expire.Before(now) {
+				delete(record.others, t)
+			}
+		}
 
-		if record.A == nil && record.AAAA == nil {
+		if record.A == nil && record.AAAA == nil && len(record.others) == 0 {
 			errors.LogDebug(context.Background(), c.name, "cache cleanup ", domain)
 			delete(c.ips, domain)
 		} else {
@@ -80,12 +149,28 @@ func (c *CacheController) updateIP(req *dnsRequest, ipRec *IPRecord) {
 		rec = &record{}
 	}
 
+	// Other answers, like CNAME, are kept only in records of types other than A and AAAA.
 	switch req.reqType {
 	case dnsmessage.TypeA:
+		ipRec.Answers = nil
 		rec.A = ipRec
 	case dnsmessage.TypeAAAA:
+		ipRec.Answers = nil
 		rec.AAAA = ipRec
+	default:
+		if rec.others == nil {
+			rec.others = make(map[dnsmessage.Type]*IPRecord)
+		}
+		rec.others[req.reqType] = ipRec
+		errors.LogInfo(context.Background(), c.name, " got answer: ", req.domain, " ", req.reqType, " -> ", len(ipRec.Answers), " records ", elapsed)
+		c.ips[req.domain] = rec
+		c.pub.Publish(recordTopic(req.domain, req.reqType), nil)
+		c.Unlock()
+		common.Must(c.cacheCleanup.Start())
+		return
 	}
+	rec.updated = time.Now()
+	rec.hits.Store(0)
 
 	errors.LogInfo(context.Background(), c.name, " got answer: ", req.domain, " ", req.reqType, " -> ", ipRec.IP, " ", elapsed)
 	c.ips[req.domain] = rec
@@ -121,7 +206,10 @@ func (c *CacheController) findIPsForDomain(domain string, option dns_feature.IPO
 	if !found {
 		return nil, 0, errRecordNotFound
 	}
+	return record.getIPs(option)
+}
 
+func (r *record) getIPs(option dns_feature.IPOption) ([]net.IP, uint32, error) {
 	var errs []error
 	var allIPs []net.IP
 	var rTTL uint32 = dns_feature.DefaultTTL
@@ -129,7 +217,7 @@ func (c *CacheController) findIPsForDomain(domain string, option dns_feature.IPO
 	mergeReq := option.IPv4Enable && option.IPv6Enable
 
 	if option.IPv4Enable {
-		ips, ttl, err := record.A.getIPs()
+		ips, ttl, err := r.A.getIPs()
 		if !mergeReq || go_errors.Is(err, errRecordNotFound) {
 			return ips, ttl, err
 		}
@@ -144,7 +232,7 @@ func (c *CacheController) findIPsForDomain(domain string, option dns_feature.IPO
 	}
 
 	if option.IPv6Enable {
-		ips, ttl, err := record.AAAA.getIPs()
+		ips, ttl, err := r.AAAA.getIPs()
 		if !mergeReq || go_errors.Is(err, errRecordNotFound) {
 			return ips, ttl, err
 		}
@@ -167,6 +255,91 @@ func (c *CacheController) findIPsForDomain(domain string, option dns_feature.IPO
 	return nil, rTTL, errors.Combine(errs...)
 }
 
+// findCachedIPsForDomain is findIPsForDomain for lookups that may be answered from the cache. It counts cache hits and misses.
+// Depending on the cache policy, expired records are answered, and records are refreshed in background.
+func (c *CacheController) findCachedIPsForDomain(domain string, option dns_feature.IPOption) ([]net.IP, uint32, error) {
+	c.RLock()
+	rec, found := c.ips[domain]
+	policy := c.policy
+	c.RUnlock()
+
+	if !found {
+		c.misses.Add(1)
+		return nil, 0, errRecordNotFound
+	}
+
+	ips, ttl, err := rec.getIPs(option)
+	if !go_errors.Is(err, errRecordNotFound) {
+		c.hits.Add(1)
+		if policy.Prefetch && rec.hits.Add(1) >= prefetchMinHits && rec.expiring(option) {
+			c.refreshInBackground(domain, option)
+		}
+		return ips, ttl, err
+	}
+
+	if policy.ServeStale {
+		ips, ttl, err := rec.stale(policy.MaxStale).getIPs(option)
+		if !go_errors.Is(err, errRecordNotFound) {
+			c.hits.Add(1)
+			errors.LogDebug(context.Background(), c.name, " serving stale ", domain)
+			c.refreshInBackground(domain, option)
+			return ips, ttl, err
+		}
+	}
+
+	c.misses.Add(1)
+	return nil, 0, errRecordNotFound
+}
+
+// refreshInBackground queries the records of domain in background, unless they are being refreshed.
+func (c *CacheController) refreshInBackground(domain string, option dns_feature.IPOption) {
+	c.Lock()
+	if c.refresh == nil || c.refreshing[domain] {
+		c.Unlock()
+		return
+	}
+	c.refreshing[domain] = true
+	c.Unlock()
+
+	go func() {
+		defer func() {
+			c.Lock()
+			delete(c.refreshing, domain)
+			c.Unlock()
+		}()
+
+		ctx, cancel := context.WithTimeout(context.Background(), refreshTimeout)
+		defer cancel()
+
+		sub4, sub6 := c.registerSubscribers(domain, option)
+		defer closeSubscribers(sub4, sub6)
+
+		errors.LogDebug(ctx, c.name, " refreshing ", domain)
+		noResponseErrCh := make(chan error, 2)
+		c.refresh(ctx, noResponseErrCh, domain, option)
+		for _, sub := range []*pubsub.Subscriber{sub4, sub6} {
+			if sub == nil {
+				continue
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case err := <-noResponseErrCh:
+				errors.LogInfoInner(ctx, err, c.name, " failed to refresh ", domain)
+				return
+			case <-sub.Wait():
+			}
+		}
+	}()
+}
+
+func (c *CacheController) cacheStats() CacheStats {
+	return CacheStats{
+		Hits:   c.hits.Load(),
+		Misses: c.misses.Load(),
+	}
+}
+
 func (c *CacheController) registerSubscribers(domain string, option dns_feature.IPOption) (sub4 *pubsub.Subscriber, sub6 *pubsub.Subscriber) {
 	// ipv4 and ipv6 belong to different subscription groups
 	if option.IPv4Enable {
@@ -178,6 +351,53 @@ func (c *CacheController) registerSubscribers(domain string, option dns_feature.
 	return
 }
 
+// recordTopic is the subscription of qType records of domain, other than A and AAAA.
+func recordTopic(domain string, qType dnsmessage.Type) string {
+	return domain + "#" + strconv.Itoa(int(qType))
+}
+
+// findRecordsForDomain is findIPsForDomain for records other than A and AAAA.
+func (c *CacheController) findRecordsForDomain(domain string, qType dnsmessage.Type) ([]dnsmessage.Resource, uint32, error) {
+	c.RLock()
+	var r *IPRecord
+	if rec, found := c.ips[domain]; found {
+		r = rec.others[qType]
+	}
+	c.RUnlock()
+	return r.getAnswers()
+}
+
+// queryRecords is QueryIP of name servers for records other than A and AAAA. It answers from the cache, or calls
+// send to send the query and waits for the answer.
+func (c *CacheController) queryRecords(ctx context.Context, domain string, qType dnsmessage.Type, send func(ctx context.Context, noResponseErrCh chan<- error, fqdn string)) ([]dnsmessage.Resource, uint32, error) {
+	fqdn := Fqdn(domain)
+	sub := c.pub.Subscribe(recordTopic(fqdn, qType))
+	defer sub.Close()
+
+	if c.disableCache {
+		errors.LogDebug(ctx, "DNS cache is disabled. Querying ", qType, " records for ", domain, " at ", c.name)
+	} else {
+		answers, ttl, err := c.findRecordsForDomain(fqdn, qType)
+		if !go_errors.Is(err, errRecordNotFound) {
+			c.hits.Add(1)
+			errors.LogDebugInner(ctx, err, c.name, " cache HIT ", domain, " ", qType, " -> ", len(answers), " records")
+			return answers, ttl, err
+		}
+		c.misses.Add(1)
+	}
+
+	noResponseErrCh := make(chan error, 1)
+	send(ctx, noResponseErrCh, fqdn)
+	select {
+	case <-ctx.Done():
+		return nil, 0, ctx.Err()
+	case err := <-noResponseErrCh:
+		return nil, 0, err
+	case <-sub.Wait():
+	}
+	return c.findRecordsForDomain(fqdn, qType)
+}
+
 func closeSubscribers(sub4 *pubsub.Subscriber, sub6 *pubsub.Subscriber) {
 	if sub4 != nil {
 		sub4.Close()
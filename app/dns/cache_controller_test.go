@@ -0,0 +1,114 @@
+package dns
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/xtls/xray-core/common/net"
+	dns_feature "github.com/xtls/xray-core/features/dns"
+	"golang.org/x/net/dns/dnsmessage"
+)
+
+func newTestCacheController(policy CachePolicy, ip net.IP) (*CacheController, *atomic.Int32) {
+	c := NewCacheController("test", false)
+	var refreshed atomic.Int32
+	c.setPolicy(policy, func(_ context.Context, _ chan<- error, domain string, _ dns_feature.IPOption) {
+		refreshed.Add(1)
+		c.updateIP(&dnsRequest{reqType: dnsmessage.TypeA, domain: domain, start: time.Now()}, &IPRecord{
+			IP:     []net.IP{ip},
+			Expire: time.Now().Add(time.Minute),
+		})
+	})
+	return c, &refreshed
+}
+
+func waitForRefresh(c *CacheController, domain string) {
+	for i := 0; i < 100; i++ {
+		c.RLock()
+		refreshing := c.refreshing[domain]
+		c.RUnlock()
+		if !refreshing {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestCacheServeStale(t *testing.T) {
+	c, refreshed := newTestCacheController(CachePolicy{ServeStale: true, MaxStale: time.Hour}, net.IP{2, 2, 2, 2})
+	option := dns_feature.IPOption{IPv4Enable: true}
+
+	c.updateIP(&dnsRequest{reqType: dnsmessage.TypeA, domain: "example.com.", start: time.Now()}, &IPRecord{
+		IP:     []net.IP{{1, 1, 1, 1}},
+		Expire: time.Now().Add(-time.Minute),
+	})
+	ips, ttl, err := c.findCachedIPsForDomain("example.com.", option)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r := cmp.Diff(ips, []net.IP{{1, 1, 1, 1}}); r != "" {
+		t.Error(r)
+	}
+	if ttl != staleAnswerTTL {
+		t.Error("expect TTL ", staleAnswerTTL, " of stale answers, but got ", ttl)
+	}
+
+	waitForRefresh(c, "example.com.")
+	if n := refreshed.Load(); n != 1 {
+		t.Error("expect the record to be refreshed once, but got ", n)
+	}
+	ips, _, err = c.findCachedIPsForDomain("example.com.", option)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r := cmp.Diff(ips, []net.IP{{2, 2, 2, 2}}); r != "" {
+		t.Error(r)
+	}
+}
+
+func TestCacheServeStaleExpired(t *testing.T) {
+	c, refreshed := newTestCacheController(CachePolicy{ServeStale: true, MaxStale: time.Hour}, net.IP{2, 2, 2, 2})
+
+	c.updateIP(&dnsRequest{reqType: dnsmessage.TypeA, domain: "example.com.", start: time.Now()}, &IPRecord{
+		IP:     []net.IP{{1, 1, 1, 1}},
+		Expire: time.Now().Add(-2 * time.Hour),
+	})
+	if _, _, err := c.findCachedIPsForDomain("example.com.", dns_feature.IPOption{IPv4Enable: true}); err != errRecordNotFound {
+		t.Error("expect record not found, but got ", err)
+	}
+	if n := refreshed.Load(); n != 0 {
+		t.Error("expect no refreshing, but got ", n)
+	}
+}
+
+func TestCachePrefetch(t *testing.T) {
+	c, refreshed := newTestCacheController(CachePolicy{Prefetch: true}, net.IP{2, 2, 2, 2})
+	option := dns_feature.IPOption{IPv4Enable: true}
+
+	c.updateIP(&dnsRequest{reqType: dnsmessage.TypeA, domain: "example.com.", start: time.Now()}, &IPRecord{
+		IP:     []net.IP{{1, 1, 1, 1}},
+		Expire: time.Now().Add(time.Hour),
+	})
+	for i := 0; i < prefetchMinHits; i++ {
+		if _, _, err := c.findCachedIPsForDomain("example.com.", option); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if n := refreshed.Load(); n != 0 {
+		t.Error("expect no prefetching of records far from expiry, but got ", n)
+	}
+
+	c.Lock()
+	c.ips["example.com."].updated = time.Now().Add(-24 * time.Hour)
+	c.Unlock()
+	if _, _, err := c.findCachedIPsForDomain("example.com.", option); err != nil {
+		t.Fatal(err)
+	}
+	waitForRefresh(c, "example.com.")
+	if n := refreshed.Load(); n != 1 {
+		t.Error("expect the record to be prefetched once, but got ", n)
+	}
+}
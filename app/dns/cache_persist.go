@@ -0,0 +1,176 @@
+package dns
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"time"
+
+	"github.com/xtls/xray-core/common"
+	"github.com/xtls/xray-core/common/errors"
+	"github.com/xtls/xray-core/common/net"
+	"github.com/xtls/xray-core/common/platform/filesystem"
+	"golang.org/x/net/dns/dnsmessage"
+)
+
+type persistedIPRecord struct {
+	IP     []net.IP         `json:"ip,omitempty"`
+	Expire time.Time        `json:"expire"`
+	RCode  dnsmessage.RCode `json:"rcode,omitempty"`
+}
+
+type persistedRecord struct {
+	A    *persistedIPRecord `json:"a,omitempty"`
+	AAAA *persistedIPRecord `json:"aaaa,omitempty"`
+}
+
+// persistedCache is the snapshot of the caches of all name servers, keyed by the name of the name server and then
+// the domain. Records are saved with their time of expiry, so that their TTL goes down while xray is not running.
+type persistedCache struct {
+	Servers map[string]map[string]*persistedRecord `json:"servers"`
+}
+
+func persistIPRecord(r *IPRecord, horizon time.Time) *persistedIPRecord {
+	if r == nil || r.Expire.Before(horizon) {
+		return nil
+	}
+	return &persistedIPRecord{
+		IP:     r.IP,
+		Expire: r.Expire,
+		RCode:  r.RCode,
+	}
+}
+
+func restoreIPRecord(r *persistedIPRecord, horizon time.Time) *IPRecord {
+	if r == nil || r.Expire.Before(horizon) {
+		return nil
+	}
+	return &IPRecord{
+		IP:     r.IP,
+		Expire: r.Expire,
+		RCode:  r.RCode,
+	}
+}
+
+// snapshot returns the records in the cache, except those no longer kept.
+func (c *CacheController) snapshot() map[string]*persistedRecord {
+	c.RLock()
+	defer c.RUnlock()
+
+	horizon := c.expiryHorizon()
+	records := make(map[string]*persistedRecord)
+	for domain, rec := range c.ips {
+		saved := &persistedRecord{
+			A:    persistIPRecord(rec.A, horizon),
+			AAAA: persistIPRecord(rec.AAAA, horizon),
+		}
+		if saved.A != nil || saved.AAAA != nil {
+			records[domain] = saved
+		}
+	}
+	return records
+}
+
+// restore adds the records to the cache, except those no longer kept. Records already in the cache are kept.
+func (c *CacheController) restore(records map[string]*persistedRecord) int {
+	if c.disableCache || len(records) == 0 {
+		return 0
+	}
+
+	c.Lock()
+	horizon := c.expiryHorizon()
+	restored := 0
+	for domain, saved := range records {
+		if _, found := c.ips[domain]; found {
+			continue
+		}
+		rec := &record{
+			A:       restoreIPRecord(saved.A, horizon),
+			AAAA:    restoreIPRecord(saved.AAAA, horizon),
+			updated: time.Now(),
+		}
+		if rec.A != nil || rec.AAAA != nil {
+			c.ips[domain] = rec
+			restored++
+		}
+	}
+	c.Unlock()
+
+	if restored > 0 {
+		common.Must(c.cacheCleanup.Start())
+	}
+	return restored
+}
+
+// cacheSnapshot returns the snapshot of the caches of all name servers.
+func (s *DNS) cacheSnapshot() *persistedCache {
+	clients := s.state.Load().clients
+
+	snapshot := &persistedCache{
+		Servers: make(map[string]map[string]*persistedRecord),
+	}
+	for _, client := range clients {
+		cs, ok := client.server.(cachedServer)
+		if !ok {
+			continue
+		}
+		records := snapshot.Servers[client.Name()]
+		if records == nil {
+			records = make(map[string]*persistedRecord)
+			snapshot.Servers[client.Name()] = records
+		}
+		for domain, rec := range cs.cache().snapshot() {
+			records[domain] = rec
+		}
+	}
+	return snapshot
+}
+
+// restoreCache restores the caches of the name servers from the snapshot, and returns the number of restored records.
+func (s *DNS) restoreCache(snapshot *persistedCache) int {
+	restored := 0
+	for _, client := range s.state.Load().clients {
+		if cs, ok := client.server.(cachedServer); ok {
+			restored += cs.cache().restore(snapshot.Servers[client.Name()])
+		}
+	}
+	return restored
+}
+
+// loadCache restores the caches of the name servers from the persist file.
+func (s *DNS) loadCache() error {
+	data, err := os.ReadFile(s.persistPath)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	var snapshot persistedCache
+	if err := json.Unmarshal(data, &snapshot); err != nil {
+		return err
+	}
+	restored := s.restoreCache(&snapshot)
+	errors.LogInfo(context.Background(), "restored ", restored, " DNS records from ", s.persistPath)
+	return nil
+}
+
+// saveCache writes the caches of all name servers to the persist file.
+func (s *DNS) saveCache() error {
+	data, err := json.Marshal(s.cacheSnapshot())
+	if err != nil {
+		return err
+	}
+	if err := filesystem.WriteFileAtomic(s.persistPath, data); err != nil {
+		return errors.New("failed to save DNS cache").Base(err)
+	}
+	return nil
+}
+
+// persistCache runs periodically to snapshot the caches, so that most of them survive a crash as well.
+func (s *DNS) persistCache() error {
+	if err := s.saveCache(); err != nil {
+		errors.LogWarningInner(context.Background(), err, "failed to persist DNS cache to ", s.persistPath)
+	}
+	return nil
+}
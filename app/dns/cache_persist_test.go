@@ -0,0 +1,69 @@
+package dns
+
+import (
+	"net/url"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/xtls/xray-core/common"
+	"github.com/xtls/xray-core/common/net"
+	dns_feature "github.com/xtls/xray-core/features/dns"
+	"golang.org/x/net/dns/dnsmessage"
+)
+
+func newTestPersistDNS(path string) (*DNS, *CacheController) {
+	u, err := url.Parse("tcp+local://127.0.0.1")
+	common.Must(err)
+	server, err := NewTCPLocalNameServer(u, false, nil)
+	common.Must(err)
+	d := &DNS{
+		persistPath: path,
+	}
+	d.state.Store(&dnsState{clients: []*Client{{server: server}}})
+	return d, server.cacheController
+}
+
+func TestPersistCache(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "dns.json")
+	d, c := newTestPersistDNS(path)
+
+	expire := time.Now().Add(time.Hour).Round(0)
+	c.updateIP(&dnsRequest{reqType: dnsmessage.TypeA, domain: "example.com.", start: time.Now()}, &IPRecord{
+		IP:     []net.IP{{1, 1, 1, 1}},
+		Expire: expire,
+	})
+	c.updateIP(&dnsRequest{reqType: dnsmessage.TypeAAAA, domain: "example.com.", start: time.Now()}, &IPRecord{
+		RCode:  dnsmessage.RCodeSuccess,
+		Expire: expire,
+	})
+	c.updateIP(&dnsRequest{reqType: dnsmessage.TypeA, domain: "expired.com.", start: time.Now()}, &IPRecord{
+		IP:     []net.IP{{2, 2, 2, 2}},
+		Expire: time.Now().Add(-time.Second),
+	})
+	common.Must(d.saveCache())
+
+	d, c = newTestPersistDNS(path)
+	common.Must(d.loadCache())
+
+	ips, ttl, err := c.findIPsForDomain("example.com.", dns_feature.IPOption{IPv4Enable: true})
+	common.Must(err)
+	if r := cmp.Diff(ips, []net.IP{{1, 1, 1, 1}}); r != "" {
+		t.Error(r)
+	}
+	if ttl < 3590 || ttl > 3601 {
+		t.Error("expect the TTL to be kept, but got ", ttl)
+	}
+	if _, _, err := c.findIPsForDomain("example.com.", dns_feature.IPOption{IPv6Enable: true}); err != dns_feature.ErrEmptyResponse {
+		t.Error("expect empty response, but got ", err)
+	}
+	if _, _, err := c.findIPsForDomain("expired.com.", dns_feature.IPOption{IPv4Enable: true}); err != errRecordNotFound {
+		t.Error("expect expired records to be dropped, but got ", err)
+	}
+}
+
+func TestPersistCacheMissingFile(t *testing.T) {
+	d, _ := newTestPersistDNS(filepath.Join(t.TempDir(), "dns.json"))
+	common.Must(d.loadCache())
+}
@@ -7,25 +7,36 @@ import (
 	"fmt"
 	"sort"
 	"strings"
-	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/xtls/xray-core/common"
 	"github.com/xtls/xray-core/common/errors"
 	"github.com/xtls/xray-core/common/net"
 	"github.com/xtls/xray-core/common/session"
 	"github.com/xtls/xray-core/common/strmatcher"
+	"github.com/xtls/xray-core/common/task"
 	"github.com/xtls/xray-core/features/dns"
+	"golang.org/x/net/dns/dnsmessage"
 )
 
 // DNS is a DNS rely server.
 type DNS struct {
-	sync.Mutex
+	ctx context.Context
+	// state is replaced as a whole on reload, so that lookups see a consistent config without locking.
+	state atomic.Pointer[dnsState]
+
+	persistPath string
+	persister   *task.Periodic
+}
+
+// dnsState is the config of DNS which is replaced on reload. It is not modified after creation.
+type dnsState struct {
 	disableFallback        bool
 	disableFallbackIfMatch bool
 	ipOption               *dns.IPOption
 	hosts                  *StaticHosts
 	clients                []*Client
-	ctx                    context.Context
 	domainMatcher          strmatcher.IndexMatcher
 	matcherInfos           []*DomainMatcherInfo
 	checkSystem            bool
@@ -140,17 +151,36 @@ func New(ctx context.Context, config *Config) (*DNS, error) {
 		clients = append(clients, NewLocalDNSClient(ipOption))
 	}
 
-	return &DNS{
+	d := &DNS{
+		ctx: ctx,
+	}
+	d.state.Store(&dnsState{
 		hosts:                  hosts,
 		ipOption:               &ipOption,
 		clients:                clients,
-		ctx:                    ctx,
 		domainMatcher:          domainMatcher,
 		matcherInfos:           matcherInfos,
 		disableFallback:        config.DisableFallback,
 		disableFallbackIfMatch: config.DisableFallbackIfMatch,
 		checkSystem:            checkSystem,
-	}, nil
+	})
+
+	if len(config.CachePersistPath) > 0 {
+		d.persistPath = config.CachePersistPath
+		if err := d.loadCache(); err != nil {
+			return nil, errors.New("failed to load DNS cache from ", d.persistPath).Base(err)
+		}
+		interval := time.Minute
+		if config.CachePersistInterval > 0 {
+			interval = time.Duration(config.CachePersistInterval) * time.Second
+		}
+		d.persister = &task.Periodic{
+			Interval: interval,
+			Execute:  d.persistCache,
+		}
+	}
+
+	return d, nil
 }
 
 // Type implements common.HasType.
@@ -160,11 +190,50 @@ func (*DNS) Type() interface{} {
 
 // Start implements common.Runnable.
 func (s *DNS) Start() error {
+	if s.persister != nil {
+		return s.persister.Start()
+	}
 	return nil
 }
 
 // Close implements common.Closable.
 func (s *DNS) Close() error {
+	if s.persister != nil {
+		s.persister.Close()
+		if err := s.saveCache(); err != nil {
+			errors.LogWarningInner(context.Background(), err, "failed to persist DNS cache to ", s.persistPath)
+		}
+	}
+	return nil
+}
+
+// Reload implements features.Reloadable.
+func (s *DNS) Reload(config interface{}) error {
+	c, ok := config.(*Config)
+	if !ok {
+		return errors.New("Reload: config type error")
+	}
+	n, err := New(s.ctx, c)
+	if err != nil {
+		return err
+	}
+	// Records cached by the name servers kept in the new config stay. The persist file is not changed until restart.
+	n.restoreCache(s.cacheSnapshot())
+
+	old := s.state.Swap(n.state.Load())
+
+	// The replaced clients are closed after the queries in flight on them time out.
+	var timeout time.Duration
+	for _, client := range old.clients {
+		timeout = max(timeout, client.timeoutMs)
+	}
+	time.AfterFunc(timeout, func() {
+		for _, client := range old.clients {
+			if err := client.Close(); err != nil {
+				errors.LogInfoInner(s.ctx, err, "failed to close replaced DNS client ", client.Name())
+			}
+		}
+	})
 	return nil
 }
 
@@ -174,7 +243,7 @@ func (s *DNS) IsOwnLink(ctx context.Context) bool {
 	if inbound == nil {
 		return false
 	}
-	for _, client := range s.clients {
+	for _, client := range s.state.Load().clients {
 		if client.tag == inbound.Tag {
 			return true
 		}
@@ -182,6 +251,21 @@ func (s *DNS) IsOwnLink(ctx context.Context) bool {
 	return false
 }
 
+// CacheStats returns the cache hits and misses of the name servers with a cache, keyed by name server.
+func (s *DNS) CacheStats() map[string]CacheStats {
+	result := make(map[string]CacheStats)
+	for _, client := range s.state.Load().clients {
+		if cs, ok := client.server.(cachedServer); ok {
+			stats := result[client.Name()]
+			c := cs.cache().cacheStats()
+			stats.Hits += c.Hits
+			stats.Misses += c.Misses
+			result[client.Name()] = stats
+		}
+	}
+	return result
+}
+
 // LookupIP implements dns.Client.
 func (s *DNS) LookupIP(domain string, option dns.IPOption) ([]net.IP, uint32, error) {
 	// Normalize the FQDN form query
@@ -190,13 +274,14 @@ func (s *DNS) LookupIP(domain string, option dns.IPOption) ([]net.IP, uint32, er
 		return nil, 0, errors.New("empty domain name")
 	}
 
-	if s.checkSystem {
+	st := s.state.Load()
+	if st.checkSystem {
 		supportIPv4, supportIPv6 := checkSystemNetwork()
 		option.IPv4Enable = option.IPv4Enable && supportIPv4
 		option.IPv6Enable = option.IPv6Enable && supportIPv6
 	} else {
-		option.IPv4Enable = option.IPv4Enable && s.ipOption.IPv4Enable
-		option.IPv6Enable = option.IPv6Enable && s.ipOption.IPv6Enable
+		option.IPv4Enable = option.IPv4Enable && st.ipOption.IPv4Enable
+		option.IPv6Enable = option.IPv6Enable && st.ipOption.IPv6Enable
 	}
 
 	if !option.IPv4Enable && !option.IPv6Enable {
@@ -204,7 +289,7 @@ func (s *DNS) LookupIP(domain string, option dns.IPOption) ([]net.IP, uint32, er
 	}
 
 	// Static host lookup
-	switch addrs, err := s.hosts.Lookup(domain, option); {
+	switch addrs, err := st.hosts.Lookup(domain, option); {
 	case err != nil:
 		if go_errors.Is(err, dns.ErrEmptyResponse) {
 			return nil, 0, dns.ErrEmptyResponse
@@ -228,7 +313,7 @@ func (s *DNS) LookupIP(domain string, option dns.IPOption) ([]net.IP, uint32, er
 
 	// Name servers lookup
 	var errs []error
-	for _, client := range s.sortClients(domain) {
+	for _, client := range s.sortClients(st, domain) {
 		if !option.FakeEnable && strings.EqualFold(client.Name(), "FakeDNS") {
 			errors.LogDebug(s.ctx, "skip DNS resolution for domain ", domain, " at server ", client.Name())
 			continue
@@ -268,21 +353,78 @@ func (s *DNS) LookupIP(domain string, option dns.IPOption) ([]net.IP, uint32, er
 	return nil, 0, dns.ErrEmptyResponse
 }
 
-func (s *DNS) sortClients(domain string) []*Client {
-	clients := make([]*Client, 0, len(s.clients))
-	clientUsed := make([]bool, len(s.clients))
-	clientNames := make([]string, 0, len(s.clients))
+// LookupRecords implements dns.RecordClient.
+func (s *DNS) LookupRecords(domain string, qType dnsmessage.Type) ([]dnsmessage.Resource, uint32, error) {
+	// Normalize the FQDN form query
+	domain = strings.TrimSuffix(domain, ".")
+	if domain == "" {
+		return nil, 0, errors.New("empty domain name")
+	}
+
+	st := s.state.Load()
+	// Static hosts only replace the domain, as they hold no records other than A and AAAA.
+	if addrs, _ := st.hosts.Lookup(domain, dns.IPOption{IPv4Enable: true, IPv6Enable: true}); len(addrs) == 1 && addrs[0].Family().IsDomain() {
+		errors.LogInfo(s.ctx, "domain replaced: ", domain, " -> ", addrs[0].Domain())
+		domain = addrs[0].Domain()
+	}
+
+	// Name servers lookup
+	var errs []error
+	for _, client := range s.sortClients(st, domain) {
+		if _, ok := client.server.(recordServer); !ok {
+			errors.LogDebug(s.ctx, "skip ", qType, " query for domain ", domain, " at server ", client.Name())
+			continue
+		}
+
+		answers, ttl, err := client.QueryRecords(s.ctx, domain, qType)
+
+		if len(answers) > 0 {
+			if ttl == 0 {
+				ttl = 1
+			}
+			return answers, ttl, nil
+		}
+
+		errors.LogInfoInner(s.ctx, err, "failed to lookup ", qType, " records for domain ", domain, " at server ", client.Name())
+		if err == nil {
+			err = dns.ErrEmptyResponse
+		}
+		errs = append(errs, err)
+
+		if client.IsFinalQuery() {
+			break
+		}
+	}
+
+	if len(errs) > 0 {
+		allErrs := errors.Combine(errs...)
+		err0 := errs[0]
+		if errors.AllEqual(err0, allErrs) {
+			if go_errors.Is(err0, dns.ErrEmptyResponse) {
+				return nil, 0, dns.ErrEmptyResponse
+			}
+			return nil, 0, errors.New("returning nil for domain ", domain).Base(err0)
+		}
+		return nil, 0, errors.New("returning nil for domain ", domain).Base(allErrs)
+	}
+	return nil, 0, dns.ErrEmptyResponse
+}
+
+func (s *DNS) sortClients(st *dnsState, domain string) []*Client {
+	clients := make([]*Client, 0, len(st.clients))
+	clientUsed := make([]bool, len(st.clients))
+	clientNames := make([]string, 0, len(st.clients))
 	domainRules := []string{}
 
 	// Priority domain matching
 	hasMatch := false
-	MatchSlice := s.domainMatcher.Match(domain)
+	MatchSlice := st.domainMatcher.Match(domain)
 	sort.Slice(MatchSlice, func(i, j int) bool {
 		return MatchSlice[i] < MatchSlice[j]
 	})
 	for _, match := range MatchSlice {
-		info := s.matcherInfos[match]
-		client := s.clients[info.clientIdx]
+		info := st.matcherInfos[match]
+		client := st.clients[info.clientIdx]
 		domainRule := client.domains[info.domainRuleIdx]
 		domainRules = append(domainRules, fmt.Sprintf("%s(DNS idx:%d)", domainRule, info.clientIdx))
 		if clientUsed[info.clientIdx] {
@@ -294,9 +436,9 @@ func (s *DNS) sortClients(domain string) []*Client {
 		hasMatch = true
 	}
 
-	if !(s.disableFallback || s.disableFallbackIfMatch && hasMatch) {
+	if !(st.disableFallback || st.disableFallbackIfMatch && hasMatch) {
 		// Default round-robin query
-		for idx, client := range s.clients {
+		for idx, client := range st.clients {
 			if clientUsed[idx] || client.skipFallback {
 				continue
 			}
@@ -314,8 +456,8 @@ func (s *DNS) sortClients(domain string) []*Client {
 	}
 
 	if len(clients) == 0 {
-		clients = append(clients, s.clients[0])
-		clientNames = append(clientNames, s.clients[0].Name())
+		clients = append(clients, st.clients[0])
+		clientNames = append(clientNames, st.clients[0].Name())
 		errors.LogDebug(s.ctx, "domain ", domain, " will use the first DNS: ", clientNames)
 	}
 
@@ -20,6 +20,7 @@ import (
 	feature_dns "github.com/xtls/xray-core/features/dns"
 	"github.com/xtls/xray-core/proxy/freedom"
 	"github.com/xtls/xray-core/testing/servers/udp"
+	"golang.org/x/net/dns/dnsmessage"
 )
 
 type staticHandler struct{}
@@ -106,6 +107,17 @@ func (*staticHandler) ServeDNS(w dns.ResponseWriter, r *dns.Msg) {
 		case q.Name == "Mijia\\ Cloud." && q.Qtype == dns.TypeA:
 			rr, _ := dns.NewRR("Mijia\\ Cloud. IN A 127.0.0.1")
 			ans.Answer = append(ans.Answer, rr)
+
+		case q.Name == "google.com." && q.Qtype == dns.TypeMX:
+			rr, _ := dns.NewRR("google.com. IN MX 10 smtp.google.com.")
+			ans.Answer = append(ans.Answer, rr)
+
+		case q.Name == "google.com." && q.Qtype == dns.TypeHTTPS:
+			rr, _ := dns.NewRR("google.com. IN HTTPS 1 . alpn=h2")
+			ans.Answer = append(ans.Answer, rr)
+
+		case q.Name == "notexist.google.com." && q.Qtype == dns.TypeMX:
+			ans.MsgHdr.Rcode = dns.RcodeNameError
 		}
 	}
 	w.WriteMsg(ans)
@@ -171,6 +183,93 @@ func TestUDPServerSubnet(t *testing.T) {
 	}
 }
 
+func TestLookupRecords(t *testing.T) {
+	port := udp.PickPort()
+
+	dnsServer := dns.Server{
+		Addr:    "127.0.0.1:" + port.String(),
+		Net:     "udp",
+		Handler: &staticHandler{},
+		UDPSize: 1200,
+	}
+	go dnsServer.ListenAndServe()
+	time.Sleep(time.Second)
+
+	config := &core.Config{
+		App: []*serial.TypedMessage{
+			serial.ToTypedMessage(&Config{
+				NameServer: []*NameServer{
+					{
+						Address: &net.Endpoint{
+							Network: net.Network_UDP,
+							Address: &net.IPOrDomain{
+								Address: &net.IPOrDomain_Ip{
+									Ip: []byte{127, 0, 0, 1},
+								},
+							},
+							Port: uint32(port),
+						},
+					},
+				},
+			}),
+			serial.ToTypedMessage(&dispatcher.Config{}),
+			serial.ToTypedMessage(&proxyman.OutboundConfig{}),
+			serial.ToTypedMessage(&policy.Config{}),
+		},
+		Outbound: []*core.OutboundHandlerConfig{
+			{
+				ProxySettings: serial.ToTypedMessage(&freedom.Config{}),
+			},
+		},
+	}
+
+	v, err := core.New(config)
+	common.Must(err)
+
+	client := v.GetFeature(feature_dns.ClientType()).(feature_dns.RecordClient)
+
+	{
+		answers, _, err := client.LookupRecords("google.com", dnsmessage.TypeMX)
+		if err != nil {
+			t.Fatal("unexpected error: ", err)
+		}
+		if len(answers) != 1 {
+			t.Fatal("len(answers): ", len(answers))
+		}
+		mx, ok := answers[0].Body.(*dnsmessage.MXResource)
+		if !ok {
+			t.Fatal("not MX record")
+		}
+		if mx.Pref != 10 || mx.MX.String() != "smtp.google.com." {
+			t.Error("unexpected MX record: ", mx.GoString())
+		}
+	}
+
+	{
+		answers, _, err := client.LookupRecords("google.com", dnsmessage.Type(dns.TypeHTTPS))
+		if err != nil {
+			t.Fatal("unexpected error: ", err)
+		}
+		if len(answers) != 1 || answers[0].Header.Type != dnsmessage.Type(dns.TypeHTTPS) {
+			t.Fatal("unexpected answers: ", answers)
+		}
+	}
+
+	{
+		_, _, err := client.LookupRecords("google.com", dnsmessage.TypeTXT)
+		if err != feature_dns.ErrEmptyResponse {
+			t.Error("expect empty response, but got ", err)
+		}
+	}
+
+	{
+		_, _, err := client.LookupRecords("notexist.google.com", dnsmessage.TypeMX)
+		if rcode := feature_dns.RCodeFromError(err); rcode != dns.RcodeNameError {
+			t.Error("expect NXDOMAIN, but got ", err)
+		}
+	}
+}
+
 func TestUDPServer(t *testing.T) {
 	port := udp.PickPort()
 
@@ -1063,3 +1162,106 @@ func TestMultiMatchPrioritizedDomain(t *testing.T) {
 		t.Error("DNS query doesn't finish in 2 seconds.")
 	}
 }
+
+func TestReload(t *testing.T) {
+	port := udp.PickPort()
+
+	dnsServer := dns.Server{
+		Addr:    "127.0.0.1:" + port.String(),
+		Net:     "udp",
+		Handler: &staticHandler{},
+		UDPSize: 1200,
+	}
+
+	go dnsServer.ListenAndServe()
+	defer dnsServer.Shutdown()
+	time.Sleep(time.Second)
+
+	newConfig := func(ip []byte) *Config {
+		return &Config{
+			NameServer: []*NameServer{
+				{
+					Address: &net.Endpoint{
+						Network: net.Network_UDP,
+						Address: &net.IPOrDomain{
+							Address: &net.IPOrDomain_Ip{
+								Ip: []byte{127, 0, 0, 1},
+							},
+						},
+						Port: uint32(port),
+					},
+				},
+			},
+			StaticHosts: []*Config_HostMapping{
+				{
+					Type:   DomainMatchingType_Full,
+					Domain: "reload.test",
+					Ip:     [][]byte{ip},
+				},
+			},
+		}
+	}
+
+	v, err := core.New(&core.Config{
+		App: []*serial.TypedMessage{
+			serial.ToTypedMessage(newConfig([]byte{1, 1, 1, 1})),
+			serial.ToTypedMessage(&dispatcher.Config{}),
+			serial.ToTypedMessage(&proxyman.OutboundConfig{}),
+			serial.ToTypedMessage(&policy.Config{}),
+		},
+		Outbound: []*core.OutboundHandlerConfig{
+			{
+				ProxySettings: serial.ToTypedMessage(&freedom.Config{}),
+			},
+		},
+	})
+	common.Must(err)
+
+	client := v.GetFeature(feature_dns.ClientType()).(*DNS)
+	option := feature_dns.IPOption{IPv4Enable: true, IPv6Enable: true}
+
+	done := make(chan struct{})
+	errCh := make(chan error, 2)
+	lookup := func(domain string, expected ...net.IP) {
+		for {
+			select {
+			case <-done:
+				errCh <- nil
+				return
+			default:
+			}
+			ips, _, err := client.LookupIP(domain, option)
+			if err != nil {
+				errCh <- err
+				return
+			}
+			if len(ips) != 1 || (!ips[0].Equal(expected[0]) && !ips[0].Equal(expected[len(expected)-1])) {
+				errCh <- errors.New("unexpected IPs of ", domain, ": ", ips)
+				return
+			}
+		}
+	}
+	go lookup("reload.test", net.IP{1, 1, 1, 1}, net.IP{2, 2, 2, 2})
+	go lookup("google.com", net.IP{8, 8, 8, 8})
+
+	for i := 0; i < 20; i++ {
+		ip := []byte{1, 1, 1, 1}
+		if i%2 == 0 {
+			ip = []byte{2, 2, 2, 2}
+		}
+		common.Must(client.Reload(newConfig(ip)))
+		time.Sleep(10 * time.Millisecond)
+	}
+	close(done)
+	for i := 0; i < 2; i++ {
+		if err := <-errCh; err != nil {
+			t.Error(err)
+		}
+	}
+
+	ips, _, err := client.LookupIP("reload.test", option)
+	common.Must(err)
+	if r := cmp.Diff(ips, []net.IP{{1, 1, 1, 1}}); r != "" {
+		t.Error(r)
+	}
+}
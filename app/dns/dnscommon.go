@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/binary"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/xtls/xray-core/common"
@@ -27,6 +28,46 @@ func Fqdn(domain string) string {
 type record struct {
 	A    *IPRecord
 	AAAA *IPRecord
+	// Records of other types than A and AAAA.
+	others map[dnsmessage.Type]*IPRecord
+
+	// When the record was last updated, and how many times it has been hit since.
+	updated time.Time
+	hits    atomic.Uint32
+}
+
+// expiring returns whether the queried records are about to expire, which is less than 1/prefetchWindow of their
+// TTL left.
+func (r *record) expiring(option dns_feature.IPOption) bool {
+	now := time.Now()
+	for _, rec := range []*IPRecord{r.A, r.AAAA} {
+		if rec == nil || (rec == r.A && !option.IPv4Enable) || (rec == r.AAAA && !option.IPv6Enable) {
+			continue
+		}
+		if rec.Expire.Sub(now)*prefetchWindow < rec.Expire.Sub(r.updated) {
+			return true
+		}
+	}
+	return false
+}
+
+// stale returns a copy of the record, where the records expired less than maxStale ago are answered with
+// staleAnswerTTL, and the records expired earlier are removed.
+func (r *record) stale(maxStale time.Duration) *record {
+	now := time.Now()
+	stale := &record{A: r.A, AAAA: r.AAAA}
+	for _, rec := range []**IPRecord{&stale.A, &stale.AAAA} {
+		switch {
+		case *rec == nil || (*rec).Expire.After(now):
+		case now.Sub((*rec).Expire) > maxStale:
+			*rec = nil
+		default:
+			staleRec := **rec
+			staleRec.Expire = now.Add(staleAnswerTTL * time.Second)
+			*rec = &staleRec
+		}
+	}
+	return stale
 }
 
 // IPRecord is a cacheable item for a resolved domain
@@ -36,15 +77,18 @@ type IPRecord struct {
 	Expire    time.Time
 	RCode     dnsmessage.RCode
 	RawHeader *dnsmessage.Header
+	// Answers other than A and AAAA, like CNAME, MX and HTTPS.
+	Answers []dnsmessage.Resource
 }
 
-func (r *IPRecord) getIPs() ([]net.IP, uint32, error) {
+// getTTL returns the TTL left, and the error of the rcode.
+func (r *IPRecord) getTTL() (uint32, error) {
 	if r == nil {
-		return nil, 0, errRecordNotFound
+		return 0, errRecordNotFound
 	}
 	untilExpire := time.Until(r.Expire).Seconds()
 	if untilExpire <= 0 {
-		return nil, 0, errRecordNotFound
+		return 0, errRecordNotFound
 	}
 
 	ttl := uint32(untilExpire) + 1
@@ -52,7 +96,15 @@ func (r *IPRecord) getIPs() ([]net.IP, uint32, error) {
 		r.Expire = time.Now().Add(time.Second) // To ensure that two consecutive requests get the same result
 	}
 	if r.RCode != dnsmessage.RCodeSuccess {
-		return nil, ttl, dns_feature.RCodeError(r.RCode)
+		return ttl, dns_feature.RCodeError(r.RCode)
+	}
+	return ttl, nil
+}
+
+func (r *IPRecord) getIPs() ([]net.IP, uint32, error) {
+	ttl, err := r.getTTL()
+	if err != nil {
+		return nil, ttl, err
 	}
 	if len(r.IP) == 0 {
 		return nil, ttl, dns_feature.ErrEmptyResponse
@@ -61,6 +113,18 @@ func (r *IPRecord) getIPs() ([]net.IP, uint32, error) {
 	return r.IP, ttl, nil
 }
 
+func (r *IPRecord) getAnswers() ([]dnsmessage.Resource, uint32, error) {
+	ttl, err := r.getTTL()
+	if err != nil {
+		return nil, ttl, err
+	}
+	if len(r.Answers) == 0 {
+		return nil, ttl, dns_feature.ErrEmptyResponse
+	}
+
+	return r.Answers, ttl, nil
+}
+
 var errRecordNotFound = errors.New("record not found")
 
 type dnsRequest struct {
@@ -180,6 +244,58 @@ func buildReqMsgs(domain string, option dns_feature.IPOption, reqIDGen func() ui
 	return reqs
 }
 
+// buildRecordReqMsg builds the request for qType records of domain.
+func buildRecordReqMsg(domain string, qType dnsmessage.Type, reqIDGen func() uint16, reqOpts *dnsmessage.Resource) *dnsRequest {
+	msg := new(dnsmessage.Message)
+	msg.Header.ID = reqIDGen()
+	msg.Header.RecursionDesired = true
+	msg.Questions = []dnsmessage.Question{{
+		Name:  dnsmessage.MustNewName(domain),
+		Type:  qType,
+		Class: dnsmessage.ClassINET,
+	}}
+	if reqOpts != nil {
+		msg.Additionals = append(msg.Additionals, *reqOpts)
+	}
+	return &dnsRequest{
+		reqType: qType,
+		domain:  domain,
+		start:   time.Now(),
+		msg:     msg,
+	}
+}
+
+// parseResourceBody parses the body of the resource, whose header is just parsed.
+func parseResourceBody(parser *dnsmessage.Parser, t dnsmessage.Type) (dnsmessage.ResourceBody, error) {
+	switch t {
+	case dnsmessage.TypeCNAME:
+		r, err := parser.CNAMEResource()
+		return &r, err
+	case dnsmessage.TypePTR:
+		r, err := parser.PTRResource()
+		return &r, err
+	case dnsmessage.TypeMX:
+		r, err := parser.MXResource()
+		return &r, err
+	case dnsmessage.TypeTXT:
+		r, err := parser.TXTResource()
+		return &r, err
+	case dnsmessage.TypeSRV:
+		r, err := parser.SRVResource()
+		return &r, err
+	case dnsmessage.TypeNS:
+		r, err := parser.NSResource()
+		return &r, err
+	case dnsmessage.TypeSOA:
+		r, err := parser.SOAResource()
+		return &r, err
+	default:
+		// Names in other types, like HTTPS and SVCB, are not compressed, so they are kept as is.
+		r, err := parser.UnknownResource()
+		return &r, err
+	}
+}
+
 // parseResponse parses DNS answers from the returned payload
 func parseResponse(payload []byte) (*IPRecord, error) {
 	var parser dnsmessage.Parser
@@ -237,11 +353,12 @@ L:
 				ipRecord.IP = append(ipRecord.IP, newIP)
 			}
 		default:
-			if err := parser.SkipAnswer(); err != nil {
-				errors.LogInfoInner(context.Background(), err, "failed to skip answer")
+			body, err := parseResourceBody(&parser, ah.Type)
+			if err != nil {
+				errors.LogInfoInner(context.Background(), err, "failed to parse ", ah.Type, " record for domain: ", ah.Name)
 				break L
 			}
-			continue
+			ipRecord.Answers = append(ipRecord.Answers, dnsmessage.Resource{Header: ah, Body: body})
 		}
 	}
 
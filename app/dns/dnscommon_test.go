@@ -44,6 +44,18 @@ func Test_parseResponse(t *testing.T) {
 	)
 	p = append(p, common.Must2(ans.Pack()))
 
+	cname := func(target string) dnsmessage.Resource {
+		return dnsmessage.Resource{
+			Header: dnsmessage.ResourceHeader{
+				Name:  dnsmessage.MustNewName("google.com."),
+				Type:  dnsmessage.TypeCNAME,
+				Class: dnsmessage.ClassINET,
+				TTL:   3600,
+			},
+			Body: &dnsmessage.CNAMEResource{CNAME: dnsmessage.MustNewName(target)},
+		}
+	}
+
 	tests := []struct {
 		name    string
 		want    *IPRecord
@@ -51,7 +63,7 @@ func Test_parseResponse(t *testing.T) {
 	}{
 		{
 			"empty",
-			&IPRecord{0, []net.IP(nil), time.Time{}, dnsmessage.RCodeSuccess, nil},
+			&IPRecord{0, []net.IP(nil), time.Time{}, dnsmessage.RCodeSuccess, nil, nil},
 			false,
 		},
 		{
@@ -67,12 +79,15 @@ func Test_parseResponse(t *testing.T) {
 				time.Time{},
 				dnsmessage.RCodeSuccess,
 				nil,
+				[]dnsmessage.Resource{cname("m.test.google.com."), cname("fake.google.com.")},
 			},
 			false,
 		},
 		{
 			"aaaa record",
-			&IPRecord{2, []net.IP{net.ParseIP("2001:4860:4860::8888"), net.ParseIP("2001:4860:4860::8844")}, time.Time{}, dnsmessage.RCodeSuccess, nil},
+			&IPRecord{2, []net.IP{net.ParseIP("2001:4860:4860::8888"), net.ParseIP("2001:4860:4860::8844")}, time.Time{}, dnsmessage.RCodeSuccess, nil, []dnsmessage.Resource{
+				cname("m.test.google.com."), cname("fake.google.com."), cname("m.test.google.com."), cname("test.google.com."),
+			}},
 			false,
 		},
 	}
@@ -85,9 +100,12 @@ func Test_parseResponse(t *testing.T) {
 			}
 
 			if got != nil {
-				// reset the time and RawHeader
+				// reset the time, RawHeader and lengths of answers
 				got.Expire = time.Time{}
 				got.RawHeader = nil
+				for i := range got.Answers {
+					got.Answers[i].Header.Length = 0
+				}
 			}
 			if cmp.Diff(got, tt.want) != "" {
 				t.Error(cmp.Diff(got, tt.want))
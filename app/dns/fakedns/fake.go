@@ -12,6 +12,7 @@ import (
 	"github.com/xtls/xray-core/common/cache"
 	"github.com/xtls/xray-core/common/errors"
 	"github.com/xtls/xray-core/common/net"
+	"github.com/xtls/xray-core/common/task"
 	"github.com/xtls/xray-core/features/dns"
 )
 
@@ -19,8 +20,13 @@ type Holder struct {
 	domainToIP cache.Lru
 	ipRange    *gonet.IPNet
 	mu         *sync.Mutex
+	// closed is guarded by mu. A closed holder neither assigns nor looks up fake IPs, while its fields stay valid for
+	// the queries still in flight.
+	closed bool
 
 	config *FakeDnsPool
+
+	persister *task.Periodic
 }
 
 func (fkdns *Holder) IsIPInIPPool(ip net.Address) bool {
@@ -44,15 +50,42 @@ func (*Holder) Type() interface{} {
 
 func (fkdns *Holder) Start() error {
 	if fkdns.config != nil && fkdns.config.IpPool != "" && fkdns.config.LruSize != 0 {
-		return fkdns.initializeFromConfig()
+		if err := fkdns.initializeFromConfig(); err != nil {
+			return err
+		}
+		if fkdns.config.PersistPath != "" {
+			if err := fkdns.loadMappings(); err != nil {
+				return errors.New("failed to load fake DNS mappings from ", fkdns.config.PersistPath).Base(err)
+			}
+			interval := time.Minute
+			if fkdns.config.PersistInterval > 0 {
+				interval = time.Duration(fkdns.config.PersistInterval) * time.Second
+			}
+			fkdns.persister = &task.Periodic{
+				Interval: interval,
+				Execute:  fkdns.persistMappings,
+			}
+			return fkdns.persister.Start()
+		}
+		return nil
 	}
 	return errors.New("invalid fakeDNS setting")
 }
 
 func (fkdns *Holder) Close() error {
-	fkdns.domainToIP = nil
-	fkdns.ipRange = nil
-	fkdns.mu = nil
+	// Mappings are no longer assigned once closed, so that none is missing from the final save.
+	if fkdns.mu != nil {
+		fkdns.mu.Lock()
+		fkdns.closed = true
+		fkdns.mu.Unlock()
+	}
+	if fkdns.persister != nil {
+		fkdns.persister.Close()
+		fkdns.persister = nil
+		if err := fkdns.saveMappings(); err != nil {
+			errors.LogWarningInner(context.Background(), err, "failed to persist fake DNS mappings to ", fkdns.config.PersistPath)
+		}
+	}
 	return nil
 }
 
@@ -71,7 +104,7 @@ func NewFakeDNSHolder() (*Holder, error) {
 }
 
 func NewFakeDNSHolderConfigOnly(conf *FakeDnsPool) (*Holder, error) {
-	return &Holder{nil, nil, nil, conf}, nil
+	return &Holder{config: conf}, nil
 }
 
 func (fkdns *Holder) initializeFromConfig() error {
@@ -94,6 +127,7 @@ func (fkdns *Holder) initialize(ipPoolCidr string, lruSize int) error {
 	fkdns.domainToIP = cache.NewLru(lruSize)
 	fkdns.ipRange = ipRange
 	fkdns.mu = new(sync.Mutex)
+	fkdns.closed = false
 	return nil
 }
 
@@ -101,6 +135,9 @@ func (fkdns *Holder) initialize(ipPoolCidr string, lruSize int) error {
 func (fkdns *Holder) GetFakeIPForDomain(domain string) []net.Address {
 	fkdns.mu.Lock()
 	defer fkdns.mu.Unlock()
+	if fkdns.closed {
+		return []net.Address{}
+	}
 	if v, ok := fkdns.domainToIP.Get(domain); ok {
 		return []net.Address{v.(net.Address)}
 	}
@@ -135,6 +172,11 @@ func (fkdns *Holder) GetDomainFromFakeDNS(ip net.Address) string {
 	if !ip.Family().IsIP() || !fkdns.ipRange.Contains(ip.IP()) {
 		return ""
 	}
+	fkdns.mu.Lock()
+	defer fkdns.mu.Unlock()
+	if fkdns.closed {
+		return ""
+	}
 	if k, ok := fkdns.domainToIP.GetKeyFromValue(ip); ok {
 		return k.(string)
 	}
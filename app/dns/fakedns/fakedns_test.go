@@ -2,7 +2,9 @@ package fakedns
 
 import (
 	gonet "net"
+	"path/filepath"
 	"strconv"
+	"sync"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -204,3 +206,50 @@ func TestFakeDNSMulti(t *testing.T) {
 		})
 	})
 }
+
+func TestFakeDnsHolderPersist(t *testing.T) {
+	config := &FakeDnsPool{
+		IpPool:      dns.FakeIPv4Pool,
+		LruSize:     256,
+		PersistPath: filepath.Join(t.TempDir(), "fakedns.json"),
+	}
+	fkdns, err := NewFakeDNSHolderConfigOnly(config)
+	common.Must(err)
+	common.Must(fkdns.Start())
+	addr := fkdns.GetFakeIPForDomain("fakednstest.example.com")
+	addr2 := fkdns.GetFakeIPForDomain("fakednstest2.example.com")
+	common.Must(fkdns.Close())
+
+	fkdns, err = NewFakeDNSHolderConfigOnly(config)
+	common.Must(err)
+	common.Must(fkdns.Start())
+	defer fkdns.Close()
+
+	assert.Equal(t, "fakednstest.example.com", fkdns.GetDomainFromFakeDNS(addr[0]))
+	assert.Equal(t, "fakednstest2.example.com", fkdns.GetDomainFromFakeDNS(addr2[0]))
+	assert.Equal(t, addr, fkdns.GetFakeIPForDomain("fakednstest.example.com"))
+}
+
+func TestFakeDnsHolderClose(t *testing.T) {
+	fkdns, err := NewFakeDNSHolder()
+	common.Must(err)
+	addr := fkdns.GetFakeIPForDomain("fakednstest.example.com")
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				fkdns.GetDomainFromFakeDNS(addr[0])
+				fkdns.GetFakeIPForDomain("fakednstest.example.com")
+			}
+		}()
+	}
+	common.Must(fkdns.Close())
+	wg.Wait()
+
+	assert.True(t, fkdns.IsIPInIPPool(addr[0]))
+	assert.Equal(t, "", fkdns.GetDomainFromFakeDNS(addr[0]))
+	assert.Len(t, fkdns.GetFakeIPForDomain("fakednstest.example.com"), 0)
+}
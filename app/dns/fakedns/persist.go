@@ -0,0 +1,89 @@
+package fakedns
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+
+	"github.com/xtls/xray-core/common/errors"
+	"github.com/xtls/xray-core/common/net"
+	"github.com/xtls/xray-core/common/platform/filesystem"
+)
+
+type persistedMapping struct {
+	Domain string `json:"domain"`
+	IP     string `json:"ip"`
+}
+
+// persistedPool is the snapshot of the relationship between domain names and IP addresses of a pool. Mappings are
+// ordered from the least recently used to the most.
+type persistedPool struct {
+	IPPool   string             `json:"ipPool"`
+	Mappings []persistedMapping `json:"mappings"`
+}
+
+// loadMappings restores the mappings saved in the persist file, so that fake IPs held by clients keep resolving.
+// Mappings out of the IP pool, which may have changed, are dropped.
+func (fkdns *Holder) loadMappings() error {
+	data, err := os.ReadFile(fkdns.config.PersistPath)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	var saved persistedPool
+	if err := json.Unmarshal(data, &saved); err != nil {
+		return err
+	}
+
+	fkdns.mu.Lock()
+	defer fkdns.mu.Unlock()
+	restored := 0
+	for _, m := range saved.Mappings {
+		ip := net.ParseAddress(m.IP)
+		if m.Domain == "" || !ip.Family().IsIP() || !fkdns.ipRange.Contains(ip.IP()) {
+			continue
+		}
+		if _, found := fkdns.domainToIP.PeekKeyFromValue(ip); found {
+			continue
+		}
+		fkdns.domainToIP.Put(m.Domain, ip)
+		restored++
+	}
+	errors.LogInfo(context.Background(), "restored ", restored, " fake DNS mappings from ", fkdns.config.PersistPath)
+	return nil
+}
+
+// saveMappings writes the mappings to the persist file.
+func (fkdns *Holder) saveMappings() error {
+	saved := persistedPool{
+		IPPool: fkdns.config.IpPool,
+	}
+	fkdns.mu.Lock()
+	fkdns.domainToIP.Range(func(key, value interface{}) bool {
+		saved.Mappings = append(saved.Mappings, persistedMapping{
+			Domain: key.(string),
+			IP:     value.(net.Address).IP().String(),
+		})
+		return true
+	})
+	fkdns.mu.Unlock()
+
+	data, err := json.Marshal(&saved)
+	if err != nil {
+		return err
+	}
+	if err := filesystem.WriteFileAtomic(fkdns.config.PersistPath, data); err != nil {
+		return errors.New("failed to save fake DNS mappings").Base(err)
+	}
+	return nil
+}
+
+// persistMappings is run by the persister of Holder.
+func (fkdns *Holder) persistMappings() error {
+	if err := fkdns.saveMappings(); err != nil {
+		errors.LogWarningInner(context.Background(), err, "failed to persist fake DNS mappings to ", fkdns.config.PersistPath)
+	}
+	return nil
+}
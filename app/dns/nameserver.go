@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/xtls/xray-core/app/router"
+	"github.com/xtls/xray-core/common"
 	"github.com/xtls/xray-core/common/errors"
 	"github.com/xtls/xray-core/common/net"
 	"github.com/xtls/xray-core/common/session"
@@ -14,6 +15,8 @@ import (
 	"github.com/xtls/xray-core/core"
 	"github.com/xtls/xray-core/features/dns"
 	"github.com/xtls/xray-core/features/routing"
+	"github.com/xtls/xray-core/transport/internet/tls"
+	"golang.org/x/net/dns/dnsmessage"
 )
 
 // Server is the interface for Name Server.
@@ -24,6 +27,19 @@ type Server interface {
 	QueryIP(ctx context.Context, domain string, option dns.IPOption) ([]net.IP, uint32, error)
 }
 
+// cachedServer is a Server with a cache.
+type cachedServer interface {
+	Server
+	cache() *CacheController
+	setCachePolicy(policy CachePolicy)
+}
+
+// recordServer is a Server which also queries records of types other than A and AAAA.
+type recordServer interface {
+	Server
+	QueryRecords(ctx context.Context, domain string, qType dnsmessage.Type) ([]dnsmessage.Resource, uint32, error)
+}
+
 // Client is the interface for DNS client.
 type Client struct {
 	server        Server
@@ -41,7 +57,7 @@ type Client struct {
 }
 
 // NewServer creates a name server object according to the network destination url.
-func NewServer(ctx context.Context, dest net.Destination, dispatcher routing.Dispatcher, disableCache bool, clientIP net.IP) (Server, error) {
+func NewServer(ctx context.Context, dest net.Destination, dispatcher routing.Dispatcher, disableCache bool, clientIP net.IP, tlsConfig *tls.Config) (Server, error) {
 	if address := dest.Address; address.Family().IsDomain() {
 		u, err := url.Parse(address.Domain())
 		if err != nil {
@@ -64,6 +80,10 @@ func NewServer(ctx context.Context, dest net.Destination, dispatcher routing.Dis
 			return NewTCPNameServer(u, dispatcher, disableCache, clientIP)
 		case strings.EqualFold(u.Scheme, "tcp+local"): // DNS-over-TCP Local mode
 			return NewTCPLocalNameServer(u, disableCache, clientIP)
+		case strings.EqualFold(u.Scheme, "tls"): // DNS-over-TLS Remote mode
+			return NewTLSNameServer(u, dispatcher, tlsConfig, disableCache, clientIP)
+		case strings.EqualFold(u.Scheme, "tls+local"): // DNS-over-TLS Local mode
+			return NewTLSLocalNameServer(u, tlsConfig, disableCache, clientIP)
 		case strings.EqualFold(u.String(), "fakedns"):
 			var fd dns.FakeDNSEngine
 			err = core.RequireFeatures(ctx, func(fdns dns.FakeDNSEngine) {
@@ -99,11 +119,24 @@ func NewClient(
 
 	err := core.RequireFeatures(ctx, func(dispatcher routing.Dispatcher) error {
 		// Create a new server for each client for now
-		server, err := NewServer(ctx, ns.Address.AsDestination(), dispatcher, disableCache, clientIP)
+		tlsConfig := &tls.Config{
+			ServerName:                           ns.ServerName,
+			PinnedPeerCertificateChainSha256:     ns.PinnedPeerCertificateChainSha256,
+			PinnedPeerCertificatePublicKeySha256: ns.PinnedPeerCertificatePublicKeySha256,
+		}
+		server, err := NewServer(ctx, ns.Address.AsDestination(), dispatcher, disableCache, clientIP, tlsConfig)
 		if err != nil {
 			return errors.New("failed to create nameserver").Base(err).AtWarning()
 		}
 
+		if cs, ok := server.(cachedServer); ok {
+			cs.setCachePolicy(CachePolicy{
+				ServeStale: ns.ServeStale,
+				MaxStale:   time.Duration(ns.MaxStaleSeconds) * time.Second,
+				Prefetch:   ns.Prefetch,
+			})
+		}
+
 		// Prioritize local domains with specific TLDs or those without any dot for the local DNS
 		if _, isLocalDNS := server.(*LocalNameServer); isLocalDNS {
 			ns.PrioritizedDomain = append(ns.PrioritizedDomain, localTLDsAndDotlessDomains...)
@@ -215,6 +248,11 @@ func (c *Client) IsFinalQuery() bool {
 	return c.finalQuery
 }
 
+// Close closes the name server of the client, if it holds any resources.
+func (c *Client) Close() error {
+	return common.Close(c.server)
+}
+
 // QueryIP sends DNS query to the name server with the client's IP.
 func (c *Client) QueryIP(ctx context.Context, domain string, option dns.IPOption) ([]net.IP, uint32, error) {
 	if c.checkSystem {
@@ -278,6 +316,27 @@ func (c *Client) QueryIP(ctx context.Context, domain string, option dns.IPOption
 	return ips, ttl, nil
 }
 
+// QueryRecords sends queries of qType records to the DNS server.
+func (c *Client) QueryRecords(ctx context.Context, domain string, qType dnsmessage.Type) ([]dnsmessage.Resource, uint32, error) {
+	server, ok := c.server.(recordServer)
+	if !ok {
+		return nil, 0, errors.New(c.Name(), " does not support ", qType, " queries")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, c.timeoutMs)
+	ctx = session.ContextWithInbound(ctx, &session.Inbound{Tag: c.tag})
+	answers, ttl, err := server.QueryRecords(ctx, domain, qType)
+	cancel()
+
+	if err != nil {
+		return nil, 0, err
+	}
+	if len(answers) == 0 {
+		return nil, 0, dns.ErrEmptyResponse
+	}
+	return answers, ttl, nil
+}
+
 func ResolveIpOptionOverride(queryStrategy QueryStrategy, ipOption dns.IPOption) dns.IPOption {
 	switch queryStrategy {
 	case QueryStrategy_USE_IP:
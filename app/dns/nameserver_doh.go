@@ -24,6 +24,7 @@ import (
 	dns_feature "github.com/xtls/xray-core/features/dns"
 	"github.com/xtls/xray-core/features/routing"
 	"github.com/xtls/xray-core/transport/internet"
+	"golang.org/x/net/dns/dnsmessage"
 	"golang.org/x/net/http2"
 )
 
@@ -117,11 +118,44 @@ func (s *DoHNameServer) Name() string {
 	return s.cacheController.name
 }
 
+func (s *DoHNameServer) cache() *CacheController {
+	return s.cacheController
+}
+
+func (s *DoHNameServer) setCachePolicy(policy CachePolicy) {
+	s.cacheController.setPolicy(policy, s.sendQuery)
+}
+
+// Close implements common.Closable.
+func (s *DoHNameServer) Close() error {
+	s.httpClient.CloseIdleConnections()
+	return s.cacheController.Close()
+}
+
 func (s *DoHNameServer) newReqID() uint16 {
 	return 0
 }
 
 func (s *DoHNameServer) sendQuery(ctx context.Context, noResponseErrCh chan<- error, domain string, option dns_feature.IPOption) {
+	s.sendRequests(ctx, noResponseErrCh, domain, buildReqMsgs(domain, option, s.newReqID, s.reqOptions()))
+}
+
+// reqOptions returns the EDNS0 options of requests.
+func (s *DoHNameServer) reqOptions() *dnsmessage.Resource {
+	// As we don't want our traffic pattern looks like DoH, we use Random-Length Padding instead of Block-Length Padding recommended in RFC 8467
+	// Although DoH server like 1.1.1.1 will pad the response to Block-Length 468, at least it is better than no padding for response at all
+	return genEDNS0Options(s.clientIP, int(crypto.RandBetween(100, 300)))
+}
+
+// QueryRecords implements recordServer.
+func (s *DoHNameServer) QueryRecords(ctx context.Context, domain string, qType dnsmessage.Type) ([]dnsmessage.Resource, uint32, error) {
+	return s.cacheController.queryRecords(ctx, domain, qType, func(ctx context.Context, noResponseErrCh chan<- error, fqdn string) {
+		s.sendRequests(ctx, noResponseErrCh, fqdn, []*dnsRequest{buildRecordReqMsg(fqdn, qType, s.newReqID, s.reqOptions())})
+	})
+}
+
+// sendRequests sends the requests for domain, and updates the cache with the responses.
+func (s *DoHNameServer) sendRequests(ctx context.Context, noResponseErrCh chan<- error, domain string, reqs []*dnsRequest) {
 	errors.LogInfo(ctx, s.Name(), " querying: ", domain)
 
 	if s.Name()+"." == "DOH//"+domain {
@@ -130,10 +164,6 @@ func (s *DoHNameServer) sendQuery(ctx context.Context, noResponseErrCh chan<- er
 		return
 	}
 
-	// As we don't want our traffic pattern looks like DoH, we use Random-Length Padding instead of Block-Length Padding recommended in RFC 8467
-	// Although DoH server like 1.1.1.1 will pad the response to Block-Length 468, at least it is better than no padding for response at all
-	reqs := buildReqMsgs(domain, option, s.newReqID, genEDNS0Options(s.clientIP, int(crypto.RandBetween(100, 300))))
-
 	var deadline time.Time
 	if d, ok := ctx.Deadline(); ok {
 		deadline = d
@@ -224,7 +254,7 @@ func (s *DoHNameServer) QueryIP(ctx context.Context, domain string, option dns_f
 	if s.cacheController.disableCache {
 		errors.LogDebug(ctx, "DNS cache is disabled. Querying IP for ", domain, " at ", s.Name())
 	} else {
-		ips, ttl, err := s.cacheController.findIPsForDomain(fqdn, option)
+		ips, ttl, err := s.cacheController.findCachedIPsForDomain(fqdn, option)
 		if !go_errors.Is(err, errRecordNotFound) {
 			errors.LogDebugInner(ctx, err, s.Name(), " cache HIT ", domain, " -> ", ips)
 			log.Record(&log.DNSLog{Server: s.Name(), Domain: domain, Result: ips, Status: log.DNSCacheHit, Elapsed: 0, Error: err})
@@ -18,6 +18,7 @@ import (
 	"github.com/xtls/xray-core/common/session"
 	dns_feature "github.com/xtls/xray-core/features/dns"
 	"github.com/xtls/xray-core/transport/internet/tls"
+	"golang.org/x/net/dns/dnsmessage"
 	"golang.org/x/net/http2"
 )
 
@@ -64,14 +65,43 @@ func (s *QUICNameServer) Name() string {
 	return s.cacheController.name
 }
 
+func (s *QUICNameServer) cache() *CacheController {
+	return s.cacheController
+}
+
+func (s *QUICNameServer) setCachePolicy(policy CachePolicy) {
+	s.cacheController.setPolicy(policy, s.sendQuery)
+}
+
+// Close implements common.Closable.
+func (s *QUICNameServer) Close() error {
+	s.Lock()
+	if s.connection != nil {
+		_ = s.connection.CloseWithError(0, "")
+		s.connection = nil
+	}
+	s.Unlock()
+	return s.cacheController.Close()
+}
+
 func (s *QUICNameServer) newReqID() uint16 {
 	return 0
 }
 
 func (s *QUICNameServer) sendQuery(ctx context.Context, noResponseErrCh chan<- error, domain string, option dns_feature.IPOption) {
-	errors.LogInfo(ctx, s.Name(), " querying: ", domain)
+	s.sendRequests(ctx, noResponseErrCh, domain, buildReqMsgs(domain, option, s.newReqID, genEDNS0Options(s.clientIP, 0)))
+}
 
-	reqs := buildReqMsgs(domain, option, s.newReqID, genEDNS0Options(s.clientIP, 0))
+// QueryRecords implements recordServer.
+func (s *QUICNameServer) QueryRecords(ctx context.Context, domain string, qType dnsmessage.Type) ([]dnsmessage.Resource, uint32, error) {
+	return s.cacheController.queryRecords(ctx, domain, qType, func(ctx context.Context, noResponseErrCh chan<- error, fqdn string) {
+		s.sendRequests(ctx, noResponseErrCh, fqdn, []*dnsRequest{buildRecordReqMsg(fqdn, qType, s.newReqID, genEDNS0Options(s.clientIP, 0))})
+	})
+}
+
+// sendRequests sends the requests for domain, and updates the cache with the responses.
+func (s *QUICNameServer) sendRequests(ctx context.Context, noResponseErrCh chan<- error, domain string, reqs []*dnsRequest) {
+	errors.LogInfo(ctx, s.Name(), " querying: ", domain)
 
 	var deadline time.Time
 	if d, ok := ctx.Deadline(); ok {
@@ -181,7 +211,7 @@ func (s *QUICNameServer) QueryIP(ctx context.Context, domain string, option dns_
 	if s.cacheController.disableCache {
 		errors.LogDebug(ctx, "DNS cache is disabled. Querying IP for ", domain, " at ", s.Name())
 	} else {
-		ips, ttl, err := s.cacheController.findIPsForDomain(fqdn, option)
+		ips, ttl, err := s.cacheController.findCachedIPsForDomain(fqdn, option)
 		if !go_errors.Is(err, errRecordNotFound) {
 			errors.LogDebugInner(ctx, err, s.Name(), " cache HIT ", domain, " -> ", ips)
 			log.Record(&log.DNSLog{Server: s.Name(), Domain: domain, Result: ips, Status: log.DNSCacheHit, Elapsed: 0, Error: err})
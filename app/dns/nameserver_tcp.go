@@ -19,6 +19,7 @@ import (
 	dns_feature "github.com/xtls/xray-core/features/dns"
 	"github.com/xtls/xray-core/features/routing"
 	"github.com/xtls/xray-core/transport/internet"
+	"golang.org/x/net/dns/dnsmessage"
 )
 
 // TCPNameServer implemented DNS over TCP (RFC7766).
@@ -95,14 +96,37 @@ func (s *TCPNameServer) Name() string {
 	return s.cacheController.name
 }
 
+func (s *TCPNameServer) cache() *CacheController {
+	return s.cacheController
+}
+
+func (s *TCPNameServer) setCachePolicy(policy CachePolicy) {
+	s.cacheController.setPolicy(policy, s.sendQuery)
+}
+
+// Close implements common.Closable.
+func (s *TCPNameServer) Close() error {
+	return s.cacheController.Close()
+}
+
 func (s *TCPNameServer) newReqID() uint16 {
 	return uint16(atomic.AddUint32(&s.reqID, 1))
 }
 
 func (s *TCPNameServer) sendQuery(ctx context.Context, noResponseErrCh chan<- error, domain string, option dns_feature.IPOption) {
-	errors.LogDebug(ctx, s.Name(), " querying DNS for: ", domain)
+	s.sendRequests(ctx, noResponseErrCh, domain, buildReqMsgs(domain, option, s.newReqID, genEDNS0Options(s.clientIP, 0)))
+}
 
-	reqs := buildReqMsgs(domain, option, s.newReqID, genEDNS0Options(s.clientIP, 0))
+// QueryRecords implements recordServer.
+func (s *TCPNameServer) QueryRecords(ctx context.Context, domain string, qType dnsmessage.Type) ([]dnsmessage.Resource, uint32, error) {
+	return s.cacheController.queryRecords(ctx, domain, qType, func(ctx context.Context, noResponseErrCh chan<- error, fqdn string) {
+		s.sendRequests(ctx, noResponseErrCh, fqdn, []*dnsRequest{buildRecordReqMsg(fqdn, qType, s.newReqID, genEDNS0Options(s.clientIP, 0))})
+	})
+}
+
+// sendRequests sends the requests for domain, and updates the cache with the responses.
+func (s *TCPNameServer) sendRequests(ctx context.Context, noResponseErrCh chan<- error, domain string, reqs []*dnsRequest) {
+	errors.LogDebug(ctx, s.Name(), " querying DNS for: ", domain)
 
 	var deadline time.Time
 	if d, ok := ctx.Deadline(); ok {
@@ -209,7 +233,7 @@ func (s *TCPNameServer) QueryIP(ctx context.Context, domain string, option dns_f
 	if s.cacheController.disableCache {
 		errors.LogDebug(ctx, "DNS cache is disabled. Querying IP for ", domain, " at ", s.Name())
 	} else {
-		ips, ttl, err := s.cacheController.findIPsForDomain(fqdn, option)
+		ips, ttl, err := s.cacheController.findCachedIPsForDomain(fqdn, option)
 		if !go_errors.Is(err, errRecordNotFound) {
 			errors.LogDebugInner(ctx, err, s.Name(), " cache HIT ", domain, " -> ", ips)
 			log.Record(&log.DNSLog{Server: s.Name(), Domain: domain, Result: ips, Status: log.DNSCacheHit, Elapsed: 0, Error: err})
@@ -0,0 +1,463 @@
+package dns
+
+import (
+	"context"
+	gotls "crypto/tls"
+	"encoding/binary"
+	go_errors "errors"
+	"io"
+	"net/url"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/xtls/xray-core/common"
+	"github.com/xtls/xray-core/common/errors"
+	"github.com/xtls/xray-core/common/log"
+	"github.com/xtls/xray-core/common/net"
+	"github.com/xtls/xray-core/common/net/cnc"
+	"github.com/xtls/xray-core/common/protocol/dns"
+	"github.com/xtls/xray-core/common/session"
+	dns_feature "github.com/xtls/xray-core/features/dns"
+	"github.com/xtls/xray-core/features/routing"
+	"github.com/xtls/xray-core/transport/internet"
+	"github.com/xtls/xray-core/transport/internet/tls"
+	"golang.org/x/net/dns/dnsmessage"
+)
+
+// NextProtoDoT is the ALPN token of DNS-over-TLS.
+const NextProtoDoT = "dot"
+
+// tlsSessionIdleTimeout is how long an idle DNS-over-TLS session is kept for reuse.
+const tlsSessionIdleTimeout = 30 * time.Second
+
+var errTLSSessionClosed = errors.New("DNS-over-TLS session closed")
+
+// TLSNameServer implemented DNS over TLS (RFC7858). Queries are pipelined over one TLS session, which is reused
+// until it is closed by either side or idle.
+type TLSNameServer struct {
+	cacheController *CacheController
+	destination     *net.Destination
+	reqID           uint32
+	tlsConfig       *gotls.Config
+	dial            func(context.Context) (net.Conn, error)
+	clientIP        net.IP
+
+	dialing sync.Mutex
+	access  sync.Mutex
+	session *tlsSession
+	closed  bool
+}
+
+// NewTLSNameServer creates DNS over TLS server object for remote resolving.
+func NewTLSNameServer(
+	url *url.URL,
+	dispatcher routing.Dispatcher,
+	tlsConfig *tls.Config,
+	disableCache bool,
+	clientIP net.IP,
+) (*TLSNameServer, error) {
+	s, err := baseTLSNameServer(url, "DOT", tlsConfig, disableCache, clientIP)
+	if err != nil {
+		return nil, err
+	}
+
+	s.dial = func(ctx context.Context) (net.Conn, error) {
+		link, err := dispatcher.Dispatch(toDnsContext(ctx, s.destination.String()), *s.destination)
+		if err != nil {
+			return nil, err
+		}
+
+		cc := common.ChainedClosable{}
+		if cw, ok := link.Writer.(common.Closable); ok {
+			cc = append(cc, cw)
+		}
+		if cr, ok := link.Reader.(common.Closable); ok {
+			cc = append(cc, cr)
+		}
+		return cnc.NewConnection(
+			cnc.ConnectionInputMulti(link.Writer),
+			cnc.ConnectionOutputMulti(link.Reader),
+			cnc.ConnectionOnClose(cc),
+		), nil
+	}
+
+	return s, nil
+}
+
+// NewTLSLocalNameServer creates DNS over TLS client object for local resolving
+func NewTLSLocalNameServer(url *url.URL, tlsConfig *tls.Config, disableCache bool, clientIP net.IP) (*TLSNameServer, error) {
+	s, err := baseTLSNameServer(url, "DOTL", tlsConfig, disableCache, clientIP)
+	if err != nil {
+		return nil, err
+	}
+
+	s.dial = func(ctx context.Context) (net.Conn, error) {
+		log.Record(&log.AccessMessage{
+			From:   "DNS",
+			To:     s.destination,
+			Status: log.AccessAccepted,
+			Detour: "local",
+		})
+		return internet.DialSystem(ctx, *s.destination, nil)
+	}
+
+	return s, nil
+}
+
+func baseTLSNameServer(url *url.URL, prefix string, tlsConfig *tls.Config, disableCache bool, clientIP net.IP) (*TLSNameServer, error) {
+	port := net.Port(853)
+	if url.Port() != "" {
+		var err error
+		if port, err = net.PortFromString(url.Port()); err != nil {
+			return nil, err
+		}
+	}
+	dest := net.TCPDestination(net.ParseAddress(url.Hostname()), port)
+
+	if tlsConfig == nil {
+		tlsConfig = &tls.Config{}
+	}
+	config := tlsConfig.GetTLSConfig(tls.WithNextProto(NextProtoDoT))
+	if config.ServerName == "" {
+		config.ServerName = url.Hostname()
+	}
+	// A pinned certificate chain identifies the server by itself, so that servers with self-signed certificates work.
+	if len(tlsConfig.PinnedPeerCertificateChainSha256) > 0 {
+		config.InsecureSkipVerify = true
+	}
+
+	errors.LogInfo(context.Background(), "DNS: created ", prefix, " client for ", dest.NetAddr(), ", with server name ", config.ServerName)
+	s := &TLSNameServer{
+		cacheController: NewCacheController(prefix+"//"+dest.NetAddr(), disableCache),
+		destination:     &dest,
+		tlsConfig:       config,
+		clientIP:        clientIP,
+	}
+
+	return s, nil
+}
+
+// Name implements Server.
+func (s *TLSNameServer) Name() string {
+	return s.cacheController.name
+}
+
+func (s *TLSNameServer) cache() *CacheController {
+	return s.cacheController
+}
+
+func (s *TLSNameServer) setCachePolicy(policy CachePolicy) {
+	s.cacheController.setPolicy(policy, s.sendQuery)
+}
+
+// Close implements common.Closable.
+func (s *TLSNameServer) Close() error {
+	s.access.Lock()
+	if s.session != nil {
+		s.session.close()
+		s.session = nil
+	}
+	s.closed = true
+	s.access.Unlock()
+	return s.cacheController.Close()
+}
+
+func (s *TLSNameServer) newReqID() uint16 {
+	return uint16(atomic.AddUint32(&s.reqID, 1))
+}
+
+// getSession returns the session to send queries, which is reused if it is still open, and whether it is reused.
+func (s *TLSNameServer) getSession(ctx context.Context) (*tlsSession, bool, error) {
+	if session := s.openSession(); session != nil {
+		return session, true, nil
+	}
+
+	// Only one query dials at a time, and the others wait to reuse its session. The session is dialed without
+	// holding access, so that Close is not blocked by a slow handshake.
+	s.dialing.Lock()
+	defer s.dialing.Unlock()
+	if session := s.openSession(); session != nil {
+		return session, true, nil
+	}
+	conn, err := s.dial(ctx)
+	if err != nil {
+		return nil, false, errors.New("failed to dial ", s.destination).Base(err)
+	}
+	tlsConn := gotls.Client(conn, s.tlsConfig)
+	if err := tlsConn.HandshakeContext(ctx); err != nil {
+		conn.Close()
+		return nil, false, errors.New("failed to handshake with ", s.destination).Base(err)
+	}
+
+	s.access.Lock()
+	defer s.access.Unlock()
+	if s.closed {
+		tlsConn.Close()
+		return nil, false, errTLSSessionClosed
+	}
+	s.session = newTLSSession(tlsConn)
+	return s.session, false, nil
+}
+
+// openSession returns the current session if it is still open.
+func (s *TLSNameServer) openSession() *tlsSession {
+	s.access.Lock()
+	defer s.access.Unlock()
+	if s.session != nil && !s.session.isClosed() {
+		return s.session
+	}
+	return nil
+}
+
+func (s *TLSNameServer) sendQuery(ctx context.Context, noResponseErrCh chan<- error, domain string, option dns_feature.IPOption) {
+	s.sendRequests(ctx, noResponseErrCh, domain, buildReqMsgs(domain, option, s.newReqID, genEDNS0Options(s.clientIP, 0)))
+}
+
+// QueryRecords implements recordServer.
+func (s *TLSNameServer) QueryRecords(ctx context.Context, domain string, qType dnsmessage.Type) ([]dnsmessage.Resource, uint32, error) {
+	return s.cacheController.queryRecords(ctx, domain, qType, func(ctx context.Context, noResponseErrCh chan<- error, fqdn string) {
+		s.sendRequests(ctx, noResponseErrCh, fqdn, []*dnsRequest{buildRecordReqMsg(fqdn, qType, s.newReqID, genEDNS0Options(s.clientIP, 0))})
+	})
+}
+
+// sendRequests sends the requests for domain, and updates the cache with the responses.
+func (s *TLSNameServer) sendRequests(ctx context.Context, noResponseErrCh chan<- error, domain string, reqs []*dnsRequest) {
+	errors.LogDebug(ctx, s.Name(), " querying DNS for: ", domain)
+
+	if s.destination.Address.Family().IsDomain() && s.destination.Address.Domain()+"." == domain {
+		errors.LogError(ctx, s.Name(), " tries to resolve itself! Use IP or set \"hosts\" instead.")
+		noResponseErrCh <- errors.New("tries to resolve itself!", s.Name())
+		return
+	}
+
+	var deadline time.Time
+	if d, ok := ctx.Deadline(); ok {
+		deadline = d
+	} else {
+		deadline = time.Now().Add(time.Second * 5)
+	}
+
+	for _, req := range reqs {
+		go func(r *dnsRequest) {
+			dnsCtx := ctx
+
+			if inbound := session.InboundFromContext(ctx); inbound != nil {
+				dnsCtx = session.ContextWithInbound(dnsCtx, inbound)
+			}
+
+			dnsCtx = session.ContextWithContent(dnsCtx, &session.Content{
+				Protocol:       "tls",
+				SkipDNSResolve: true,
+			})
+
+			var cancel context.CancelFunc
+			dnsCtx, cancel = context.WithDeadline(dnsCtx, deadline)
+			defer cancel()
+
+			b, err := dns.PackMessage(r.msg)
+			if err != nil {
+				errors.LogErrorInner(ctx, err, "failed to pack dns query")
+				noResponseErrCh <- err
+				return
+			}
+			defer b.Release()
+
+			var resp []byte
+			for attempt := 0; ; attempt++ {
+				ts, reused, err := s.getSession(dnsCtx)
+				if err != nil {
+					errors.LogErrorInner(ctx, err, "failed to connect to namesever")
+					noResponseErrCh <- err
+					return
+				}
+				resp, err = ts.exchange(dnsCtx, r.msg.ID, b.Bytes())
+				// A reused session may have been closed by the server when idle, so retry once with a new one.
+				if go_errors.Is(err, errTLSSessionClosed) && reused && attempt == 0 {
+					continue
+				}
+				if err != nil {
+					errors.LogErrorInner(ctx, err, "failed to exchange DNS over TLS")
+					noResponseErrCh <- err
+					return
+				}
+				break
+			}
+
+			rec, err := parseResponse(resp)
+			if err != nil {
+				errors.LogErrorInner(ctx, err, "failed to parse DNS over TLS response")
+				noResponseErrCh <- err
+				return
+			}
+
+			s.cacheController.updateIP(r, rec)
+		}(req)
+	}
+}
+
+// QueryIP implements Server.
+func (s *TLSNameServer) QueryIP(ctx context.Context, domain string, option dns_feature.IPOption) ([]net.IP, uint32, error) {
+	fqdn := Fqdn(domain)
+	sub4, sub6 := s.cacheController.registerSubscribers(fqdn, option)
+	defer closeSubscribers(sub4, sub6)
+
+	if s.cacheController.disableCache {
+		errors.LogDebug(ctx, "DNS cache is disabled. Querying IP for ", domain, " at ", s.Name())
+	} else {
+		ips, ttl, err := s.cacheController.findCachedIPsForDomain(fqdn, option)
+		if !go_errors.Is(err, errRecordNotFound) {
+			errors.LogDebugInner(ctx, err, s.Name(), " cache HIT ", domain, " -> ", ips)
+			log.Record(&log.DNSLog{Server: s.Name(), Domain: domain, Result: ips, Status: log.DNSCacheHit, Elapsed: 0, Error: err})
+			return ips, ttl, err
+		}
+	}
+
+	noResponseErrCh := make(chan error, 2)
+	s.sendQuery(ctx, noResponseErrCh, fqdn, option)
+	start := time.Now()
+
+	if sub4 != nil {
+		select {
+		case <-ctx.Done():
+			return nil, 0, ctx.Err()
+		case err := <-noResponseErrCh:
+			return nil, 0, err
+		case <-sub4.Wait():
+			sub4.Close()
+		}
+	}
+	if sub6 != nil {
+		select {
+		case <-ctx.Done():
+			return nil, 0, ctx.Err()
+		case err := <-noResponseErrCh:
+			return nil, 0, err
+		case <-sub6.Wait():
+			sub6.Close()
+		}
+	}
+
+	ips, ttl, err := s.cacheController.findIPsForDomain(fqdn, option)
+	log.Record(&log.DNSLog{Server: s.Name(), Domain: domain, Result: ips, Status: log.DNSQueried, Elapsed: time.Since(start), Error: err})
+	return ips, ttl, err
+}
+
+// tlsSession is a TLS connection to a DNS-over-TLS server, over which queries are pipelined. Responses, which may
+// be out of order, are delivered to the queries by message ID.
+type tlsSession struct {
+	conn net.Conn
+
+	writeAccess sync.Mutex
+
+	access  sync.Mutex
+	pending map[uint16]chan []byte
+	closed  bool
+	idle    *time.Timer
+}
+
+func newTLSSession(conn net.Conn) *tlsSession {
+	ts := &tlsSession{
+		conn:    conn,
+		pending: make(map[uint16]chan []byte),
+	}
+	ts.idle = time.AfterFunc(tlsSessionIdleTimeout, ts.close)
+	go ts.readResponses()
+	return ts
+}
+
+func (ts *tlsSession) isClosed() bool {
+	ts.access.Lock()
+	defer ts.access.Unlock()
+	return ts.closed
+}
+
+// close closes the connection, and fails all pending queries.
+func (ts *tlsSession) close() {
+	ts.access.Lock()
+	defer ts.access.Unlock()
+
+	if ts.closed {
+		return
+	}
+	ts.closed = true
+	ts.idle.Stop()
+	ts.conn.Close()
+	for id, ch := range ts.pending {
+		close(ch)
+		delete(ts.pending, id)
+	}
+}
+
+func (ts *tlsSession) readResponses() {
+	defer ts.close()
+
+	var length [2]byte
+	for {
+		if _, err := io.ReadFull(ts.conn, length[:]); err != nil {
+			return
+		}
+		resp := make([]byte, binary.BigEndian.Uint16(length[:]))
+		if _, err := io.ReadFull(ts.conn, resp); err != nil {
+			return
+		}
+		if len(resp) < 2 {
+			return
+		}
+		id := binary.BigEndian.Uint16(resp)
+
+		ts.access.Lock()
+		if ch, found := ts.pending[id]; found {
+			ch <- resp
+			delete(ts.pending, id)
+		}
+		if len(ts.pending) == 0 && !ts.closed {
+			ts.idle.Reset(tlsSessionIdleTimeout)
+		}
+		ts.access.Unlock()
+	}
+}
+
+// exchange sends the query with id, and returns the response to it.
+func (ts *tlsSession) exchange(ctx context.Context, id uint16, query []byte) ([]byte, error) {
+	ch := make(chan []byte, 1)
+	ts.access.Lock()
+	if ts.closed {
+		ts.access.Unlock()
+		return nil, errTLSSessionClosed
+	}
+	if _, found := ts.pending[id]; found {
+		ts.access.Unlock()
+		return nil, errors.New("duplicated DNS message ID ", id)
+	}
+	ts.pending[id] = ch
+	ts.idle.Stop()
+	ts.access.Unlock()
+
+	msg := make([]byte, 2+len(query))
+	binary.BigEndian.PutUint16(msg, uint16(len(query)))
+	copy(msg[2:], query)
+	ts.writeAccess.Lock()
+	_, err := ts.conn.Write(msg)
+	ts.writeAccess.Unlock()
+	if err != nil {
+		ts.close()
+		return nil, errTLSSessionClosed
+	}
+
+	select {
+	case resp, ok := <-ch:
+		if !ok {
+			return nil, errTLSSessionClosed
+		}
+		return resp, nil
+	case <-ctx.Done():
+		ts.access.Lock()
+		delete(ts.pending, id)
+		if len(ts.pending) == 0 && !ts.closed {
+			ts.idle.Reset(tlsSessionIdleTimeout)
+		}
+		ts.access.Unlock()
+		return nil, ctx.Err()
+	}
+}
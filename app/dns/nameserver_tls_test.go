@@ -0,0 +1,104 @@
+package dns_test
+
+import (
+	"context"
+	gotls "crypto/tls"
+	gonet "net"
+	"net/url"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/miekg/dns"
+	. "github.com/xtls/xray-core/app/dns"
+	"github.com/xtls/xray-core/common"
+	"github.com/xtls/xray-core/common/net"
+	"github.com/xtls/xray-core/common/protocol/tls/cert"
+	dns_feature "github.com/xtls/xray-core/features/dns"
+	"github.com/xtls/xray-core/transport/internet/tls"
+)
+
+type countingListener struct {
+	gonet.Listener
+	accepted atomic.Int32
+}
+
+func (l *countingListener) Accept() (gonet.Conn, error) {
+	conn, err := l.Listener.Accept()
+	if err == nil {
+		l.accepted.Add(1)
+	}
+	return conn, err
+}
+
+func startDoTServer(t *testing.T) (*countingListener, []byte) {
+	c := cert.MustGenerate(nil, cert.DNSNames("dns.example.com"))
+	certificate, err := gotls.X509KeyPair(c.ToPEM())
+	common.Must(err)
+
+	l, err := gotls.Listen("tcp", "127.0.0.1:0", &gotls.Config{
+		Certificates: []gotls.Certificate{certificate},
+		NextProtos:   []string{NextProtoDoT},
+	})
+	common.Must(err)
+	listener := &countingListener{Listener: l}
+
+	server := &dns.Server{
+		Listener: listener,
+		Net:      "tcp-tls",
+		Handler:  &staticHandler{},
+	}
+	go server.ActivateAndServe()
+	t.Cleanup(func() { server.Shutdown() })
+	return listener, c.Certificate
+}
+
+func TestTLSLocalNameServer(t *testing.T) {
+	listener, certificate := startDoTServer(t)
+
+	url, err := url.Parse("tls+local://" + listener.Addr().String())
+	common.Must(err)
+	s, err := NewTLSLocalNameServer(url, &tls.Config{
+		ServerName:                       "dns.example.com",
+		PinnedPeerCertificateChainSha256: [][]byte{tls.GenerateCertChainHash([][]byte{certificate})},
+	}, true, net.IP(nil))
+	common.Must(err)
+
+	for i := 0; i < 3; i++ {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+		ips, _, err := s.QueryIP(ctx, "google.com", dns_feature.IPOption{
+			IPv4Enable: true,
+			IPv6Enable: true,
+		})
+		cancel()
+		common.Must(err)
+		if r := cmp.Diff(ips, []net.IP{{8, 8, 8, 8}}); r != "" {
+			t.Fatal(r)
+		}
+	}
+	if n := listener.accepted.Load(); n != 1 {
+		t.Error("expect the connection to be reused, but got ", n, " connections")
+	}
+}
+
+func TestTLSLocalNameServerPinMismatch(t *testing.T) {
+	listener, _ := startDoTServer(t)
+
+	url, err := url.Parse("tls+local://" + listener.Addr().String())
+	common.Must(err)
+	s, err := NewTLSLocalNameServer(url, &tls.Config{
+		ServerName:                       "dns.example.com",
+		PinnedPeerCertificateChainSha256: [][]byte{make([]byte, 32)},
+	}, true, net.IP(nil))
+	common.Must(err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	_, _, err = s.QueryIP(ctx, "google.com", dns_feature.IPOption{
+		IPv4Enable: true,
+	})
+	cancel()
+	if err == nil {
+		t.Error("expect an error of the pinned certificate chain hash")
+	}
+}
@@ -65,6 +65,21 @@ func (s *ClassicNameServer) Name() string {
 	return s.cacheController.name
 }
 
+func (s *ClassicNameServer) cache() *CacheController {
+	return s.cacheController
+}
+
+func (s *ClassicNameServer) setCachePolicy(policy CachePolicy) {
+	s.cacheController.setPolicy(policy, s.sendQuery)
+}
+
+// Close implements common.Closable.
+func (s *ClassicNameServer) Close() error {
+	s.requestsCleanup.Close()
+	s.udpServer.RemoveRay()
+	return s.cacheController.Close()
+}
+
 // RequestsCleanup clears expired items from cache
 func (s *ClassicNameServer) RequestsCleanup() error {
 	now := time.Now()
@@ -150,10 +165,20 @@ func (s *ClassicNameServer) addPendingRequest(req *udpDnsRequest) {
 	common.Must(s.requestsCleanup.Start())
 }
 
-func (s *ClassicNameServer) sendQuery(ctx context.Context, _ chan<- error, domain string, option dns_feature.IPOption) {
-	errors.LogDebug(ctx, s.Name(), " querying DNS for: ", domain)
+func (s *ClassicNameServer) sendQuery(ctx context.Context, noResponseErrCh chan<- error, domain string, option dns_feature.IPOption) {
+	s.sendRequests(ctx, noResponseErrCh, domain, buildReqMsgs(domain, option, s.newReqID, genEDNS0Options(s.clientIP, 0)))
+}
 
-	reqs := buildReqMsgs(domain, option, s.newReqID, genEDNS0Options(s.clientIP, 0))
+// QueryRecords implements recordServer.
+func (s *ClassicNameServer) QueryRecords(ctx context.Context, domain string, qType dnsmessage.Type) ([]dnsmessage.Resource, uint32, error) {
+	return s.cacheController.queryRecords(ctx, domain, qType, func(ctx context.Context, noResponseErrCh chan<- error, fqdn string) {
+		s.sendRequests(ctx, noResponseErrCh, fqdn, []*dnsRequest{buildRecordReqMsg(fqdn, qType, s.newReqID, genEDNS0Options(s.clientIP, 0))})
+	})
+}
+
+// sendRequests sends the requests for domain, and updates the cache with the responses.
+func (s *ClassicNameServer) sendRequests(ctx context.Context, _ chan<- error, domain string, reqs []*dnsRequest) {
+	errors.LogDebug(ctx, s.Name(), " querying DNS for: ", domain)
 
 	for _, req := range reqs {
 		udpReq := &udpDnsRequest{
@@ -177,7 +202,7 @@ func (s *ClassicNameServer) QueryIP(ctx context.Context, domain string, option d
 	if s.cacheController.disableCache {
 		errors.LogDebug(ctx, "DNS cache is disabled. Querying IP for ", domain, " at ", s.Name())
 	} else {
-		ips, ttl, err := s.cacheController.findIPsForDomain(fqdn, option)
+		ips, ttl, err := s.cacheController.findCachedIPsForDomain(fqdn, option)
 		if !go_errors.Is(err, errRecordNotFound) {
 			errors.LogDebugInner(ctx, err, s.Name(), " cache HIT ", domain, " -> ", ips)
 			log.Record(&log.DNSLog{Server: s.Name(), Domain: domain, Result: ips, Status: log.DNSCacheHit, Elapsed: 0, Error: err})
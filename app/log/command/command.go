@@ -29,6 +29,18 @@ func (s *LoggerServer) RestartLogger(ctx context.Context, request *RestartLogger
 	return &RestartLoggerResponse{}, nil
 }
 
+// SetLogFormat implements LoggerService.
+func (s *LoggerServer) SetLogFormat(ctx context.Context, request *SetLogFormatRequest) (*SetLogFormatResponse, error) {
+	logger, ok := s.V.GetFeature((*log.Instance)(nil)).(*log.Instance)
+	if !ok {
+		return nil, errors.New("unable to get logger instance")
+	}
+	if err := logger.SetFormat(request.ErrorLogFormat, request.AccessLogFormat); err != nil {
+		return nil, errors.New("failed to set log format").Base(err)
+	}
+	return &SetLogFormatResponse{}, nil
+}
+
 func (s *LoggerServer) mustEmbedUnimplementedLoggerServiceServer() {}
 
 type service struct {
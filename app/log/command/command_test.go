@@ -32,3 +32,29 @@ func TestLoggerRestart(t *testing.T) {
 	}
 	common.Must2(server.RestartLogger(context.Background(), &RestartLoggerRequest{}))
 }
+
+func TestLoggerSetLogFormat(t *testing.T) {
+	v, err := core.New(&core.Config{
+		App: []*serial.TypedMessage{
+			serial.ToTypedMessage(&log.Config{}),
+			serial.ToTypedMessage(&dispatcher.Config{}),
+			serial.ToTypedMessage(&proxyman.InboundConfig{}),
+			serial.ToTypedMessage(&proxyman.OutboundConfig{}),
+		},
+	})
+	common.Must(err)
+	common.Must(v.Start())
+
+	server := &LoggerServer{
+		V: v,
+	}
+	common.Must2(server.SetLogFormat(context.Background(), &SetLogFormatRequest{
+		ErrorLogFormat:  log.LogFormat_JSON,
+		AccessLogFormat: log.LogFormat_Text,
+	}))
+	if _, err := server.SetLogFormat(context.Background(), &SetLogFormatRequest{
+		ErrorLogFormat: log.LogFormat(2),
+	}); err == nil {
+		t.Error("expected error setting an unknown log format")
+	}
+}
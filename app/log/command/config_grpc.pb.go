@@ -20,6 +20,7 @@ const _ = grpc.SupportPackageIsVersion9
 
 const (
 	LoggerService_RestartLogger_FullMethodName = "/xray.app.log.command.LoggerService/RestartLogger"
+	LoggerService_SetLogFormat_FullMethodName  = "/xray.app.log.command.LoggerService/SetLogFormat"
 )
 
 // LoggerServiceClient is the client API for LoggerService service.
@@ -27,6 +28,7 @@ const (
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type LoggerServiceClient interface {
 	RestartLogger(ctx context.Context, in *RestartLoggerRequest, opts ...grpc.CallOption) (*RestartLoggerResponse, error)
+	SetLogFormat(ctx context.Context, in *SetLogFormatRequest, opts ...grpc.CallOption) (*SetLogFormatResponse, error)
 }
 
 type loggerServiceClient struct {
@@ -47,11 +49,22 @@ func (c *loggerServiceClient) RestartLogger(ctx context.Context, in *RestartLogg
 	return out, nil
 }
 
+func (c *loggerServiceClient) SetLogFormat(ctx context.Context, in *SetLogFormatRequest, opts ...grpc.CallOption) (*SetLogFormatResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetLogFormatResponse)
+	err := c.cc.Invoke(ctx, LoggerService_SetLogFormat_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // LoggerServiceServer is the server API for LoggerService service.
 // All implementations must embed UnimplementedLoggerServiceServer
 // for forward compatibility.
 type LoggerServiceServer interface {
 	RestartLogger(context.Context, *RestartLoggerRequest) (*RestartLoggerResponse, error)
+	SetLogFormat(context.Context, *SetLogFormatRequest) (*SetLogFormatResponse, error)
 	mustEmbedUnimplementedLoggerServiceServer()
 }
 
@@ -65,6 +78,9 @@ type UnimplementedLoggerServiceServer struct{}
 func (UnimplementedLoggerServiceServer) RestartLogger(context.Context, *RestartLoggerRequest) (*RestartLoggerResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method RestartLogger not implemented")
 }
+func (UnimplementedLoggerServiceServer) SetLogFormat(context.Context, *SetLogFormatRequest) (*SetLogFormatResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetLogFormat not implemented")
+}
 func (UnimplementedLoggerServiceServer) mustEmbedUnimplementedLoggerServiceServer() {}
 func (UnimplementedLoggerServiceServer) testEmbeddedByValue()                       {}
 
@@ -104,6 +120,24 @@ func _LoggerService_RestartLogger_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _LoggerService_SetLogFormat_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetLogFormatRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LoggerServiceServer).SetLogFormat(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LoggerService_SetLogFormat_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LoggerServiceServer).SetLogFormat(ctx, req.(*SetLogFormatRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // LoggerService_ServiceDesc is the grpc.ServiceDesc for LoggerService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -115,6 +149,10 @@ var LoggerService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "RestartLogger",
 			Handler:    _LoggerService_RestartLogger_Handler,
 		},
+		{
+			MethodName: "SetLogFormat",
+			Handler:    _LoggerService_SetLogFormat_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "app/log/command/config.proto",
@@ -0,0 +1,47 @@
+package log
+
+import (
+	golog "log"
+
+	"github.com/xtls/xray-core/common/log"
+)
+
+// fileLogWriter is a log.Writer of a rotating file. The file is opened on the first write, and closed with the
+// writer.
+type fileLogWriter struct {
+	file   *rotatingFile
+	logger *golog.Logger
+}
+
+func (w *fileLogWriter) Write(s string) error {
+	w.logger.Print(s)
+	return nil
+}
+
+// Close closes the file, and waits for the files rotated to be compressed and pruned.
+func (w *fileLogWriter) Close() error {
+	w.file.Lock()
+	err := w.file.close()
+	w.file.Unlock()
+	w.file.wait()
+	return err
+}
+
+// createFileLogWriter returns a log.WriterCreator of the file at path, which is rotated per rotation. The messages
+// are prefixed with their timestamp if withTimestamp is true.
+func createFileLogWriter(path string, rotation *LogRotation, withTimestamp bool) (log.WriterCreator, error) {
+	file, err := newRotatingFile(path, rotation)
+	if err != nil {
+		return nil, err
+	}
+	flags := 0
+	if withTimestamp {
+		flags = golog.Ldate | golog.Ltime | golog.Lmicroseconds
+	}
+	return func() log.Writer {
+		return &fileLogWriter{
+			file:   file,
+			logger: golog.New(file, "", flags),
+		}
+	}, nil
+}
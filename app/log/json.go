@@ -0,0 +1,100 @@
+package log
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/xtls/xray-core/common/log"
+	"github.com/xtls/xray-core/common/serial"
+)
+
+// jsonRecord is a log message with structured fields, written as a line of JSON.
+type jsonRecord struct {
+	Time    string `json:"time"`
+	Type    string `json:"type"`
+	Level   string `json:"level,omitempty"`
+	Message string `json:"message,omitempty"`
+
+	// Access logs
+	Status      string `json:"status,omitempty"`
+	InboundTag  string `json:"inbound_tag,omitempty"`
+	OutboundTag string `json:"outbound_tag,omitempty"`
+	Email       string `json:"email,omitempty"`
+	Source      string `json:"source,omitempty"`
+	Destination string `json:"destination,omitempty"`
+	Domain      string `json:"domain,omitempty"`
+	Reason      string `json:"reason,omitempty"`
+	Uplink      *int64 `json:"uplink_bytes,omitempty"`
+	Downlink    *int64 `json:"downlink_bytes,omitempty"`
+
+	// DNS logs
+	Server  string   `json:"server,omitempty"`
+	Result  []string `json:"result,omitempty"`
+	Cached  bool     `json:"cached,omitempty"`
+	Elapsed float64  `json:"elapsed_ms,omitempty"`
+	Error   string   `json:"error,omitempty"`
+}
+
+// jsonMessage is a log.Message rendered as JSON. The record is built when the message is handled,
+// so that its timestamp is the time of the event rather than the time it is written.
+type jsonMessage struct {
+	record *jsonRecord
+}
+
+func newJSONMessage(msg log.Message, maskMode string) *jsonMessage {
+	r := &jsonRecord{
+		Time: time.Now().Format(time.RFC3339Nano),
+	}
+	mask := func(s string) string {
+		return maskAddress(s, maskMode)
+	}
+
+	switch msg := msg.(type) {
+	case *log.GeneralMessage:
+		r.Type = "error"
+		r.Level = strings.ToLower(msg.Severity.String())
+		r.Message = mask(serial.ToString(msg.Content))
+	case *log.AccessMessage:
+		r.Type = "access"
+		r.Level = "info"
+		r.Status = string(msg.Status)
+		r.InboundTag = msg.InboundTag
+		r.OutboundTag = msg.OutboundTag
+		r.Email = msg.Email
+		r.Source = mask(serial.ToString(msg.From))
+		r.Destination = mask(serial.ToString(msg.To))
+		r.Domain = msg.Domain
+		r.Reason = mask(serial.ToString(msg.Reason))
+		if msg.Status == log.AccessClosed {
+			r.Uplink = &msg.Uplink
+			r.Downlink = &msg.Downlink
+		}
+	case *log.DNSLog:
+		r.Type = "dns"
+		r.Level = "info"
+		r.Server = msg.Server
+		r.Domain = msg.Domain
+		for _, ip := range msg.Result {
+			r.Result = append(r.Result, mask(ip.String()))
+		}
+		r.Cached = msg.Status == log.DNSCacheHit
+		r.Elapsed = float64(msg.Elapsed) / float64(time.Millisecond)
+		if msg.Error != nil {
+			r.Error = msg.Error.Error()
+		}
+	default:
+		r.Type = "error"
+		r.Message = mask(msg.String())
+	}
+	return &jsonMessage{record: r}
+}
+
+// String implements log.Message.
+func (m *jsonMessage) String() string {
+	b, err := json.Marshal(m.record)
+	if err != nil {
+		return `{"type":"error","message":"failed to marshal log message"}`
+	}
+	return string(b)
+}
@@ -20,10 +20,15 @@ type Instance struct {
 	errorLogger  log.Handler
 	active       bool
 	dns          bool
+	// stopReopen stops reopening the log files on signal.
+	stopReopen func()
 }
 
 // New creates a new log.Instance based on the given config.
 func New(ctx context.Context, config *Config) (*Instance, error) {
+	if err := checkFormats(config.ErrorLogFormat, config.AccessLogFormat); err != nil {
+		return nil, err
+	}
 	g := &Instance{
 		config: config,
 		active: false,
@@ -43,7 +48,9 @@ func New(ctx context.Context, config *Config) (*Instance, error) {
 
 func (g *Instance) initAccessLogger() error {
 	handler, err := createHandler(g.config.AccessLogType, HandlerCreatorOptions{
-		Path: g.config.AccessLogPath,
+		Path:     g.config.AccessLogPath,
+		Format:   g.config.AccessLogFormat,
+		Rotation: g.config.Rotation,
 	})
 	if err != nil {
 		return err
@@ -54,7 +61,9 @@ func (g *Instance) initAccessLogger() error {
 
 func (g *Instance) initErrorLogger() error {
 	handler, err := createHandler(g.config.ErrorLogType, HandlerCreatorOptions{
-		Path: g.config.ErrorLogPath,
+		Path:     g.config.ErrorLogPath,
+		Format:   g.config.ErrorLogFormat,
+		Rotation: g.config.Rotation,
 	})
 	if err != nil {
 		return err
@@ -84,6 +93,9 @@ func (g *Instance) startInternal() error {
 	if err := g.initErrorLogger(); err != nil {
 		return errors.New("failed to initialize error logger").Base(err).AtWarning()
 	}
+	if g.config.AccessLogType == LogType_File || g.config.ErrorLogType == LogType_File {
+		g.stopReopen = watchReopenSignal()
+	}
 
 	return nil
 }
@@ -102,31 +114,68 @@ func (g *Instance) Handle(msg log.Message) {
 		return
 	}
 
-	var Msg log.Message
-	if g.config.MaskAddress != "" {
-		Msg = &MaskedMsgWrapper{Message: msg, config: g.config}
-	} else {
-		Msg = msg
-	}
-
 	switch msg := msg.(type) {
 	case *log.AccessMessage:
+		if msg.Status == log.AccessClosed && g.config.AccessLogFormat != LogFormat_JSON {
+			return
+		}
 		if g.accessLogger != nil {
-			g.accessLogger.Handle(Msg)
+			g.accessLogger.Handle(g.format(msg, g.config.AccessLogFormat))
 		}
 	case *log.DNSLog:
 		if g.dns && g.accessLogger != nil {
-			g.accessLogger.Handle(Msg)
+			g.accessLogger.Handle(g.format(msg, g.config.AccessLogFormat))
 		}
 	case *log.GeneralMessage:
 		if g.errorLogger != nil && msg.Severity <= g.config.ErrorLogLevel {
-			g.errorLogger.Handle(Msg)
+			g.errorLogger.Handle(g.format(msg, g.config.ErrorLogFormat))
 		}
 	default:
 		// Swallow
 	}
 }
 
+// format prepares the message for a logger writing in the given format.
+func (g *Instance) format(msg log.Message, format LogFormat) log.Message {
+	if format == LogFormat_JSON {
+		return newJSONMessage(msg, g.config.MaskAddress)
+	}
+	if g.config.MaskAddress != "" {
+		return &MaskedMsgWrapper{Message: msg, config: g.config}
+	}
+	return msg
+}
+
+// checkFormats returns an error if any of the formats is unknown.
+func checkFormats(formats ...LogFormat) error {
+	for _, format := range formats {
+		if _, found := LogFormat_name[int32(format)]; !found {
+			return errors.New("unknown log format: ", int32(format))
+		}
+	}
+	return nil
+}
+
+// SetFormat switches the error and access logs to the given formats, restarting the loggers if needed.
+func (g *Instance) SetFormat(errorFormat, accessFormat LogFormat) error {
+	if err := checkFormats(errorFormat, accessFormat); err != nil {
+		return err
+	}
+	g.Lock()
+	changed := g.config.ErrorLogFormat != errorFormat || g.config.AccessLogFormat != accessFormat
+	g.config.ErrorLogFormat = errorFormat
+	g.config.AccessLogFormat = accessFormat
+	g.Unlock()
+
+	if !changed {
+		return nil
+	}
+	if err := g.Close(); err != nil {
+		return err
+	}
+	return g.Start()
+}
+
 // Close implements common.Closable.Close().
 func (g *Instance) Close() error {
 	errors.LogDebug(context.Background(), "Logger closing")
@@ -146,6 +195,11 @@ func (g *Instance) Close() error {
 	common.Close(g.errorLogger)
 	g.errorLogger = nil
 
+	if g.stopReopen != nil {
+		g.stopReopen()
+		g.stopReopen = nil
+	}
+
 	return nil
 }
 
@@ -156,15 +210,24 @@ type MaskedMsgWrapper struct {
 }
 
 func (m *MaskedMsgWrapper) String() string {
-	str := m.Message.String()
+	return maskAddress(m.Message.String(), m.config.MaskAddress)
+}
+
+var (
+	ipv4Regex = regexp.MustCompile(`(\d{1,3}\.){3}\d{1,3}`)
+	ipv6Regex = regexp.MustCompile(`((?:[\da-fA-F]{0,4}:[\da-fA-F]{0,4}){2,7})(?:[\/\\%](\d{1,3}))?`)
+)
 
-	ipv4Regex := regexp.MustCompile(`(\d{1,3}\.){3}\d{1,3}`)
-	ipv6Regex := regexp.MustCompile(`((?:[\da-fA-F]{0,4}:[\da-fA-F]{0,4}){2,7})(?:[\/\\%](\d{1,3}))?`)
+// maskAddress masks the IP addresses in str according to mode, which is one of "half", "quarter" and "full".
+func maskAddress(str string, mode string) string {
+	if mode == "" {
+		return str
+	}
 
 	// Process ipv4
 	maskedMsg := ipv4Regex.ReplaceAllStringFunc(str, func(ip string) string {
 		parts := strings.Split(ip, ".")
-		switch m.config.MaskAddress {
+		switch mode {
 		case "half":
 			return fmt.Sprintf("%s.%s.*.*", parts[0], parts[1])
 		case "quarter":
@@ -179,7 +242,7 @@ func (m *MaskedMsgWrapper) String() string {
 	// process ipv6
 	maskedMsg = ipv6Regex.ReplaceAllStringFunc(maskedMsg, func(ip string) string {
 		parts := strings.Split(ip, ":")
-		switch m.config.MaskAddress {
+		switch mode {
 		case "half":
 			if len(parts) >= 2 {
 				return fmt.Sprintf("%s:%s::/32", parts[0], parts[1])
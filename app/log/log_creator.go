@@ -9,7 +9,9 @@ import (
 )
 
 type HandlerCreatorOptions struct {
-	Path string
+	Path     string
+	Format   LogFormat
+	Rotation *LogRotation
 }
 
 type HandlerCreator func(LogType, HandlerCreatorOptions) (log.Handler, error)
@@ -43,11 +45,14 @@ func createHandler(logType LogType, options HandlerCreatorOptions) (log.Handler,
 
 func init() {
 	common.Must(RegisterHandlerCreator(LogType_Console, func(lt LogType, options HandlerCreatorOptions) (log.Handler, error) {
+		if options.Format == LogFormat_JSON {
+			return log.NewLogger(log.CreatePlainStdoutLogWriter()), nil
+		}
 		return log.NewLogger(log.CreateStdoutLogWriter()), nil
 	}))
 
 	common.Must(RegisterHandlerCreator(LogType_File, func(lt LogType, options HandlerCreatorOptions) (log.Handler, error) {
-		creator, err := log.CreateFileLogWriter(options.Path)
+		creator, err := createFileLogWriter(options.Path, options.Rotation, options.Format != LogFormat_JSON)
 		if err != nil {
 			return nil, err
 		}
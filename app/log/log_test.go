@@ -2,6 +2,7 @@ package log_test
 
 import (
 	"context"
+	"encoding/json"
 	"testing"
 
 	"github.com/golang/mock/gomock"
@@ -50,3 +51,95 @@ func TestCustomLogHandler(t *testing.T) {
 
 	common.Must(logger.Close())
 }
+
+func TestJSONLogFormat(t *testing.T) {
+	mockCtl := gomock.NewController(t)
+	defer mockCtl.Finish()
+
+	var loggedValue []string
+
+	mockHandler := mocks.NewLogHandler(mockCtl)
+	mockHandler.EXPECT().Handle(gomock.Any()).AnyTimes().DoAndReturn(func(msg clog.Message) {
+		loggedValue = append(loggedValue, msg.String())
+	})
+
+	log.RegisterHandlerCreator(log.LogType_Console, func(lt log.LogType, options log.HandlerCreatorOptions) (clog.Handler, error) {
+		return mockHandler, nil
+	})
+
+	logger, err := log.New(context.Background(), &log.Config{
+		ErrorLogLevel:   clog.Severity_Warning,
+		ErrorLogType:    log.LogType_None,
+		AccessLogType:   log.LogType_Console,
+		AccessLogFormat: log.LogFormat_Text,
+	})
+	common.Must(err)
+	common.Must(logger.Start())
+
+	msg := &clog.AccessMessage{
+		From:        "1.2.3.4:5678",
+		To:          "tcp:example.com:443",
+		Status:      clog.AccessAccepted,
+		Email:       "love@xray.com",
+		InboundTag:  "in",
+		OutboundTag: "out",
+		Domain:      "example.com",
+	}
+	clog.Record(msg)
+	clog.Record(&clog.AccessMessage{Status: clog.AccessClosed})
+	if len(loggedValue) != 1 {
+		t.Fatal("expected 1 log message in text format, but actually ", loggedValue)
+	}
+
+	common.Must(logger.SetFormat(log.LogFormat_Text, log.LogFormat_JSON))
+	loggedValue = nil
+	clog.Record(msg)
+	closed := *msg
+	closed.Status = clog.AccessClosed
+	closed.Uplink = 10
+	clog.Record(&closed)
+	if len(loggedValue) != 2 {
+		t.Fatal("expected 2 log messages in JSON format, but actually ", loggedValue)
+	}
+
+	var record map[string]interface{}
+	common.Must(json.Unmarshal([]byte(loggedValue[0]), &record))
+	for k, v := range map[string]interface{}{
+		"type":         "access",
+		"status":       "accepted",
+		"inbound_tag":  "in",
+		"outbound_tag": "out",
+		"email":        "love@xray.com",
+		"source":       "1.2.3.4:5678",
+		"destination":  "tcp:example.com:443",
+		"domain":       "example.com",
+	} {
+		if record[k] != v {
+			t.Error("expected ", k, " to be ", v, ", but actually ", record[k])
+		}
+	}
+	if _, found := record["uplink_bytes"]; found {
+		t.Error("unexpected uplink_bytes in ", loggedValue[0])
+	}
+
+	common.Must(json.Unmarshal([]byte(loggedValue[1]), &record))
+	if record["status"] != "closed" || record["uplink_bytes"] != float64(10) || record["downlink_bytes"] != float64(0) {
+		t.Error("unexpected closed record ", loggedValue[1])
+	}
+
+	if err := logger.SetFormat(log.LogFormat_Text, log.LogFormat(2)); err == nil {
+		t.Error("expected error setting an unknown log format")
+	}
+
+	common.Must(logger.Close())
+}
+
+func TestUnknownLogFormat(t *testing.T) {
+	if _, err := log.New(context.Background(), &log.Config{
+		ErrorLogType:   log.LogType_None,
+		AccessLogType:  log.LogType_None,
+		ErrorLogFormat: log.LogFormat(2),
+	}); err == nil {
+		t.Error("expected error creating logger of an unknown log format")
+	}
+}
@@ -0,0 +1,52 @@
+//go:build !windows
+// +build !windows
+
+package log
+
+import (
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+)
+
+// reopenSignal is the SIGUSR1 handler shared by all log instances writing files.
+var reopenSignal struct {
+	sync.Mutex
+	watchers int
+	signals  chan os.Signal
+}
+
+// watchReopenSignal reopens the log files on SIGUSR1, until the returned function is called. The signal is only
+// handled once, however many instances watch it.
+func watchReopenSignal() (stop func()) {
+	reopenSignal.Lock()
+	defer reopenSignal.Unlock()
+
+	reopenSignal.watchers++
+	if reopenSignal.watchers == 1 {
+		signals := make(chan os.Signal, 1)
+		signal.Notify(signals, syscall.SIGUSR1)
+		reopenSignal.signals = signals
+		go func() {
+			for range signals {
+				reopenFiles()
+			}
+		}()
+	}
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			reopenSignal.Lock()
+			defer reopenSignal.Unlock()
+
+			reopenSignal.watchers--
+			if reopenSignal.watchers == 0 {
+				signal.Stop(reopenSignal.signals)
+				close(reopenSignal.signals)
+				reopenSignal.signals = nil
+			}
+		})
+	}
+}
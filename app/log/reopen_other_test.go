@@ -0,0 +1,37 @@
+//go:build !windows
+// +build !windows
+
+package log
+
+import (
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/xtls/xray-core/common"
+)
+
+func TestWatchReopenSignal(t *testing.T) {
+	stop1 := watchReopenSignal()
+	stop2 := watchReopenSignal()
+
+	generation := reopenGeneration.Load()
+	common.Must(syscall.Kill(syscall.Getpid(), syscall.SIGUSR1))
+	deadline := time.Now().Add(5 * time.Second)
+	for reopenGeneration.Load() == generation {
+		if time.Now().After(deadline) {
+			t.Fatal("log files are not reopened on signal")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	stop1()
+	stop1()
+	if reopenSignal.signals == nil || reopenSignal.watchers != 1 {
+		t.Error("expected the signal to be watched until all watchers stop, but watchers are ", reopenSignal.watchers)
+	}
+	stop2()
+	if reopenSignal.signals != nil || reopenSignal.watchers != 0 {
+		t.Error("expected the signal not to be watched, but watchers are ", reopenSignal.watchers)
+	}
+}
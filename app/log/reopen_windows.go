@@ -0,0 +1,9 @@
+//go:build windows
+// +build windows
+
+package log
+
+// watchReopenSignal does nothing, as there is no SIGUSR1 on Windows.
+func watchReopenSignal() (stop func()) {
+	return func() {}
+}
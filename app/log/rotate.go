@@ -0,0 +1,228 @@
+package log
+
+import (
+	"compress/gzip"
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/xtls/xray-core/common/errors"
+)
+
+const rotationTimeFormat = "20060102-150405.000"
+
+// reopenGeneration is increased on each reopen signal. Files opened in an older generation are reopened before
+// their next write.
+var reopenGeneration atomic.Uint64
+
+// rotatingFile is a log file which is rotated by size and age, and reopened on signal.
+type rotatingFile struct {
+	sync.Mutex
+	path     string
+	rotation *LogRotation
+
+	file       *os.File
+	size       int64
+	openedAt   time.Time
+	generation uint64
+	now        func() time.Time
+
+	// cleanup serializes compression and removal of rotated files, and cleanups waits for them.
+	cleanup  sync.Mutex
+	cleanups sync.WaitGroup
+}
+
+func newRotatingFile(path string, rotation *LogRotation) (*rotatingFile, error) {
+	f := &rotatingFile{
+		path:     path,
+		rotation: rotation,
+		now:      time.Now,
+	}
+	if err := f.open(); err != nil {
+		return nil, err
+	}
+	f.close()
+	return f, nil
+}
+
+func (f *rotatingFile) open() error {
+	file, err := os.OpenFile(f.path, os.O_APPEND|os.O_WRONLY|os.O_CREATE, 0o600)
+	if err != nil {
+		return err
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return err
+	}
+	f.file = file
+	f.size = info.Size()
+	if f.openedAt.IsZero() {
+		f.openedAt = f.now()
+	}
+	f.generation = reopenGeneration.Load()
+	return nil
+}
+
+func (f *rotatingFile) close() error {
+	if f.file == nil {
+		return nil
+	}
+	err := f.file.Close()
+	f.file = nil
+	return err
+}
+
+// wait waits for the rotated files to be compressed and pruned.
+func (f *rotatingFile) wait() {
+	f.cleanups.Wait()
+}
+
+// Write implements io.Writer. Each call is a whole log line.
+func (f *rotatingFile) Write(p []byte) (int, error) {
+	f.Lock()
+	defer f.Unlock()
+
+	if f.file != nil && f.generation != reopenGeneration.Load() {
+		f.close()
+	}
+	if f.file != nil && f.shouldRotate(len(p)) {
+		if err := f.rotate(); err != nil {
+			return 0, err
+		}
+	}
+	if f.file == nil {
+		if err := f.open(); err != nil {
+			return 0, err
+		}
+	}
+	n, err := f.file.Write(p)
+	f.size += int64(n)
+	return n, err
+}
+
+func (f *rotatingFile) shouldRotate(n int) bool {
+	if f.rotation == nil || f.size == 0 {
+		return false
+	}
+	if f.rotation.MaxSize > 0 && f.size+int64(n) > f.rotation.MaxSize {
+		return true
+	}
+	if f.rotation.MaxAge > 0 && f.now().Sub(f.openedAt) >= time.Duration(f.rotation.MaxAge)*time.Second {
+		return true
+	}
+	return false
+}
+
+// rotate renames the current file with a timestamp suffix and opens a new one. Rotated files are compressed and
+// pruned in the background.
+func (f *rotatingFile) rotate() error {
+	f.close()
+	now := f.now()
+	rotated := f.rotatedPath(now)
+	if err := os.Rename(f.path, rotated); err != nil {
+		return errors.New("failed to rotate log file ", f.path).Base(err)
+	}
+	f.openedAt = now
+	if err := f.open(); err != nil {
+		return err
+	}
+	f.cleanups.Add(1)
+	go f.cleanupRotated(rotated)
+	return nil
+}
+
+// rotatedPath returns the path of the file rotated at t. If a backup of the same millisecond exists, the timestamp is
+// moved on, so that backups are neither overwritten nor out of order.
+func (f *rotatingFile) rotatedPath(t time.Time) string {
+	for {
+		rotated := f.path + "." + t.Format(rotationTimeFormat)
+		if !fileExists(rotated) && !fileExists(rotated+".gz") {
+			return rotated
+		}
+		t = t.Add(time.Millisecond)
+	}
+}
+
+func fileExists(path string) bool {
+	_, err := os.Lstat(path)
+	return err == nil
+}
+
+func (f *rotatingFile) cleanupRotated(rotated string) {
+	defer f.cleanups.Done()
+	f.cleanup.Lock()
+	defer f.cleanup.Unlock()
+
+	// Errors are not logged, as logging them would write to this very file.
+	if f.rotation.Compress {
+		compressFile(rotated)
+	}
+	if f.rotation.MaxBackups == 0 {
+		return
+	}
+	prefix := filepath.Base(f.path) + "."
+	entries, err := os.ReadDir(filepath.Dir(f.path))
+	if err != nil {
+		return
+	}
+	var backups []string
+	for _, entry := range entries {
+		name := entry.Name()
+		if !entry.IsDir() && strings.HasPrefix(name, prefix) {
+			if _, err := time.Parse(rotationTimeFormat, strings.TrimSuffix(strings.TrimPrefix(name, prefix), ".gz")); err == nil {
+				backups = append(backups, name)
+			}
+		}
+	}
+	if len(backups) <= int(f.rotation.MaxBackups) {
+		return
+	}
+	sort.Strings(backups)
+	for _, name := range backups[:len(backups)-int(f.rotation.MaxBackups)] {
+		os.Remove(filepath.Join(filepath.Dir(f.path), name))
+	}
+}
+
+// compressFile replaces the file at path with its gzip compressed version.
+func compressFile(path string) error {
+	src, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+	dst, err := os.OpenFile(path+".gz.tmp", os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o600)
+	if err != nil {
+		return err
+	}
+	defer os.Remove(dst.Name())
+
+	zw := gzip.NewWriter(dst)
+	if _, err := io.Copy(zw, src); err != nil {
+		dst.Close()
+		return err
+	}
+	if err := zw.Close(); err != nil {
+		dst.Close()
+		return err
+	}
+	if err := dst.Close(); err != nil {
+		return err
+	}
+	if err := os.Rename(dst.Name(), path+".gz"); err != nil {
+		return err
+	}
+	return os.Remove(path)
+}
+
+// reopenFiles makes all log files reopen before their next write, e.g. after they are moved by logrotate.
+func reopenFiles() {
+	reopenGeneration.Add(1)
+	errors.LogInfo(context.Background(), "reopening log files")
+}
@@ -0,0 +1,88 @@
+package log
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/xtls/xray-core/common"
+)
+
+func newTestRotatingFile(t *testing.T, rotation *LogRotation, now *time.Time) *rotatingFile {
+	f, err := newRotatingFile(filepath.Join(t.TempDir(), "error.log"), rotation)
+	common.Must(err)
+	f.now = func() time.Time { return *now }
+	f.openedAt = *now
+	return f
+}
+
+func closeTestRotatingFile(f *rotatingFile) {
+	f.Lock()
+	f.close()
+	f.Unlock()
+	f.wait()
+}
+
+func TestRotateBySize(t *testing.T) {
+	now := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	f := newTestRotatingFile(t, &LogRotation{MaxSize: 100, MaxBackups: 2, Compress: true}, &now)
+
+	// All 3 rotations happen in the same millisecond, and the oldest backup is pruned.
+	line := []byte(strings.Repeat("x", 40) + "\n")
+	for i := 0; i < 7; i++ {
+		_, err := f.Write(line)
+		common.Must(err)
+	}
+	closeTestRotatingFile(f)
+
+	backups, err := filepath.Glob(f.path + ".*")
+	common.Must(err)
+	expected := []string{
+		f.path + ".20260102-030405.001.gz",
+		f.path + ".20260102-030405.002.gz",
+	}
+	if strings.Join(backups, ",") != strings.Join(expected, ",") {
+		t.Error("expected backups ", expected, ", but actually ", backups)
+	}
+	info, err := os.Stat(f.path)
+	common.Must(err)
+	if info.Size() != int64(len(line)) {
+		t.Error("expected log file to be rotated, but its size is ", info.Size())
+	}
+}
+
+func TestRotateByAge(t *testing.T) {
+	now := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	f := newTestRotatingFile(t, &LogRotation{MaxAge: 60}, &now)
+
+	line := []byte("x\n")
+	_, err := f.Write(line)
+	common.Must(err)
+	now = now.Add(59 * time.Second)
+	_, err = f.Write(line)
+	common.Must(err)
+	if backups, _ := filepath.Glob(f.path + ".*"); len(backups) != 0 {
+		t.Error("expected no rotation before the max age, but actually ", backups)
+	}
+
+	now = now.Add(time.Second)
+	_, err = f.Write(line)
+	common.Must(err)
+	now = now.Add(30 * time.Second)
+	_, err = f.Write(line)
+	common.Must(err)
+	closeTestRotatingFile(f)
+
+	backups, err := filepath.Glob(f.path + ".*")
+	common.Must(err)
+	if len(backups) != 1 || backups[0] != f.path+".20260102-030505.000" {
+		t.Error("expected 1 backup rotated at 03:05:05, but actually ", backups)
+	}
+	info, err := os.Stat(f.path)
+	common.Must(err)
+	if info.Size() != int64(2*len(line)) {
+		t.Error("expected 2 lines in the new log file, but its size is ", info.Size())
+	}
+}
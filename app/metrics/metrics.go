@@ -20,6 +20,7 @@ import (
 )
 
 type MetricsHandler struct {
+	instance     *core.Instance
 	ohm          outbound.Manager
 	statsManager feature_stats.Manager
 	observatory  extension.Observatory
@@ -31,13 +32,17 @@ type MetricsHandler struct {
 // NewMetricsHandler creates a new MetricsHandler based on the given config.
 func NewMetricsHandler(ctx context.Context, config *Config) (*MetricsHandler, error) {
 	c := &MetricsHandler{
-		tag:    config.Tag,
-		listen: config.Listen,
+		instance: core.MustFromContext(ctx),
+		tag:      config.Tag,
+		listen:   config.Listen,
 	}
 	common.Must(core.RequireFeatures(ctx, func(om outbound.Manager, sm feature_stats.Manager) {
 		c.statsManager = sm
 		c.ohm = om
 	}))
+	common.Must(core.OptionalFeatures(ctx, func(observatory extension.Observatory) {
+		c.observatory = observatory
+	}))
 	expvar.Publish("stats", expvar.Func(func() interface{} {
 		manager, ok := c.statsManager.(*stats.Manager)
 		if !ok {
@@ -50,6 +55,9 @@ func NewMetricsHandler(ctx context.Context, config *Config) (*MetricsHandler, er
 		}
 		manager.VisitCounters(func(name string, counter feature_stats.Counter) bool {
 			nameSplit := strings.Split(name, ">>>")
+			if len(nameSplit) != 4 || nameSplit[2] != "traffic" || resp[nameSplit[0]] == nil {
+				return true
+			}
 			typeName, tagOrUser, direction := nameSplit[0], nameSplit[1], nameSplit[3]
 			if item, found := resp[typeName][tagOrUser]; found {
 				item[direction] = counter.Value()
@@ -64,13 +72,7 @@ func NewMetricsHandler(ctx context.Context, config *Config) (*MetricsHandler, er
 	}))
 	expvar.Publish("observatory", expvar.Func(func() interface{} {
 		if c.observatory == nil {
-			common.Must(core.RequireFeatures(ctx, func(observatory extension.Observatory) error {
-				c.observatory = observatory
-				return nil
-			}))
-			if c.observatory == nil {
-				return nil
-			}
+			return nil
 		}
 		resp := map[string]*observatory.OutboundStatus{}
 		if o, err := c.observatory.GetObservation(context.Background()); err != nil {
@@ -82,6 +84,7 @@ func NewMetricsHandler(ctx context.Context, config *Config) (*MetricsHandler, er
 		}
 		return resp
 	}))
+	http.HandleFunc("/metrics", c.serveOpenMetrics)
 	return c, nil
 }
 
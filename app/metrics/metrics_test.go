@@ -0,0 +1,150 @@
+package metrics_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/xtls/xray-core/app/dispatcher"
+	. "github.com/xtls/xray-core/app/metrics"
+	"github.com/xtls/xray-core/app/observatory"
+	"github.com/xtls/xray-core/app/proxyman"
+	_ "github.com/xtls/xray-core/app/proxyman/outbound"
+	"github.com/xtls/xray-core/app/stats"
+	"github.com/xtls/xray-core/common"
+	"github.com/xtls/xray-core/common/serial"
+	"github.com/xtls/xray-core/core"
+	feature_stats "github.com/xtls/xray-core/features/stats"
+)
+
+var (
+	instanceOnce sync.Once
+	instance     *core.Instance
+)
+
+// getInstance returns the instance with the metrics app. It is shared by all tests, as the metrics app registers
+// its handlers in the global expvar and http.DefaultServeMux.
+func getInstance() *core.Instance {
+	instanceOnce.Do(func() {
+		v, err := core.New(&core.Config{
+			App: []*serial.TypedMessage{
+				serial.ToTypedMessage(&stats.Config{}),
+				serial.ToTypedMessage(&dispatcher.Config{}),
+				serial.ToTypedMessage(&proxyman.OutboundConfig{}),
+				serial.ToTypedMessage(&Config{Tag: "metrics_out"}),
+				serial.ToTypedMessage(&observatory.Config{}),
+			},
+		})
+		common.Must(err)
+		instance = v
+	})
+	return instance
+}
+
+func setCounter(t *testing.T, name string, value int64) {
+	t.Helper()
+	manager := getInstance().GetFeature(feature_stats.ManagerType()).(feature_stats.Manager)
+	c, err := feature_stats.GetOrRegisterCounter(manager, name)
+	common.Must(err)
+	c.Set(value)
+}
+
+func get(t *testing.T, path string) *httptest.ResponseRecorder {
+	t.Helper()
+	w := httptest.NewRecorder()
+	http.DefaultServeMux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+	if w.Code != http.StatusOK {
+		t.Fatal("unexpected status of ", path, ": ", w.Code)
+	}
+	return w
+}
+
+func TestExpvarStats(t *testing.T) {
+	setCounter(t, "inbound>>>in>>>traffic>>>uplink", 10)
+	setCounter(t, "user>>>u@example.com>>>traffic>>>downlink", 20)
+	setCounter(t, "rule>>>r1>>>hits", 3)
+	setCounter(t, "rule>>>r1>>>traffic>>>uplink", 30)
+
+	var vars struct {
+		Stats map[string]map[string]map[string]int64 `json:"stats"`
+	}
+	common.Must(json.Unmarshal(get(t, "/debug/vars").Body.Bytes(), &vars))
+
+	if v := vars.Stats["inbound"]["in"]["uplink"]; v != 10 {
+		t.Error("expect inbound uplink 10, but actually ", v)
+	}
+	if v := vars.Stats["user"]["u@example.com"]["downlink"]; v != 20 {
+		t.Error("expect user downlink 20, but actually ", v)
+	}
+	if _, found := vars.Stats["rule"]; found {
+		t.Error("unexpected rule stats: ", vars.Stats["rule"])
+	}
+}
+
+func TestOpenMetrics(t *testing.T) {
+	setCounter(t, "inbound>>>om_in>>>traffic>>>uplink", 10)
+	setCounter(t, "user>>>om@example.com>>>traffic>>>downlink", 20)
+	setCounter(t, `rule>>>om"r1>>>hits`, 3)
+
+	w := get(t, "/metrics")
+	if ct := w.Header().Get("Content-Type"); ct != "application/openmetrics-text; version=1.0.0; charset=utf-8" {
+		t.Error("unexpected content type: ", ct)
+	}
+	body := w.Body.String()
+	if !strings.HasSuffix(body, "\n# EOF\n") {
+		t.Error("metrics do not end with # EOF")
+	}
+
+	lines := strings.Split(strings.TrimSuffix(body, "\n"), "\n")
+	for _, expected := range []string{
+		"# TYPE xray_build info",
+		"# TYPE xray_inbound_traffic_bytes counter",
+		"# HELP xray_inbound_traffic_bytes Traffic of inbounds in bytes.",
+		`xray_inbound_traffic_bytes_total{tag="om_in",direction="uplink"} 10`,
+		"# TYPE xray_user_traffic_bytes counter",
+		`xray_user_traffic_bytes_total{user="om@example.com",direction="downlink"} 20`,
+		"# TYPE xray_counter gauge",
+		`xray_counter{name="rule>>>om\"r1>>>hits"} 3`,
+		"# TYPE go_goroutines gauge",
+	} {
+		found := false
+		for _, line := range lines {
+			if line == expected {
+				found = true
+				break
+			}
+		}
+		if !found {
+			t.Error("expect line ", expected, " in metrics:\n", body)
+		}
+	}
+
+	// Each family is declared once, before its samples, which are named after it.
+	families := map[string]bool{}
+	family := ""
+	for _, line := range lines[:len(lines)-1] {
+		if name, found := strings.CutPrefix(line, "# TYPE "); found {
+			name, typ, _ := strings.Cut(name, " ")
+			if families[name] {
+				t.Error("family ", name, " is declared twice")
+			}
+			families[name] = true
+			family = name
+			if typ != "counter" && typ != "gauge" && typ != "info" {
+				t.Error("unexpected type of ", name, ": ", typ)
+			}
+			continue
+		}
+		if strings.HasPrefix(line, "# HELP "+family+" ") {
+			continue
+		}
+		name, _, _ := strings.Cut(line, " ")
+		name, _, _ = strings.Cut(name, "{")
+		if family == "" || (name != family && name != family+"_total" && name != family+"_info") {
+			t.Error("sample ", line, " is not in family ", family)
+		}
+	}
+}
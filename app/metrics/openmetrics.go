@@ -0,0 +1,227 @@
+package metrics
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"runtime"
+	"sort"
+	"strconv"
+	"strings"
+
+	app_dns "github.com/xtls/xray-core/app/dns"
+	"github.com/xtls/xray-core/app/observatory"
+	"github.com/xtls/xray-core/app/stats"
+	"github.com/xtls/xray-core/core"
+	"github.com/xtls/xray-core/features/dns"
+	feature_stats "github.com/xtls/xray-core/features/stats"
+)
+
+const openMetricsContentType = "application/openmetrics-text; version=1.0.0; charset=utf-8"
+
+type metricSample struct {
+	labels []string // Pairs of label name and value
+	value  float64
+}
+
+type metricFamily struct {
+	name    string
+	typ     string
+	help    string
+	samples []metricSample
+}
+
+func newMetricFamily(name string, typ string, help string) *metricFamily {
+	return &metricFamily{
+		name: name,
+		typ:  typ,
+		help: help,
+	}
+}
+
+func (f *metricFamily) add(value float64, labels ...string) {
+	f.samples = append(f.samples, metricSample{
+		labels: labels,
+		value:  value,
+	})
+}
+
+func (f *metricFamily) writeTo(b *strings.Builder) {
+	if len(f.samples) == 0 {
+		return
+	}
+	b.WriteString("# TYPE " + f.name + " " + f.typ + "\n")
+	b.WriteString("# HELP " + f.name + " " + f.help + "\n")
+
+	sampleName := f.name
+	switch f.typ {
+	case "counter":
+		sampleName += "_total"
+	case "info":
+		sampleName += "_info"
+	}
+	sort.SliceStable(f.samples, func(i, j int) bool {
+		return strings.Join(f.samples[i].labels, "\x00") < strings.Join(f.samples[j].labels, "\x00")
+	})
+	for _, s := range f.samples {
+		b.WriteString(sampleName)
+		if len(s.labels) > 0 {
+			b.WriteByte('{')
+			for i := 0; i+1 < len(s.labels); i += 2 {
+				if i > 0 {
+					b.WriteByte(',')
+				}
+				b.WriteString(s.labels[i] + `="` + escapeLabelValue(s.labels[i+1]) + `"`)
+			}
+			b.WriteByte('}')
+		}
+		b.WriteString(" " + strconv.FormatFloat(s.value, 'g', -1, 64) + "\n")
+	}
+}
+
+var labelValueEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+func escapeLabelValue(v string) string {
+	return labelValueEscaper.Replace(v)
+}
+
+// serveOpenMetrics serves all metrics in the OpenMetrics text format.
+func (p *MetricsHandler) serveOpenMetrics(w http.ResponseWriter, r *http.Request) {
+	var b strings.Builder
+	for _, f := range p.collectMetrics() {
+		f.writeTo(&b)
+	}
+	b.WriteString("# EOF\n")
+
+	w.Header().Set("Content-Type", openMetricsContentType)
+	io.WriteString(w, b.String())
+}
+
+func (p *MetricsHandler) collectMetrics() []*metricFamily {
+	buildInfo := newMetricFamily("xray_build", "info", "Xray build information.")
+	buildInfo.add(1, "version", core.Version())
+
+	families := []*metricFamily{buildInfo}
+	families = append(families, p.collectStats()...)
+	families = append(families, p.collectObservatory()...)
+	families = append(families, p.collectDNS()...)
+	families = append(families, collectRuntime()...)
+	return families
+}
+
+func (p *MetricsHandler) collectStats() []*metricFamily {
+	manager, ok := p.statsManager.(*stats.Manager)
+	if !ok {
+		return nil
+	}
+
+	inboundTraffic := newMetricFamily("xray_inbound_traffic_bytes", "counter", "Traffic of inbounds in bytes.")
+	outboundTraffic := newMetricFamily("xray_outbound_traffic_bytes", "counter", "Traffic of outbounds in bytes.")
+	userTraffic := newMetricFamily("xray_user_traffic_bytes", "counter", "Traffic of users in bytes.")
+	counters := newMetricFamily("xray_counter", "gauge", "Other stats counters.")
+	manager.VisitCounters(func(name string, counter feature_stats.Counter) bool {
+		value := float64(counter.Value())
+		nameSplit := strings.Split(name, ">>>")
+		if len(nameSplit) != 4 || nameSplit[2] != "traffic" {
+			counters.add(value, "name", name)
+			return true
+		}
+		switch nameSplit[0] {
+		case "inbound":
+			inboundTraffic.add(value, "tag", nameSplit[1], "direction", nameSplit[3])
+		case "outbound":
+			outboundTraffic.add(value, "tag", nameSplit[1], "direction", nameSplit[3])
+		case "user":
+			userTraffic.add(value, "user", nameSplit[1], "direction", nameSplit[3])
+		default:
+			counters.add(value, "name", name)
+		}
+		return true
+	})
+
+	onlineIPs := newMetricFamily("xray_user_online_ips", "gauge", "Number of IPs of users seen recently.")
+	manager.VisitOnlineMaps(func(name string, om feature_stats.OnlineMap) bool {
+		nameSplit := strings.Split(name, ">>>")
+		if len(nameSplit) == 3 && nameSplit[0] == "user" && nameSplit[2] == "online" {
+			onlineIPs.add(float64(om.Count()), "user", nameSplit[1])
+		}
+		return true
+	})
+
+	return []*metricFamily{inboundTraffic, outboundTraffic, userTraffic, counters, onlineIPs}
+}
+
+func (p *MetricsHandler) collectObservatory() []*metricFamily {
+	if p.observatory == nil {
+		return nil
+	}
+	o, err := p.observatory.GetObservation(context.Background())
+	if err != nil {
+		return nil
+	}
+	result, ok := o.(*observatory.ObservationResult)
+	if !ok {
+		return nil
+	}
+
+	alive := newMetricFamily("xray_outbound_alive", "gauge", "Whether the outbound passed the last health check.")
+	delay := newMetricFamily("xray_outbound_delay_seconds", "gauge", "Round trip time of the outbound in the last health check.")
+	lastSeen := newMetricFamily("xray_outbound_last_seen_timestamp_seconds", "gauge", "Time the outbound was last seen alive.")
+	for _, s := range result.GetStatus() {
+		if s.Alive {
+			alive.add(1, "tag", s.OutboundTag)
+			delay.add(float64(s.Delay)/1000, "tag", s.OutboundTag)
+		} else {
+			alive.add(0, "tag", s.OutboundTag)
+		}
+		if s.LastSeenTime > 0 {
+			lastSeen.add(float64(s.LastSeenTime), "tag", s.OutboundTag)
+		}
+	}
+	return []*metricFamily{alive, delay, lastSeen}
+}
+
+func (p *MetricsHandler) collectDNS() []*metricFamily {
+	client, ok := p.instance.GetFeature(dns.ClientType()).(interface {
+		CacheStats() map[string]app_dns.CacheStats
+	})
+	if !ok {
+		return nil
+	}
+
+	hits := newMetricFamily("xray_dns_cache_hits", "counter", "DNS queries answered from the cache of the name server.")
+	misses := newMetricFamily("xray_dns_cache_misses", "counter", "DNS queries missing the cache of the name server.")
+	ratio := newMetricFamily("xray_dns_cache_hit_ratio", "gauge", "Ratio of DNS queries answered from the cache of the name server.")
+	for server, s := range client.CacheStats() {
+		hits.add(float64(s.Hits), "server", server)
+		misses.add(float64(s.Misses), "server", server)
+		if total := s.Hits + s.Misses; total > 0 {
+			ratio.add(float64(s.Hits)/float64(total), "server", server)
+		}
+	}
+	return []*metricFamily{hits, misses, ratio}
+}
+
+func collectRuntime() []*metricFamily {
+	var ms runtime.MemStats
+	runtime.ReadMemStats(&ms)
+
+	info := newMetricFamily("go", "info", "Go runtime information.")
+	info.add(1, "version", runtime.Version())
+	goroutines := newMetricFamily("go_goroutines", "gauge", "Number of goroutines.")
+	goroutines.add(float64(runtime.NumGoroutine()))
+	alloc := newMetricFamily("go_memstats_alloc_bytes", "gauge", "Bytes of allocated heap objects.")
+	alloc.add(float64(ms.Alloc))
+	heapInuse := newMetricFamily("go_memstats_heap_inuse_bytes", "gauge", "Bytes in in-use heap spans.")
+	heapInuse.add(float64(ms.HeapInuse))
+	heapObjects := newMetricFamily("go_memstats_heap_objects", "gauge", "Number of allocated heap objects.")
+	heapObjects.add(float64(ms.HeapObjects))
+	sys := newMetricFamily("go_memstats_sys_bytes", "gauge", "Bytes of memory obtained from the OS.")
+	sys.add(float64(ms.Sys))
+	gcCycles := newMetricFamily("go_gc_cycles", "counter", "Number of completed GC cycles.")
+	gcCycles.add(float64(ms.NumGC))
+	gcPause := newMetricFamily("go_gc_pause_seconds", "counter", "Total time spent in GC stop-the-world pauses.")
+	gcPause.add(float64(ms.PauseTotalNs) / 1e9)
+
+	return []*metricFamily{info, goroutines, alloc, heapInuse, heapObjects, sys, gcCycles, gcPause}
+}
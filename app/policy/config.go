@@ -58,6 +58,39 @@ func (p *Policy) overrideWith(another *Policy) {
 			Connection: another.Buffer.Connection,
 		}
 	}
+	if another.Quota != nil {
+		p.Quota = &Policy_Quota{
+			CloseConnections: another.Quota.CloseConnections,
+		}
+	}
+	if another.ConnectionLimit != nil {
+		p.ConnectionLimit = &Policy_ConnectionLimit{
+			MaxIps:         another.ConnectionLimit.MaxIps,
+			MaxConnections: another.ConnectionLimit.MaxConnections,
+			EvictOldest:    another.ConnectionLimit.EvictOldest,
+		}
+	}
+	if another.RateLimit != nil {
+		p.RateLimit = &Policy_RateLimit{
+			Uplink:        another.RateLimit.Uplink,
+			Downlink:      another.RateLimit.Downlink,
+			UplinkBurst:   another.RateLimit.UplinkBurst,
+			DownlinkBurst: another.RateLimit.DownlinkBurst,
+		}
+	}
+}
+
+// ToCorePolicy converts this Policy_RateLimit to policy.RateLimit.
+func (l *Policy_RateLimit) ToCorePolicy() policy.RateLimit {
+	if l == nil {
+		return policy.RateLimit{}
+	}
+	return policy.RateLimit{
+		Uplink:        l.Uplink,
+		Downlink:      l.Downlink,
+		UplinkBurst:   l.UplinkBurst,
+		DownlinkBurst: l.DownlinkBurst,
+	}
 }
 
 // ToCorePolicy converts this Policy to policy.Session.
@@ -78,17 +111,36 @@ func (p *Policy) ToCorePolicy() policy.Session {
 	if p.Buffer != nil {
 		cp.Buffer.PerConnection = p.Buffer.Connection
 	}
+	cp.RateLimit = p.RateLimit.ToCorePolicy()
+	if p.Quota != nil {
+		cp.Quota.CloseConnections = p.Quota.CloseConnections
+	}
+	if p.ConnectionLimit != nil {
+		cp.ConnectionLimit = policy.ConnectionLimit{
+			MaxIPs:         p.ConnectionLimit.MaxIps,
+			MaxConnections: p.ConnectionLimit.MaxConnections,
+			EvictOldest:    p.ConnectionLimit.EvictOldest,
+		}
+	}
 	return cp
 }
 
 // ToCorePolicy converts this SystemPolicy to policy.System.
 func (p *SystemPolicy) ToCorePolicy() policy.System {
-	return policy.System{
-		Stats: policy.SystemStats{
+	sp := policy.System{}
+	if p.Stats != nil {
+		sp.Stats = policy.SystemStats{
 			InboundUplink:    p.Stats.InboundUplink,
 			InboundDownlink:  p.Stats.InboundDownlink,
 			OutboundUplink:   p.Stats.OutboundUplink,
 			OutboundDownlink: p.Stats.OutboundDownlink,
-		},
+		}
+	}
+	if len(p.InboundRateLimit) > 0 {
+		sp.InboundRateLimit = make(map[string]policy.RateLimit, len(p.InboundRateLimit))
+		for tag, l := range p.InboundRateLimit {
+			sp.InboundRateLimit[tag] = l.ToCorePolicy()
+		}
 	}
+	return sp
 }
@@ -0,0 +1,116 @@
+package policy
+
+import (
+	"io"
+
+	"github.com/xtls/xray-core/common/errors"
+	"github.com/xtls/xray-core/common/log"
+	"github.com/xtls/xray-core/features/policy"
+)
+
+type userConnection struct {
+	source string
+	conn   io.Closer
+}
+
+// AcquireConnection implements policy.ConnectionLimiter.
+func (m *Instance) AcquireConnection(level uint32, email string, source string, conn io.Closer) (func(), error) {
+	limit := m.ForLevel(level).ConnectionLimit
+	if len(email) == 0 || (limit.MaxIPs == 0 && limit.MaxConnections == 0) {
+		return func() {}, nil
+	}
+
+	m.connectionsAccess.Lock()
+	conns := m.connections[email]
+	var evicted []*userConnection
+	for {
+		reason := exceedsLimit(conns, source, limit)
+		if reason == "" {
+			break
+		}
+		if !limit.EvictOldest {
+			m.connectionsAccess.Unlock()
+			err := errors.New("user ", email, " has ", reason)
+			log.Record(&log.AccessMessage{
+				From:   source,
+				To:     "",
+				Status: log.AccessRejected,
+				Reason: err,
+				Email:  email,
+			})
+			return nil, err
+		}
+		// Evict the oldest connection, or with too many IPs, all connections from the IP of the oldest one.
+		oldest := conns[0]
+		remaining := make([]*userConnection, 0, len(conns))
+		for i, c := range conns {
+			if i == 0 || (reason == reasonTooManyIPs && c.source == oldest.source) {
+				evicted = append(evicted, c)
+				continue
+			}
+			remaining = append(remaining, c)
+		}
+		conns = remaining
+	}
+	c := &userConnection{
+		source: source,
+		conn:   conn,
+	}
+	m.connections[email] = append(conns, c)
+	m.connectionsAccess.Unlock()
+
+	for _, e := range evicted {
+		log.Record(&log.AccessMessage{
+			From:   e.source,
+			To:     "",
+			Status: log.AccessRejected,
+			Reason: errors.New("user ", email, " evicted by a newer connection from ", source),
+			Email:  email,
+		})
+		e.conn.Close()
+	}
+
+	return func() {
+		m.releaseConnection(email, c)
+	}, nil
+}
+
+func (m *Instance) releaseConnection(email string, c *userConnection) {
+	m.connectionsAccess.Lock()
+	defer m.connectionsAccess.Unlock()
+
+	conns := m.connections[email]
+	for i, cc := range conns {
+		if cc == c {
+			conns = append(conns[:i:i], conns[i+1:]...)
+			break
+		}
+	}
+	if len(conns) == 0 {
+		delete(m.connections, email)
+	} else {
+		m.connections[email] = conns
+	}
+}
+
+const (
+	reasonTooManyConnections = "too many connections"
+	reasonTooManyIPs         = "too many IPs"
+)
+
+// exceedsLimit returns the reason why a new connection from source would exceed the limit, or empty if it would not.
+func exceedsLimit(conns []*userConnection, source string, limit policy.ConnectionLimit) string {
+	if limit.MaxConnections > 0 && len(conns) >= int(limit.MaxConnections) {
+		return reasonTooManyConnections
+	}
+	if limit.MaxIPs > 0 {
+		ips := make(map[string]bool, len(conns))
+		for _, c := range conns {
+			ips[c.source] = true
+		}
+		if !ips[source] && len(ips) >= int(limit.MaxIPs) {
+			return reasonTooManyIPs
+		}
+	}
+	return ""
+}
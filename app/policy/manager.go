@@ -2,22 +2,46 @@ package policy
 
 import (
 	"context"
+	"math"
+	"sync"
 
 	"github.com/xtls/xray-core/common"
 	"github.com/xtls/xray-core/features/policy"
+	"golang.org/x/time/rate"
 )
 
 // Instance is an instance of Policy manager.
 type Instance struct {
+	access sync.RWMutex
 	levels map[uint32]*Policy
 	system *SystemPolicy
+
+	limitersAccess  sync.Mutex
+	userLimiters    map[string]*userLimiter
+	inboundLimiters map[string]*limiterPair
+
+	connectionsAccess sync.Mutex
+	connections       map[string][]*userConnection
+}
+
+type limiterPair struct {
+	uplink   *rate.Limiter
+	downlink *rate.Limiter
+}
+
+type userLimiter struct {
+	limiterPair
+	level uint32
 }
 
 // New creates new Policy manager instance.
 func New(ctx context.Context, config *Config) (*Instance, error) {
 	m := &Instance{
-		levels: make(map[uint32]*Policy),
-		system: config.System,
+		levels:          make(map[uint32]*Policy),
+		system:          config.System,
+		userLimiters:    make(map[string]*userLimiter),
+		inboundLimiters: make(map[string]*limiterPair),
+		connections:     make(map[string][]*userConnection),
 	}
 	if len(config.Level) > 0 {
 		for lv, p := range config.Level {
@@ -37,6 +61,9 @@ func (*Instance) Type() interface{} {
 
 // ForLevel implements policy.Manager.
 func (m *Instance) ForLevel(level uint32) policy.Session {
+	m.access.RLock()
+	defer m.access.RUnlock()
+
 	if p, ok := m.levels[level]; ok {
 		return p.ToCorePolicy()
 	}
@@ -45,12 +72,142 @@ func (m *Instance) ForLevel(level uint32) policy.Session {
 
 // ForSystem implements policy.Manager.
 func (m *Instance) ForSystem() policy.System {
+	m.access.RLock()
+	defer m.access.RUnlock()
+
 	if m.system == nil {
 		return policy.System{}
 	}
 	return m.system.ToCorePolicy()
 }
 
+// ForUser implements policy.Limiter.
+func (m *Instance) ForUser(level uint32, email string) (*rate.Limiter, *rate.Limiter) {
+	m.limitersAccess.Lock()
+	defer m.limitersAccess.Unlock()
+
+	if l, found := m.userLimiters[email]; found && l.level == level {
+		return l.uplink, l.downlink
+	}
+	l := &userLimiter{level: level}
+	l.update(m.ForLevel(level).RateLimit)
+	if l.unlimited() {
+		return nil, nil
+	}
+	m.userLimiters[email] = l
+	return l.uplink, l.downlink
+}
+
+// ForInbound implements policy.Limiter.
+func (m *Instance) ForInbound(tag string) (*rate.Limiter, *rate.Limiter) {
+	m.limitersAccess.Lock()
+	defer m.limitersAccess.Unlock()
+
+	if l, found := m.inboundLimiters[tag]; found {
+		return l.uplink, l.downlink
+	}
+	l := &limiterPair{}
+	l.update(m.ForSystem().InboundRateLimit[tag])
+	if l.unlimited() {
+		return nil, nil
+	}
+	m.inboundLimiters[tag] = l
+	return l.uplink, l.downlink
+}
+
+// SetLevelRateLimit changes the rate limit of the given user level at runtime.
+// Connections already limited by the level follow the new limit immediately.
+func (m *Instance) SetLevelRateLimit(level uint32, limit *Policy_RateLimit) {
+	m.access.Lock()
+	p, found := m.levels[level]
+	if !found {
+		p = defaultPolicy()
+		m.levels[level] = p
+	}
+	p.RateLimit = limit
+	m.access.Unlock()
+
+	m.limitersAccess.Lock()
+	defer m.limitersAccess.Unlock()
+	for email, l := range m.userLimiters {
+		if l.level != level {
+			continue
+		}
+		l.update(limit.ToCorePolicy())
+		if l.unlimited() {
+			delete(m.userLimiters, email)
+		}
+	}
+}
+
+// RemoveUser drops the limiters of the given user, e.g. when the user is removed at runtime. Connections still
+// holding them keep their limits, while new connections of the user get fresh limiters.
+func (m *Instance) RemoveUser(email string) {
+	m.limitersAccess.Lock()
+	defer m.limitersAccess.Unlock()
+	delete(m.userLimiters, email)
+}
+
+// SetInboundRateLimit changes the rate limit of the given inbound at runtime.
+// Connections already limited by the inbound follow the new limit immediately.
+func (m *Instance) SetInboundRateLimit(tag string, limit *Policy_RateLimit) {
+	m.access.Lock()
+	if m.system == nil {
+		m.system = &SystemPolicy{}
+	}
+	if m.system.InboundRateLimit == nil {
+		m.system.InboundRateLimit = make(map[string]*Policy_RateLimit)
+	}
+	if limit == nil {
+		delete(m.system.InboundRateLimit, tag)
+	} else {
+		m.system.InboundRateLimit[tag] = limit
+	}
+	m.access.Unlock()
+
+	m.limitersAccess.Lock()
+	defer m.limitersAccess.Unlock()
+	if l, found := m.inboundLimiters[tag]; found {
+		l.update(limit.ToCorePolicy())
+		if l.unlimited() {
+			delete(m.inboundLimiters, tag)
+		}
+	}
+}
+
+// update applies the limit to the pair. Limiters in use are changed in place, so that all connections sharing them
+// see the new limit. A direction that becomes unlimited gets an infinite rate, as connections may still hold it.
+func (l *limiterPair) update(limit policy.RateLimit) {
+	l.uplink = updateLimiter(l.uplink, limit.Uplink, limit.UplinkBurst)
+	l.downlink = updateLimiter(l.downlink, limit.Downlink, limit.DownlinkBurst)
+}
+
+func (l *limiterPair) unlimited() bool {
+	return (l.uplink == nil || l.uplink.Limit() == rate.Inf) && (l.downlink == nil || l.downlink.Limit() == rate.Inf)
+}
+
+func updateLimiter(l *rate.Limiter, bytesPerSecond uint64, burst uint64) *rate.Limiter {
+	limit := rate.Limit(bytesPerSecond)
+	if bytesPerSecond == 0 {
+		if l == nil {
+			return nil
+		}
+		limit = rate.Inf
+	}
+	if burst == 0 {
+		burst = bytesPerSecond
+	}
+	if burst > math.MaxInt32 {
+		burst = math.MaxInt32
+	}
+	if l == nil {
+		return rate.NewLimiter(limit, int(burst))
+	}
+	l.SetLimit(limit)
+	l.SetBurst(int(burst))
+	return l
+}
+
 // Start implements common.Runnable.Start().
 func (m *Instance) Start() error {
 	return nil
@@ -43,3 +43,101 @@ func TestPolicy(t *testing.T) {
 		}
 	}
 }
+
+func TestRateLimit(t *testing.T) {
+	manager, err := New(context.Background(), &Config{
+		Level: map[uint32]*Policy{
+			0: {
+				RateLimit: &Policy_RateLimit{
+					Uplink: 1024,
+				},
+			},
+		},
+		System: &SystemPolicy{
+			InboundRateLimit: map[string]*Policy_RateLimit{
+				"in": {Downlink: 2048, DownlinkBurst: 4096},
+			},
+		},
+	})
+	common.Must(err)
+
+	up, down := manager.ForUser(0, "a@example.com")
+	if up == nil || up.Limit() != 1024 || up.Burst() != 1024 {
+		t.Error("unexpected uplink limiter ", up)
+	}
+	if down != nil {
+		t.Error("expect unlimited downlink, but got ", down)
+	}
+	if up2, _ := manager.ForUser(0, "a@example.com"); up2 != up {
+		t.Error("expect limiter to be shared by connections of the same user")
+	}
+	if up, down := manager.ForUser(1, "b@example.com"); up != nil || down != nil {
+		t.Error("expect level 1 to be unlimited")
+	}
+
+	if _, down := manager.ForInbound("in"); down == nil || down.Limit() != 2048 || down.Burst() != 4096 {
+		t.Error("unexpected inbound downlink limiter ", down)
+	}
+
+	manager.SetLevelRateLimit(0, &Policy_RateLimit{Uplink: 4096})
+	if up.Limit() != 4096 {
+		t.Error("expect limiter in use to be updated, but got ", up.Limit())
+	}
+	if p := manager.ForLevel(0); p.RateLimit.Uplink != 4096 {
+		t.Error("expect level policy to be updated, but got ", p.RateLimit.Uplink)
+	}
+
+	manager.RemoveUser("a@example.com")
+	if up2, _ := manager.ForUser(0, "a@example.com"); up2 == up || up2.Limit() != 4096 {
+		t.Error("expect limiters of the removed user to be dropped")
+	}
+}
+
+type testConn struct {
+	closed bool
+}
+
+func (c *testConn) Close() error {
+	c.closed = true
+	return nil
+}
+
+func TestConnectionLimit(t *testing.T) {
+	manager, err := New(context.Background(), &Config{
+		Level: map[uint32]*Policy{
+			0: {
+				ConnectionLimit: &Policy_ConnectionLimit{
+					MaxIps: 1,
+				},
+			},
+			1: {
+				ConnectionLimit: &Policy_ConnectionLimit{
+					MaxConnections: 2,
+					EvictOldest:    true,
+				},
+			},
+		},
+	})
+	common.Must(err)
+
+	release, err := manager.AcquireConnection(0, "a@example.com", "1.1.1.1", &testConn{})
+	common.Must(err)
+	if _, err := manager.AcquireConnection(0, "a@example.com", "1.1.1.1", &testConn{}); err != nil {
+		t.Error("expect connection from the same IP to be accepted, but got ", err)
+	}
+	if _, err := manager.AcquireConnection(0, "a@example.com", "2.2.2.2", &testConn{}); err == nil {
+		t.Error("expect connection from another IP to be rejected")
+	}
+	if _, err := manager.AcquireConnection(0, "b@example.com", "2.2.2.2", &testConn{}); err != nil {
+		t.Error("expect connection of another user to be accepted, but got ", err)
+	}
+	release()
+
+	c1, c2, c3 := &testConn{}, &testConn{}, &testConn{}
+	common.Must2(manager.AcquireConnection(1, "c@example.com", "1.1.1.1", c1))
+	common.Must2(manager.AcquireConnection(1, "c@example.com", "1.1.1.1", c2))
+	common.Must2(manager.AcquireConnection(1, "c@example.com", "2.2.2.2", c3))
+	if !c1.closed || c2.closed || c3.closed {
+		t.Error("expect only the oldest connection to be evicted")
+	}
+}
@@ -2,14 +2,18 @@ package command
 
 import (
 	"context"
+	"time"
 
 	"github.com/xtls/xray-core/app/commander"
+	app_policy "github.com/xtls/xray-core/app/policy"
 	"github.com/xtls/xray-core/common"
 	"github.com/xtls/xray-core/common/errors"
 	"github.com/xtls/xray-core/common/protocol"
 	"github.com/xtls/xray-core/core"
 	"github.com/xtls/xray-core/features/inbound"
 	"github.com/xtls/xray-core/features/outbound"
+	"github.com/xtls/xray-core/features/policy"
+	"github.com/xtls/xray-core/features/stats"
 	"github.com/xtls/xray-core/proxy"
 	grpc "google.golang.org/grpc"
 )
@@ -97,7 +101,15 @@ func (s *handlerServer) AlterInbound(ctx context.Context, request *AlterInboundR
 		return nil, errors.New("failed to get handler: ", request.Tag).Base(err)
 	}
 
-	return &AlterInboundResponse{}, operation.ApplyInbound(ctx, handler)
+	if err := operation.ApplyInbound(ctx, handler); err != nil {
+		return nil, err
+	}
+	if op, ok := operation.(*RemoveUserOperation); ok {
+		if pm, ok := s.s.GetFeature(policy.ManagerType()).(*app_policy.Instance); ok {
+			pm.RemoveUser(op.Email)
+		}
+	}
+	return &AlterInboundResponse{}, nil
 }
 
 func (s *handlerServer) ListInbounds(ctx context.Context, request *ListInboundsRequest) (*ListInboundsResponse, error) {
@@ -204,6 +216,85 @@ func (s *handlerServer) ListOutbounds(ctx context.Context, request *ListOutbound
 	return response, nil
 }
 
+func (s *handlerServer) SetRateLimit(ctx context.Context, request *SetRateLimitRequest) (*SetRateLimitResponse, error) {
+	pm, ok := s.s.GetFeature(policy.ManagerType()).(*app_policy.Instance)
+	if !ok {
+		return nil, errors.New("policy manager does not support rate limits")
+	}
+	if len(request.InboundTag) > 0 {
+		pm.SetInboundRateLimit(request.InboundTag, request.RateLimit)
+	} else {
+		pm.SetLevelRateLimit(request.Level, request.RateLimit)
+	}
+	return &SetRateLimitResponse{}, nil
+}
+
+func (s *handlerServer) getUser(ctx context.Context, tag string, email string) (*protocol.MemoryUser, error) {
+	handler, err := s.ihm.GetHandler(ctx, tag)
+	if err != nil {
+		return nil, errors.New("failed to get handler: ", tag).Base(err)
+	}
+	p, err := getInbound(handler)
+	if err != nil {
+		return nil, err
+	}
+	um, ok := p.(proxy.UserManager)
+	if !ok {
+		return nil, errors.New("proxy is not a UserManager")
+	}
+	user := um.GetUser(ctx, email)
+	if user == nil {
+		return nil, errors.New("user ", email, " not found")
+	}
+	return user, nil
+}
+
+func (s *handlerServer) userCounters(email string) []stats.Counter {
+	sm, ok := s.s.GetFeature(stats.ManagerType()).(stats.Manager)
+	if !ok {
+		return nil
+	}
+	var counters []stats.Counter
+	for _, name := range []string{"user>>>" + email + ">>>traffic>>>uplink", "user>>>" + email + ">>>traffic>>>downlink"} {
+		if c := sm.GetCounter(name); c != nil {
+			counters = append(counters, c)
+		}
+	}
+	return counters
+}
+
+func (s *handlerServer) GetUserQuota(ctx context.Context, request *GetUserQuotaRequest) (*GetUserQuotaResponse, error) {
+	user, err := s.getUser(ctx, request.Tag, request.Email)
+	if err != nil {
+		return nil, err
+	}
+	response := &GetUserQuotaResponse{
+		Quota:   user.Quota,
+		Expire:  user.Expire,
+		Expired: user.Expired(time.Now()),
+	}
+	for _, c := range s.userCounters(user.Email) {
+		if v := c.Value(); v > 0 {
+			response.Used += uint64(v)
+		}
+	}
+	if user.Quota > response.Used {
+		response.Remaining = user.Quota - response.Used
+	}
+	return response, nil
+}
+
+func (s *handlerServer) ResetUserQuota(ctx context.Context, request *ResetUserQuotaRequest) (*ResetUserQuotaResponse, error) {
+	user, err := s.getUser(ctx, request.Tag, request.Email)
+	if err != nil {
+		return nil, err
+	}
+	for _, c := range s.userCounters(user.Email) {
+		c.Set(0)
+	}
+	return &ResetUserQuotaResponse{}, nil
+}
+
 func (s *handlerServer) mustEmbedUnimplementedHandlerServiceServer() {}
 
 type service struct {
@@ -29,6 +29,9 @@ const (
 	HandlerService_RemoveOutbound_FullMethodName       = "/xray.app.proxyman.command.HandlerService/RemoveOutbound"
 	HandlerService_AlterOutbound_FullMethodName        = "/xray.app.proxyman.command.HandlerService/AlterOutbound"
 	HandlerService_ListOutbounds_FullMethodName        = "/xray.app.proxyman.command.HandlerService/ListOutbounds"
+	HandlerService_SetRateLimit_FullMethodName         = "/xray.app.proxyman.command.HandlerService/SetRateLimit"
+	HandlerService_GetUserQuota_FullMethodName         = "/xray.app.proxyman.command.HandlerService/GetUserQuota"
+	HandlerService_ResetUserQuota_FullMethodName       = "/xray.app.proxyman.command.HandlerService/ResetUserQuota"
 )
 
 // HandlerServiceClient is the client API for HandlerService service.
@@ -45,6 +48,9 @@ type HandlerServiceClient interface {
 	RemoveOutbound(ctx context.Context, in *RemoveOutboundRequest, opts ...grpc.CallOption) (*RemoveOutboundResponse, error)
 	AlterOutbound(ctx context.Context, in *AlterOutboundRequest, opts ...grpc.CallOption) (*AlterOutboundResponse, error)
 	ListOutbounds(ctx context.Context, in *ListOutboundsRequest, opts ...grpc.CallOption) (*ListOutboundsResponse, error)
+	SetRateLimit(ctx context.Context, in *SetRateLimitRequest, opts ...grpc.CallOption) (*SetRateLimitResponse, error)
+	GetUserQuota(ctx context.Context, in *GetUserQuotaRequest, opts ...grpc.CallOption) (*GetUserQuotaResponse, error)
+	ResetUserQuota(ctx context.Context, in *ResetUserQuotaRequest, opts ...grpc.CallOption) (*ResetUserQuotaResponse, error)
 }
 
 type handlerServiceClient struct {
@@ -155,6 +161,36 @@ func (c *handlerServiceClient) ListOutbounds(ctx context.Context, in *ListOutbou
 	return out, nil
 }
 
+func (c *handlerServiceClient) SetRateLimit(ctx context.Context, in *SetRateLimitRequest, opts ...grpc.CallOption) (*SetRateLimitResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetRateLimitResponse)
+	err := c.cc.Invoke(ctx, HandlerService_SetRateLimit_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *handlerServiceClient) GetUserQuota(ctx context.Context, in *GetUserQuotaRequest, opts ...grpc.CallOption) (*GetUserQuotaResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetUserQuotaResponse)
+	err := c.cc.Invoke(ctx, HandlerService_GetUserQuota_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *handlerServiceClient) ResetUserQuota(ctx context.Context, in *ResetUserQuotaRequest, opts ...grpc.CallOption) (*ResetUserQuotaResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ResetUserQuotaResponse)
+	err := c.cc.Invoke(ctx, HandlerService_ResetUserQuota_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // HandlerServiceServer is the server API for HandlerService service.
 // All implementations must embed UnimplementedHandlerServiceServer
 // for forward compatibility.
@@ -169,6 +205,9 @@ type HandlerServiceServer interface {
 	RemoveOutbound(context.Context, *RemoveOutboundRequest) (*RemoveOutboundResponse, error)
 	AlterOutbound(context.Context, *AlterOutboundRequest) (*AlterOutboundResponse, error)
 	ListOutbounds(context.Context, *ListOutboundsRequest) (*ListOutboundsResponse, error)
+	SetRateLimit(context.Context, *SetRateLimitRequest) (*SetRateLimitResponse, error)
+	GetUserQuota(context.Context, *GetUserQuotaRequest) (*GetUserQuotaResponse, error)
+	ResetUserQuota(context.Context, *ResetUserQuotaRequest) (*ResetUserQuotaResponse, error)
 	mustEmbedUnimplementedHandlerServiceServer()
 }
 
@@ -209,6 +248,15 @@ func (UnimplementedHandlerServiceServer) AlterOutbound(context.Context, *AlterOu
 func (UnimplementedHandlerServiceServer) ListOutbounds(context.Context, *ListOutboundsRequest) (*ListOutboundsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListOutbounds not implemented")
 }
+func (UnimplementedHandlerServiceServer) SetRateLimit(context.Context, *SetRateLimitRequest) (*SetRateLimitResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetRateLimit not implemented")
+}
+func (UnimplementedHandlerServiceServer) GetUserQuota(context.Context, *GetUserQuotaRequest) (*GetUserQuotaResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetUserQuota not implemented")
+}
+func (UnimplementedHandlerServiceServer) ResetUserQuota(context.Context, *ResetUserQuotaRequest) (*ResetUserQuotaResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ResetUserQuota not implemented")
+}
 func (UnimplementedHandlerServiceServer) mustEmbedUnimplementedHandlerServiceServer() {}
 func (UnimplementedHandlerServiceServer) testEmbeddedByValue()                        {}
 
@@ -410,6 +458,60 @@ func _HandlerService_ListOutbounds_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _HandlerService_SetRateLimit_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetRateLimitRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HandlerServiceServer).SetRateLimit(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HandlerService_SetRateLimit_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HandlerServiceServer).SetRateLimit(ctx, req.(*SetRateLimitRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HandlerService_GetUserQuota_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetUserQuotaRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HandlerServiceServer).GetUserQuota(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HandlerService_GetUserQuota_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HandlerServiceServer).GetUserQuota(ctx, req.(*GetUserQuotaRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HandlerService_ResetUserQuota_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResetUserQuotaRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HandlerServiceServer).ResetUserQuota(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HandlerService_ResetUserQuota_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HandlerServiceServer).ResetUserQuota(ctx, req.(*ResetUserQuotaRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // HandlerService_ServiceDesc is the grpc.ServiceDesc for HandlerService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -457,6 +559,18 @@ var HandlerService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ListOutbounds",
 			Handler:    _HandlerService_ListOutbounds_Handler,
 		},
+		{
+			MethodName: "SetRateLimit",
+			Handler:    _HandlerService_SetRateLimit_Handler,
+		},
+		{
+			MethodName: "GetUserQuota",
+			Handler:    _HandlerService_GetUserQuota_Handler,
+		},
+		{
+			MethodName: "ResetUserQuota",
+			Handler:    _HandlerService_ResetUserQuota_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "app/proxyman/command/command.proto",
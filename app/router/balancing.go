@@ -3,6 +3,7 @@ package router
 import (
 	"context"
 	sync "sync"
+	"time"
 
 	"github.com/xtls/xray-core/app/observatory"
 	"github.com/xtls/xray-core/common"
@@ -10,12 +11,18 @@ import (
 	"github.com/xtls/xray-core/core"
 	"github.com/xtls/xray-core/features/extension"
 	"github.com/xtls/xray-core/features/outbound"
+	"github.com/xtls/xray-core/features/routing"
 )
 
 type BalancingStrategy interface {
 	PickOutbound([]string) string
 }
 
+// ContextBalancingStrategy is a BalancingStrategy which picks outbounds depending on the routing context.
+type ContextBalancingStrategy interface {
+	PickOutboundByContext(routing.Context, []string) string
+}
+
 type BalancingPrincipleTarget interface {
 	GetPrincipleTarget([]string) []string
 }
@@ -83,16 +90,24 @@ func (s *RoundRobinStrategy) PickOutbound(tags []string) string {
 }
 
 type Balancer struct {
+	tag         string
 	selectors   []string
 	strategy    BalancingStrategy
 	ohm         outbound.Manager
 	fallbackTag string
 
+	// Selectors of the failover groups after the primary one of selectors.
+	failoverSelectors [][]string
+	// Nil if neither failover groups nor hysteresis is configured.
+	health   *healthTracker
+	holdTime time.Duration
+	failover failoverState
+
 	override override
 }
 
-// PickOutbound picks the tag of a outbound
-func (b *Balancer) PickOutbound() (string, error) {
+// PickOutbound picks the tag of a outbound for the connection in ctx, which may be nil.
+func (b *Balancer) PickOutbound(ctx routing.Context) (string, error) {
 	candidates, err := b.SelectOutbounds()
 	if err != nil {
 		if b.fallbackTag != "" {
@@ -104,8 +119,16 @@ func (b *Balancer) PickOutbound() (string, error) {
 	var tag string
 	if o := b.override.Get(); o != "" {
 		tag = o
+	} else if b.health == nil {
+		tag = b.pick(ctx, candidates)
 	} else {
-		tag = b.strategy.PickOutbound(candidates)
+		groups := [][]string{candidates}
+		if hs, ok := b.ohm.(outbound.HandlerSelector); ok {
+			for _, selectors := range b.failoverSelectors {
+				groups = append(groups, hs.Select(selectors))
+			}
+		}
+		tag = b.pickFromGroups(ctx, groups)
 	}
 	if tag == "" {
 		if b.fallbackTag != "" {
@@ -118,10 +141,75 @@ func (b *Balancer) PickOutbound() (string, error) {
 	return tag, nil
 }
 
+func (b *Balancer) pick(ctx routing.Context, candidates []string) string {
+	if s, ok := b.strategy.(ContextBalancingStrategy); ok && ctx != nil {
+		return s.PickOutboundByContext(ctx, candidates)
+	}
+	return b.strategy.PickOutbound(candidates)
+}
+
+// pickFromGroups picks from the alive outbounds of the active failover group, and moves on to the next group if the
+// strategy picks none.
+func (b *Balancer) pickFromGroups(ctx routing.Context, groups [][]string) string {
+	now := time.Now()
+	aliveTags := make([][]string, len(groups))
+	alive := make([]bool, len(groups))
+	for i, group := range groups {
+		for _, tag := range group {
+			if b.health.isAlive(tag) {
+				aliveTags[i] = append(aliveTags[i], tag)
+			}
+		}
+		alive[i] = len(aliveTags[i]) > 0
+	}
+
+	group, switched := b.failover.selectGroup(alive, b.holdTime, now)
+	if switched {
+		errors.LogInfo(context.Background(), "balancer ", b.tag, " switches to failover group ", group)
+	}
+	for ; group < len(groups); group++ {
+		if !alive[group] {
+			continue
+		}
+		if tag := b.pick(ctx, aliveTags[group]); tag != "" {
+			if b.holdsPick() {
+				tag = b.failover.hold(tag, aliveTags[group], b.holdTime, now)
+			}
+			return tag
+		}
+	}
+	return ""
+}
+
+// holdsPick returns whether the outbound picked is held for holdTime. Only strategies re-selecting the best
+// outbound by their observation hold it, so that they do not flap between outbounds of similar results, while
+// random and round robin strategies keep spreading connections.
+func (b *Balancer) holdsPick() bool {
+	if b.holdTime <= 0 {
+		return false
+	}
+	switch b.strategy.(type) {
+	case *LeastPingStrategy, *LeastLoadStrategy:
+		return true
+	}
+	return false
+}
+
 func (b *Balancer) InjectContext(ctx context.Context) {
 	if contextReceiver, ok := b.strategy.(extension.ContextReceiver); ok {
 		contextReceiver.InjectContext(ctx)
 	}
+	if b.health != nil {
+		b.health.InjectContext(ctx)
+	}
+}
+
+// Close stops tracking the health of outbounds.
+func (b *Balancer) Close() error {
+	if b.health != nil {
+		return b.health.Close()
+	}
+	return nil
 }
 
 // SelectOutbounds select outbounds with selectors of the Balancer
@@ -134,9 +222,17 @@ func (b *Balancer) SelectOutbounds() ([]string, error) {
 	return tags, nil
 }
 
+// getBalancer returns the balancer with tag.
+func (r *Router) getBalancer(tag string) (*Balancer, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	b, ok := r.balancers[tag]
+	return b, ok
+}
+
 // GetPrincipleTarget implements routing.BalancerPrincipleTarget
 func (r *Router) GetPrincipleTarget(tag string) ([]string, error) {
-	if b, ok := r.balancers[tag]; ok {
+	if b, ok := r.getBalancer(tag); ok {
 		if s, ok := b.strategy.(BalancingPrincipleTarget); ok {
 			candidates, err := b.SelectOutbounds()
 			if err != nil {
@@ -151,7 +247,7 @@ func (r *Router) GetPrincipleTarget(tag string) ([]string, error) {
 
 // SetOverrideTarget implements routing.BalancerOverrider
 func (r *Router) SetOverrideTarget(tag, target string) error {
-	if b, ok := r.balancers[tag]; ok {
+	if b, ok := r.getBalancer(tag); ok {
 		b.override.Put(target)
 		return nil
 	}
@@ -160,7 +256,7 @@ func (r *Router) SetOverrideTarget(tag, target string) error {
 
 // GetOverrideTarget implements routing.BalancerOverrider
 func (r *Router) GetOverrideTarget(tag string) (string, error) {
-	if b, ok := r.balancers[tag]; ok {
+	if b, ok := r.getBalancer(tag); ok {
 		return b.override.Get(), nil
 	}
 	return "", errors.New("cannot find tag")
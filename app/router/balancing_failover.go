@@ -0,0 +1,180 @@
+package router
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/xtls/xray-core/app/observatory"
+	"github.com/xtls/xray-core/common"
+	"github.com/xtls/xray-core/common/errors"
+	"github.com/xtls/xray-core/common/task"
+	"github.com/xtls/xray-core/core"
+	"github.com/xtls/xray-core/features/extension"
+)
+
+const healthPollInterval = time.Second
+
+// outboundHealth is the health of an outbound with hysteresis.
+type outboundHealth struct {
+	alive bool
+	// The last observed health, and since when it is observed.
+	observedAlive bool
+	since         time.Time
+}
+
+// healthTracker follows the observatory, and considers an outbound dead only after it is observed dead for
+// deadAfter, and alive again only after it is observed alive for aliveAfter. It works with both the observatory and
+// the burst observatory, as it polls their results rather than counting probes.
+type healthTracker struct {
+	deadAfter  time.Duration
+	aliveAfter time.Duration
+
+	ctx         context.Context
+	observatory extension.Observatory
+	poll        *task.Periodic
+
+	access    sync.RWMutex
+	outbounds map[string]*outboundHealth
+}
+
+func newHealthTracker(deadAfter, aliveAfter time.Duration) *healthTracker {
+	t := &healthTracker{
+		deadAfter:  deadAfter,
+		aliveAfter: aliveAfter,
+		outbounds:  make(map[string]*outboundHealth),
+	}
+	t.poll = &task.Periodic{
+		Interval: healthPollInterval,
+		Execute:  t.pollObservation,
+	}
+	return t
+}
+
+func (t *healthTracker) InjectContext(ctx context.Context) {
+	t.ctx = ctx
+	common.Must(core.OptionalFeatures(ctx, func(observatory extension.Observatory) error {
+		t.observatory = observatory
+		return t.poll.Start()
+	}))
+}
+
+func (t *healthTracker) pollObservation() error {
+	result, err := t.observatory.GetObservation(t.ctx)
+	if err != nil {
+		errors.LogInfoInner(t.ctx, err, "cannot get observer report")
+		return nil
+	}
+	if result, ok := result.(*observatory.ObservationResult); ok {
+		t.update(result.Status, time.Now())
+	}
+	return nil
+}
+
+// update applies an observation at now.
+func (t *healthTracker) update(status []*observatory.OutboundStatus, now time.Time) {
+	t.access.Lock()
+	defer t.access.Unlock()
+
+	for _, s := range status {
+		h, found := t.outbounds[s.OutboundTag]
+		if !found {
+			t.outbounds[s.OutboundTag] = &outboundHealth{
+				alive:         s.Alive,
+				observedAlive: s.Alive,
+				since:         now,
+			}
+			continue
+		}
+		if s.Alive != h.observedAlive {
+			h.observedAlive = s.Alive
+			h.since = now
+		}
+		if h.alive != h.observedAlive {
+			threshold := t.aliveAfter
+			if h.alive {
+				threshold = t.deadAfter
+			}
+			if now.Sub(h.since) >= threshold {
+				h.alive = h.observedAlive
+			}
+		}
+	}
+}
+
+// isAlive returns whether the outbound is considered alive. Outbounds not observed are considered alive.
+func (t *healthTracker) isAlive(tag string) bool {
+	t.access.RLock()
+	defer t.access.RUnlock()
+
+	h, found := t.outbounds[tag]
+	return !found || h.alive
+}
+
+func (t *healthTracker) Close() error {
+	return t.poll.Close()
+}
+
+// failoverState is the state of switching between the failover groups and outbounds of a balancer.
+type failoverState struct {
+	access sync.Mutex
+	// The group in use, and since when each group has been alive. Zero time if the group is not alive.
+	activeGroup  int
+	groupAliveAt []time.Time
+	// The outbound last picked, and when it was picked in place of another.
+	picked   string
+	pickedAt time.Time
+}
+
+// selectGroup returns the group of the highest priority with an alive outbound. Switching back to a group of
+// higher priority than the active one happens only after the group has been alive for hold.
+func (s *failoverState) selectGroup(alive []bool, hold time.Duration, now time.Time) (group int, switched bool) {
+	s.access.Lock()
+	defer s.access.Unlock()
+
+	if len(s.groupAliveAt) != len(alive) {
+		s.groupAliveAt = make([]time.Time, len(alive))
+		s.activeGroup = 0
+	}
+	for i, a := range alive {
+		switch {
+		case !a:
+			s.groupAliveAt[i] = time.Time{}
+		case s.groupAliveAt[i].IsZero():
+			s.groupAliveAt[i] = now
+		}
+	}
+	for i, a := range alive {
+		if !a {
+			continue
+		}
+		if i < s.activeGroup && now.Sub(s.groupAliveAt[i]) < hold && alive[s.activeGroup] {
+			continue
+		}
+		switched = i != s.activeGroup
+		s.activeGroup = i
+		return i, switched
+	}
+	return len(alive), false
+}
+
+// hold returns the outbound picked last time in place of tag, if it was picked less than hold ago and is still
+// a candidate.
+func (s *failoverState) hold(tag string, candidates []string, hold time.Duration, now time.Time) string {
+	s.access.Lock()
+	defer s.access.Unlock()
+
+	if tag == s.picked {
+		return tag
+	}
+	if s.picked != "" && now.Sub(s.pickedAt) < hold {
+		for _, c := range candidates {
+			if c == s.picked {
+				return s.picked
+			}
+		}
+	}
+	s.picked = tag
+	s.pickedAt = now
+	return tag
+}
@@ -0,0 +1,137 @@
+package router
+
+import (
+	"testing"
+	"time"
+
+	"github.com/xtls/xray-core/app/observatory"
+)
+
+func TestHealthTrackerHysteresis(t *testing.T) {
+	tracker := newHealthTracker(30*time.Second, 2*time.Minute)
+	start := time.Now()
+	observe := func(elapsed time.Duration, alive bool) {
+		tracker.update([]*observatory.OutboundStatus{{OutboundTag: "a", Alive: alive}}, start.Add(elapsed))
+	}
+
+	if !tracker.isAlive("a") {
+		t.Error("expect unobserved outbound to be alive")
+	}
+	cases := []struct {
+		elapsed  time.Duration
+		observed bool
+		alive    bool
+	}{
+		{0, true, true},
+		{10 * time.Second, false, true},
+		{20 * time.Second, true, true}, // jitter is ignored
+		{30 * time.Second, false, true},
+		{59 * time.Second, false, true},
+		{60 * time.Second, false, false},
+		{90 * time.Second, true, false},
+		{200 * time.Second, true, false},
+		{210 * time.Second, true, true},
+	}
+	for _, c := range cases {
+		observe(c.elapsed, c.observed)
+		if alive := tracker.isAlive("a"); alive != c.alive {
+			t.Error("at ", c.elapsed, ", expect alive ", c.alive, ", but actually ", alive)
+		}
+	}
+}
+
+func TestFailoverSelectGroup(t *testing.T) {
+	var state failoverState
+	hold := time.Minute
+	start := time.Now()
+
+	cases := []struct {
+		elapsed time.Duration
+		alive   []bool
+		group   int
+	}{
+		{0, []bool{true, true, true}, 0},
+		{time.Second, []bool{false, true, true}, 1},
+		{2 * time.Second, []bool{true, true, true}, 1}, // primary is back, but not for long enough
+		{61 * time.Second, []bool{true, true, true}, 1},
+		{62 * time.Second, []bool{true, true, true}, 0},
+		{63 * time.Second, []bool{false, false, true}, 2},
+		{64 * time.Second, []bool{true, false, false}, 0}, // the active group is dead, so no holding
+		{65 * time.Second, []bool{false, false, false}, 3},
+	}
+	for _, c := range cases {
+		if group, _ := state.selectGroup(c.alive, hold, start.Add(c.elapsed)); group != c.group {
+			t.Error("at ", c.elapsed, ", expect group ", c.group, ", but actually ", group)
+		}
+	}
+}
+
+func TestFailoverHold(t *testing.T) {
+	var state failoverState
+	hold := time.Minute
+	start := time.Now()
+	candidates := []string{"a", "b"}
+
+	if tag := state.hold("a", candidates, hold, start); tag != "a" {
+		t.Error("expect a, but actually ", tag)
+	}
+	if tag := state.hold("b", candidates, hold, start.Add(30*time.Second)); tag != "a" {
+		t.Error("expect a to be held, but actually ", tag)
+	}
+	if tag := state.hold("b", []string{"b"}, hold, start.Add(40*time.Second)); tag != "b" {
+		t.Error("expect b when a is no longer a candidate, but actually ", tag)
+	}
+	if tag := state.hold("a", candidates, hold, start.Add(50*time.Second)); tag != "b" {
+		t.Error("expect b to be held, but actually ", tag)
+	}
+	if tag := state.hold("a", candidates, hold, start.Add(101*time.Second)); tag != "a" {
+		t.Error("expect a after holding, but actually ", tag)
+	}
+}
+
+func TestBalancerPickFromGroups(t *testing.T) {
+	b := &Balancer{
+		strategy: &RoundRobinStrategy{},
+		health:   newHealthTracker(0, 0),
+	}
+	b.health.update([]*observatory.OutboundStatus{
+		{OutboundTag: "a1", Alive: false},
+		{OutboundTag: "a2", Alive: false},
+		{OutboundTag: "b1", Alive: true},
+	}, time.Now())
+
+	groups := [][]string{{"a1", "a2"}, {"b1", "b2"}, {"c"}}
+	for i := 0; i < 4; i++ {
+		if tag := b.pickFromGroups(nil, groups); tag != "b1" && tag != "b2" {
+			t.Error("expect an outbound of the secondary group, but actually ", tag)
+		}
+	}
+
+	b.health.update([]*observatory.OutboundStatus{{OutboundTag: "a2", Alive: true}}, time.Now())
+	if tag := b.pickFromGroups(nil, groups); tag != "a2" {
+		t.Error("expect a2, but actually ", tag)
+	}
+}
+
+func TestBalancerHoldsPickOnlyForBestOutbound(t *testing.T) {
+	b := &Balancer{
+		strategy: &RoundRobinStrategy{},
+		health:   newHealthTracker(0, 0),
+		holdTime: time.Minute,
+	}
+	groups := [][]string{{"a", "b"}}
+	picked := make(map[string]bool)
+	for i := 0; i < 4; i++ {
+		picked[b.pickFromGroups(nil, groups)] = true
+	}
+	if len(picked) != 2 {
+		t.Error("expect round robin between a and b, but actually ", picked)
+	}
+
+	for _, strategy := range []BalancingStrategy{&LeastPingStrategy{}, &LeastLoadStrategy{}} {
+		b.strategy = strategy
+		if !b.holdsPick() {
+			t.Errorf("expect %T to hold the outbound picked", strategy)
+		}
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"time"
 
+	"github.com/xtls/xray-core/app/router"
 	"github.com/xtls/xray-core/common"
 	"github.com/xtls/xray-core/common/errors"
 	"github.com/xtls/xray-core/core"
@@ -67,6 +68,29 @@ func (s *routingServer) RemoveRule(ctx context.Context, request *RemoveRuleReque
 	return nil, errors.New("unsupported router implementation")
 }
 
+func (s *routingServer) GetRuleStats(ctx context.Context, request *GetRuleStatsRequest) (*GetRuleStatsResponse, error) {
+	r, ok := s.router.(*router.Router)
+	if !ok {
+		return nil, errors.New("unsupported router implementation")
+	}
+	response := &GetRuleStatsResponse{}
+	for _, stat := range r.GetRuleStats(request.RuleTags, request.Reset_) {
+		rs := &RuleStats{
+			RuleTag:     stat.RuleTag,
+			OutboundTag: stat.OutboundTag,
+			BalancerTag: stat.BalancerTag,
+			Hits:        stat.Hits,
+			Uplink:      stat.Uplink,
+			Downlink:    stat.Downlink,
+		}
+		if !stat.LastHit.IsZero() {
+			rs.LastHit = stat.LastHit.Unix()
+		}
+		response.Stats = append(response.Stats, rs)
+	}
+	return response, nil
+}
+
 // NewRoutingServer creates a statistics service with statistics manager.
 func NewRoutingServer(router routing.Router, routingStats stats.Channel) RoutingServiceServer {
 	return &routingServer{
@@ -79,7 +103,14 @@ func (s *routingServer) TestRoute(ctx context.Context, request *TestRouteRequest
 	if request.RoutingContext == nil {
 		return nil, errors.New("Invalid routing request.")
 	}
-	route, err := s.router.PickRoute(AsRoutingContext(request.RoutingContext))
+	var route routing.Route
+	var err error
+	if r, ok := s.router.(*router.Router); ok {
+		// Probes are not counted as hits of rules.
+		route, err = r.TestRoute(AsRoutingContext(request.RoutingContext))
+	} else {
+		route, err = s.router.PickRoute(AsRoutingContext(request.RoutingContext))
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -90,6 +121,49 @@ func (s *routingServer) TestRoute(ctx context.Context, request *TestRouteRequest
 	return AsProtobufMessage(request.FieldSelectors)(route), nil
 }
 
+func (s *routingServer) ExplainRoute(ctx context.Context, request *TestRouteRequest) (*ExplainRouteResponse, error) {
+	if request.RoutingContext == nil {
+		return nil, errors.New("Invalid routing request.")
+	}
+	r, ok := s.router.(*router.Router)
+	if !ok {
+		return nil, errors.New("unsupported router implementation")
+	}
+	e := r.ExplainRoute(AsRoutingContext(request.RoutingContext))
+	response := &ExplainRouteResponse{
+		DomainStrategy: e.DomainStrategy.String(),
+		Resolved:       e.Resolved,
+		OutboundTag:    e.OutboundTag,
+		RuleTag:        e.RuleTag,
+	}
+	for _, ip := range e.ResolvedIPs {
+		response.ResolvedIps = append(response.ResolvedIps, ip.String())
+	}
+	if e.ResolveErr != nil {
+		response.ResolveError = e.ResolveErr.Error()
+	}
+	if e.Err != nil && e.Err != common.ErrNoClue {
+		response.Error = e.Err.Error()
+	}
+	for _, rule := range e.Rules {
+		re := &RuleExplanation{
+			RuleTag:      rule.RuleTag,
+			OutboundTag:  rule.OutboundTag,
+			BalancerTag:  rule.BalancerTag,
+			Matched:      rule.Matched,
+			AfterResolve: rule.AfterResolve,
+		}
+		for _, c := range rule.Conditions {
+			re.Conditions = append(re.Conditions, &ConditionExplanation{
+				Name:   c.Name,
+				Status: string(c.Status),
+			})
+		}
+		response.Rules = append(response.Rules, re)
+	}
+	return response, nil
+}
+
 func (s *routingServer) SubscribeRoutingStats(request *SubscribeRoutingStatsRequest, stream RoutingService_SubscribeRoutingStatsServer) error {
 	if s.routingStats == nil {
 		return errors.New("Routing statistics not enabled.")
@@ -4,6 +4,7 @@ import (
 	"strings"
 
 	"github.com/xtls/xray-core/common/net"
+	"github.com/xtls/xray-core/common/process"
 	"github.com/xtls/xray-core/features/routing"
 )
 
@@ -51,6 +52,16 @@ func (c routingContext) GetSkipDNSResolve() bool {
 	return false
 }
 
+// GetALPN implements routing.Context. The ALPN is unknown for a protobuf object.
+func (c routingContext) GetALPN() []string {
+	return nil
+}
+
+// GetProcess implements routing.Context. The process is unknown for a protobuf object.
+func (c routingContext) GetProcess() *process.Info {
+	return nil
+}
+
 // AsRoutingContext converts a protobuf RoutingContext into an implementation of routing.Context.
 func AsRoutingContext(r *RoutingContext) routing.Context {
 	return routingContext{r}
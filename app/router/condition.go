@@ -3,6 +3,7 @@ package router
 import (
 	"regexp"
 	"strings"
+	"time"
 
 	"github.com/xtls/xray-core/common/errors"
 	"github.com/xtls/xray-core/common/net"
@@ -40,6 +41,29 @@ func (v *ConditionChan) Len() int {
 	return len(*v)
 }
 
+// AnyCondition matches if any of its conditions matches.
+type AnyCondition []Condition
+
+// Apply implements Condition.
+func (v AnyCondition) Apply(ctx routing.Context) bool {
+	for _, cond := range v {
+		if cond.Apply(ctx) {
+			return true
+		}
+	}
+	return false
+}
+
+// NotCondition matches if the condition in it does not.
+type NotCondition struct {
+	Condition Condition
+}
+
+// Apply implements Condition.
+func (v *NotCondition) Apply(ctx routing.Context) bool {
+	return !v.Condition.Apply(ctx)
+}
+
 var matcherTypeMap = map[Domain_Type]strmatcher.Type{
 	Domain_Plain:  strmatcher.Substr,
 	Domain_Regex:  strmatcher.Regex,
@@ -325,3 +349,216 @@ func (m *AttributeMatcher) Apply(ctx routing.Context) bool {
 	}
 	return m.Match(attributes)
 }
+
+// ProcessMatcher matches the local process the connection is from, by its name or executable path.
+type ProcessMatcher struct {
+	names    map[string]bool
+	paths    map[string]bool
+	dirPaths []string
+}
+
+func NewProcessMatcher(processes []string) *ProcessMatcher {
+	m := &ProcessMatcher{
+		names: make(map[string]bool),
+		paths: make(map[string]bool),
+	}
+	for _, p := range processes {
+		switch {
+		case strings.HasSuffix(p, "/"):
+			m.dirPaths = append(m.dirPaths, p)
+		case strings.Contains(p, "/"):
+			m.paths[p] = true
+		case len(p) > 0:
+			m.names[p] = true
+		}
+	}
+	return m
+}
+
+// Apply implements Condition.
+func (m *ProcessMatcher) Apply(ctx routing.Context) bool {
+	info := ctx.GetProcess()
+	if info == nil || info.PID == 0 {
+		return false
+	}
+	if m.names[info.Name] {
+		return true
+	}
+	if len(info.Path) == 0 {
+		return false
+	}
+	if m.paths[info.Path] {
+		return true
+	}
+	for _, dir := range m.dirPaths {
+		if strings.HasPrefix(info.Path, dir) {
+			return true
+		}
+	}
+	return false
+}
+
+// ProcessOwnerMatcher matches the UID or GID of the local process the connection is from.
+type ProcessOwnerMatcher struct {
+	ids    map[uint32]bool
+	asType string // "uid" or "gid"
+}
+
+func NewProcessOwnerMatcher(ids []uint32, asType string) *ProcessOwnerMatcher {
+	m := &ProcessOwnerMatcher{
+		ids:    make(map[uint32]bool, len(ids)),
+		asType: asType,
+	}
+	for _, id := range ids {
+		m.ids[id] = true
+	}
+	return m
+}
+
+// Apply implements Condition.
+func (m *ProcessOwnerMatcher) Apply(ctx routing.Context) bool {
+	info := ctx.GetProcess()
+	if info == nil {
+		return false
+	}
+	if m.asType == "gid" {
+		// GID is known only if the process is found
+		return info.PID != 0 && m.ids[info.GID]
+	}
+	return m.ids[info.UID]
+}
+
+// TLSFingerprintMatcher matches a fingerprint of the sniffed TLS client hello, i.e. its JA3 or JA4.
+type TLSFingerprintMatcher struct {
+	attribute string
+	values    map[string]bool
+}
+
+func NewTLSFingerprintMatcher(attribute string, values []string) *TLSFingerprintMatcher {
+	m := &TLSFingerprintMatcher{
+		attribute: attribute,
+		values:    make(map[string]bool, len(values)),
+	}
+	for _, v := range values {
+		m.values[strings.ToLower(v)] = true
+	}
+	return m
+}
+
+// Apply implements Condition.
+func (m *TLSFingerprintMatcher) Apply(ctx routing.Context) bool {
+	value, found := ctx.GetAttributes()[m.attribute]
+	return found && m.values[value]
+}
+
+// ALPNMatcher matches any of the protocols offered by the sniffed TLS client hello.
+type ALPNMatcher struct {
+	protocols map[string]bool
+}
+
+func NewALPNMatcher(protocols []string) *ALPNMatcher {
+	m := &ALPNMatcher{
+		protocols: make(map[string]bool, len(protocols)),
+	}
+	for _, p := range protocols {
+		m.protocols[p] = true
+	}
+	return m
+}
+
+// Apply implements Condition.
+func (m *ALPNMatcher) Apply(ctx routing.Context) bool {
+	for _, p := range ctx.GetALPN() {
+		if m.protocols[p] {
+			return true
+		}
+	}
+	return false
+}
+
+type ScheduleMatcher struct {
+	windows  []*TimeWindow
+	location *time.Location
+}
+
+func NewScheduleMatcher(schedule *Schedule) (*ScheduleMatcher, error) {
+	location, err := parseTimeZone(schedule.TimeZone)
+	if err != nil {
+		return nil, err
+	}
+	for _, w := range schedule.Window {
+		if w.Start >= 24*60 || w.End > 24*60 {
+			return nil, errors.New("invalid time window ", w.Start, "-", w.End)
+		}
+		for _, d := range w.Weekdays {
+			if d > 6 {
+				return nil, errors.New("invalid weekday ", d)
+			}
+		}
+	}
+	return &ScheduleMatcher{
+		windows:  schedule.Window,
+		location: location,
+	}, nil
+}
+
+// parseTimeZone parses an IANA time zone name or a fixed offset like "+08:00".
+func parseTimeZone(zone string) (*time.Location, error) {
+	if zone == "" {
+		return time.Local, nil
+	}
+	if zone[0] == '+' || zone[0] == '-' {
+		t, err := time.Parse("-07:00", zone)
+		if err != nil {
+			return nil, errors.New("invalid time zone offset ", zone).Base(err)
+		}
+		_, offset := t.Zone()
+		return time.FixedZone(zone, offset), nil
+	}
+	location, err := time.LoadLocation(zone)
+	if err != nil {
+		return nil, errors.New("unknown time zone ", zone).Base(err)
+	}
+	return location, nil
+}
+
+func hasWeekday(weekdays []uint32, day time.Weekday) bool {
+	if len(weekdays) == 0 {
+		return true
+	}
+	for _, d := range weekdays {
+		if time.Weekday(d) == day {
+			return true
+		}
+	}
+	return false
+}
+
+// Match reports whether t is in any of the time windows.
+func (m *ScheduleMatcher) Match(t time.Time) bool {
+	t = t.In(m.location)
+	minute := uint32(t.Hour()*60 + t.Minute())
+	day := t.Weekday()
+	yesterday := (day + 6) % 7
+	for _, w := range m.windows {
+		if w.Start < w.End {
+			if minute >= w.Start && minute < w.End && hasWeekday(w.Weekdays, day) {
+				return true
+			}
+			continue
+		}
+		// The window wraps over midnight, and its weekdays are the days it starts on.
+		if minute >= w.Start && hasWeekday(w.Weekdays, day) {
+			return true
+		}
+		if minute < w.End && hasWeekday(w.Weekdays, yesterday) {
+			return true
+		}
+	}
+	return false
+}
+
+// Apply implements Condition.
+func (m *ScheduleMatcher) Apply(ctx routing.Context) bool {
+	return m.Match(time.Now())
+}
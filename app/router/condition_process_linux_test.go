@@ -0,0 +1,60 @@
+package router_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/xtls/xray-core/app/router"
+	"github.com/xtls/xray-core/common"
+	"github.com/xtls/xray-core/common/net"
+	"github.com/xtls/xray-core/common/session"
+	routing_session "github.com/xtls/xray-core/features/routing/session"
+)
+
+func TestProcessMatcher(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	common.Must(err)
+	defer listener.Close()
+
+	conn, err := net.Dial("tcp", listener.Addr().String())
+	common.Must(err)
+	defer conn.Close()
+
+	executable, err := os.Executable()
+	common.Must(err)
+
+	withConn := func() *routing_session.Context {
+		return &routing_session.Context{
+			Inbound:  &session.Inbound{Source: net.DestinationFromAddr(conn.LocalAddr())},
+			Outbound: &session.Outbound{Target: net.DestinationFromAddr(listener.Addr())},
+		}
+	}
+	remote := &routing_session.Context{
+		Inbound:  &session.Inbound{Source: net.TCPDestination(net.ParseAddress("192.0.2.1"), 12345)},
+		Outbound: &session.Outbound{Target: net.DestinationFromAddr(listener.Addr())},
+	}
+
+	cases := []struct {
+		rule   *RoutingRule
+		output bool
+	}{
+		{&RoutingRule{Process: []string{filepath.Base(executable)}}, true},
+		{&RoutingRule{Process: []string{executable}}, true},
+		{&RoutingRule{Process: []string{filepath.Dir(executable) + "/"}}, true},
+		{&RoutingRule{Process: []string{"xray-nonexistent", "/nonexistent/"}}, false},
+		{&RoutingRule{Uid: []uint32{uint32(os.Getuid())}}, true},
+		{&RoutingRule{Uid: []uint32{uint32(os.Getuid()) + 1}}, false},
+		{&RoutingRule{Gid: []uint32{uint32(os.Getgid())}}, true},
+	}
+	for _, c := range cases {
+		cond, err := c.rule.BuildCondition()
+		common.Must(err)
+		if actual := cond.Apply(withConn()); actual != c.output {
+			t.Error("for rule ", c.rule, ", expect ", c.output, " but got ", actual)
+		}
+		if cond.Apply(remote) {
+			t.Error("for rule ", c.rule, ", expect no match for remote connection")
+		}
+	}
+}
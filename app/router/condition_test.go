@@ -3,6 +3,7 @@ package router_test
 import (
 	"strconv"
 	"testing"
+	"time"
 
 	. "github.com/xtls/xray-core/app/router"
 	"github.com/xtls/xray-core/common"
@@ -11,6 +12,7 @@ import (
 	"github.com/xtls/xray-core/common/platform/filesystem"
 	"github.com/xtls/xray-core/common/protocol"
 	"github.com/xtls/xray-core/common/protocol/http"
+	"github.com/xtls/xray-core/common/protocol/tls"
 	"github.com/xtls/xray-core/common/session"
 	"github.com/xtls/xray-core/features/routing"
 	routing_session "github.com/xtls/xray-core/features/routing/session"
@@ -33,6 +35,13 @@ func withContent(content *session.Content) routing.Context {
 	return &routing_session.Context{Content: content}
 }
 
+func withUserAndTarget(email string, domain string) routing.Context {
+	return &routing_session.Context{
+		Inbound:  &session.Inbound{User: &protocol.MemoryUser{Email: email}},
+		Outbound: &session.Outbound{Target: net.TCPDestination(net.DomainAddress(domain), 443)},
+	}
+}
+
 func TestRoutingRule(t *testing.T) {
 	type ruleTest struct {
 		input  routing.Context
@@ -285,6 +294,111 @@ func TestRoutingRule(t *testing.T) {
 				},
 			},
 		},
+		{
+			rule: &RoutingRule{
+				Ja3:  []string{"B8F81673C0E1D29908346F3BAB892B9B"},
+				Alpn: []string{"h2"},
+			},
+			test: []ruleTest{
+				{
+					input: withContent(&session.Content{Protocol: "tls", Attributes: map[string]string{
+						tls.AttributeJA3: "b8f81673c0e1d29908346f3bab892b9b",
+					}, ALPN: []string{"http/1.1", "h2"}}),
+					output: true,
+				},
+				{
+					input: withContent(&session.Content{Protocol: "tls", Attributes: map[string]string{
+						tls.AttributeJA3: "b8f81673c0e1d29908346f3bab892b9b",
+					}, ALPN: []string{"http/1.1"}}),
+					output: false,
+				},
+				{
+					input: withContent(&session.Content{Protocol: "tls", Attributes: map[string]string{
+						tls.AttributeJA3: "b8f81673c0e1d29908346f3bab892b9b",
+					}, ALPN: []string{"h2,http/1.1"}}),
+					output: false,
+				},
+				{
+					input:  withContent(&session.Content{Protocol: "tls"}),
+					output: false,
+				},
+			},
+		},
+		{
+			rule: &RoutingRule{
+				Ja4: []string{"t12d1510h2_f0daf39aad75_e69ac49eb88f"},
+			},
+			test: []ruleTest{
+				{
+					input:  withContent(&session.Content{Attributes: map[string]string{tls.AttributeJA4: "t12d1510h2_f0daf39aad75_e69ac49eb88f"}}),
+					output: true,
+				},
+				{
+					input:  withContent(&session.Content{Attributes: map[string]string{tls.AttributeJA4: "q12d1510h2_f0daf39aad75_e69ac49eb88f"}}),
+					output: false,
+				},
+			},
+		},
+		{
+			rule: &RoutingRule{
+				OrRules: []*RoutingRule{
+					{Domain: []*Domain{{Value: "example.com", Type: Domain_Domain}}},
+					{Domain: []*Domain{{Value: "example.org", Type: Domain_Domain}}},
+				},
+				NotRules: []*RoutingRule{
+					{Domain: []*Domain{{Value: "ads.example.com", Type: Domain_Domain}}},
+					{UserEmail: []string{"love@xray.com"}},
+				},
+			},
+			test: []ruleTest{
+				{
+					input:  withUserAndTarget("", "www.example.org"),
+					output: true,
+				},
+				{
+					input:  withUserAndTarget("admin@xray.com", "example.com"),
+					output: true,
+				},
+				{
+					input:  withUserAndTarget("", "ads.example.com"),
+					output: false,
+				},
+				{
+					input:  withUserAndTarget("love@xray.com", "example.com"),
+					output: false,
+				},
+				{
+					input:  withUserAndTarget("", "example.net"),
+					output: false,
+				},
+			},
+		},
+		{
+			rule: &RoutingRule{
+				Networks: []net.Network{net.Network_TCP},
+				AndRules: []*RoutingRule{
+					{
+						NotRules: []*RoutingRule{
+							{PortList: &net.PortList{Range: []*net.PortRange{{From: 443, To: 443}}}},
+						},
+					},
+				},
+			},
+			test: []ruleTest{
+				{
+					input:  withOutbound(&session.Outbound{Target: net.TCPDestination(net.DomainAddress("example.com"), 80)}),
+					output: true,
+				},
+				{
+					input:  withOutbound(&session.Outbound{Target: net.TCPDestination(net.DomainAddress("example.com"), 443)}),
+					output: false,
+				},
+				{
+					input:  withOutbound(&session.Outbound{Target: net.UDPDestination(net.DomainAddress("example.com"), 80)}),
+					output: false,
+				},
+			},
+		},
 	}
 
 	for _, test := range cases {
@@ -458,3 +572,42 @@ func BenchmarkMultiGeoIPMatcher(b *testing.B) {
 		_ = matcher.Apply(ctx)
 	}
 }
+
+func TestScheduleMatcher(t *testing.T) {
+	matcher, err := NewScheduleMatcher(&Schedule{
+		TimeZone: "+08:00",
+		Window: []*TimeWindow{
+			// Friday night to Saturday morning
+			{Weekdays: []uint32{5}, Start: 22 * 60, End: 6 * 60},
+			// Every day at noon
+			{Start: 12 * 60, End: 13 * 60},
+		},
+	})
+	common.Must(err)
+
+	zone := time.FixedZone("", 8*3600)
+	cases := []struct {
+		time   time.Time
+		output bool
+	}{
+		{time.Date(2024, 5, 3, 21, 59, 0, 0, zone), false}, // Friday
+		{time.Date(2024, 5, 3, 22, 0, 0, 0, zone), true},
+		{time.Date(2024, 5, 4, 5, 59, 0, 0, zone), true}, // Saturday
+		{time.Date(2024, 5, 4, 6, 0, 0, 0, zone), false},
+		{time.Date(2024, 5, 4, 22, 30, 0, 0, zone), false},
+		{time.Date(2024, 5, 2, 23, 0, 0, 0, zone), false},      // Thursday
+		{time.Date(2024, 5, 6, 12, 30, 0, 0, zone), true},      // Monday
+		{time.Date(2024, 5, 3, 14, 0, 0, 0, time.UTC), true},   // Friday 22:00 at +08:00
+		{time.Date(2024, 5, 6, 4, 30, 0, 0, time.UTC), true},   // Monday 12:30 at +08:00
+		{time.Date(2024, 5, 6, 12, 30, 0, 0, time.UTC), false}, // Monday 20:30 at +08:00
+	}
+	for _, c := range cases {
+		if actual := matcher.Match(c.time); actual != c.output {
+			t.Error("for ", c.time, ", expect ", c.output, " but got ", actual)
+		}
+	}
+
+	if _, err := NewScheduleMatcher(&Schedule{TimeZone: "Nowhere/Nothing"}); err == nil {
+		t.Error("expect error for unknown time zone")
+	}
+}
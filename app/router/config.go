@@ -4,8 +4,10 @@ import (
 	"context"
 	"regexp"
 	"strings"
+	"time"
 
 	"github.com/xtls/xray-core/common/errors"
+	"github.com/xtls/xray-core/common/protocol/tls"
 	"github.com/xtls/xray-core/features/outbound"
 	"github.com/xtls/xray-core/features/routing"
 )
@@ -15,11 +17,13 @@ type Rule struct {
 	RuleTag   string
 	Balancer  *Balancer
 	Condition Condition
+
+	stats *ruleStats
 }
 
-func (r *Rule) GetTag() (string, error) {
+func (r *Rule) GetTag(ctx routing.Context) (string, error) {
 	if r.Balancer != nil {
-		return r.Balancer.PickOutbound()
+		return r.Balancer.PickOutbound(ctx)
 	}
 	return r.Tag, nil
 }
@@ -30,6 +34,11 @@ func (r *Rule) Apply(ctx routing.Context) bool {
 }
 
 func (rr *RoutingRule) BuildCondition() (Condition, error) {
+	return rr.buildCondition(nil)
+}
+
+// buildCondition builds the condition of the rule, looking up rule sets in ruleSets.
+func (rr *RoutingRule) buildCondition(ruleSets map[string]*ruleSetProvider) (Condition, error) {
 	conds := NewConditionChan()
 
 	if len(rr.Domain) > 0 {
@@ -106,6 +115,78 @@ func (rr *RoutingRule) BuildCondition() (Condition, error) {
 		conds.Add(&AttributeMatcher{configuredKeys})
 	}
 
+	if len(rr.RuleSet) > 0 {
+		matcher := &RuleSetMatcher{}
+		for _, tag := range rr.RuleSet {
+			p, found := ruleSets[tag]
+			if !found {
+				return nil, errors.New("rule set ", tag, " not found")
+			}
+			matcher.providers = append(matcher.providers, p)
+		}
+		conds.Add(matcher)
+	}
+
+	if len(rr.Process) > 0 {
+		conds.Add(NewProcessMatcher(rr.Process))
+	}
+
+	if len(rr.Uid) > 0 {
+		conds.Add(NewProcessOwnerMatcher(rr.Uid, "uid"))
+	}
+
+	if len(rr.Gid) > 0 {
+		conds.Add(NewProcessOwnerMatcher(rr.Gid, "gid"))
+	}
+
+	if len(rr.Ja3) > 0 {
+		conds.Add(NewTLSFingerprintMatcher(tls.AttributeJA3, rr.Ja3))
+	}
+
+	if len(rr.Ja4) > 0 {
+		conds.Add(NewTLSFingerprintMatcher(tls.AttributeJA4, rr.Ja4))
+	}
+
+	if len(rr.Alpn) > 0 {
+		conds.Add(NewALPNMatcher(rr.Alpn))
+	}
+
+	if rr.Schedule != nil && len(rr.Schedule.Window) > 0 {
+		cond, err := NewScheduleMatcher(rr.Schedule)
+		if err != nil {
+			return nil, errors.New("failed to build schedule condition").Base(err)
+		}
+		conds.Add(cond)
+	}
+
+	for _, sub := range rr.AndRules {
+		cond, err := sub.buildCondition(ruleSets)
+		if err != nil {
+			return nil, errors.New("failed to build sub-rule of and").Base(err)
+		}
+		conds.Add(cond)
+	}
+
+	if len(rr.OrRules) > 0 {
+		var anyOf AnyCondition
+		for _, sub := range rr.OrRules {
+			cond, err := sub.buildCondition(ruleSets)
+			if err != nil {
+				return nil, errors.New("failed to build sub-rule of or").Base(err)
+			}
+			anyOf = append(anyOf, cond)
+		}
+		conds.Add(anyOf)
+	}
+
+	for _, sub := range rr.NotRules {
+		cond, err := sub.buildCondition(ruleSets)
+		if err != nil {
+			return nil, errors.New("failed to build sub-rule of not").Base(err)
+		}
+		conds.Add(&NotCondition{Condition: cond})
+	}
+
 	if conds.Len() == 0 {
 		return nil, errors.New("this rule has no effective fields").AtWarning()
 	}
@@ -115,21 +196,12 @@ func (rr *RoutingRule) BuildCondition() (Condition, error) {
 
 // Build builds the balancing rule
 func (br *BalancingRule) Build(ohm outbound.Manager, dispatcher routing.Dispatcher) (*Balancer, error) {
+	var strategy BalancingStrategy
 	switch strings.ToLower(br.Strategy) {
 	case "leastping":
-		return &Balancer{
-			selectors:   br.OutboundSelector,
-			strategy:    &LeastPingStrategy{},
-			fallbackTag: br.FallbackTag,
-			ohm:         ohm,
-		}, nil
+		strategy = &LeastPingStrategy{}
 	case "roundrobin":
-		return &Balancer{
-			selectors:   br.OutboundSelector,
-			strategy:    &RoundRobinStrategy{FallbackTag: br.FallbackTag},
-			fallbackTag: br.FallbackTag,
-			ohm:         ohm,
-		}, nil
+		strategy = &RoundRobinStrategy{FallbackTag: br.FallbackTag}
 	case "leastload":
 		i, err := br.StrategySettings.GetInstance()
 		if err != nil {
@@ -139,23 +211,41 @@ func (br *BalancingRule) Build(ohm outbound.Manager, dispatcher routing.Dispatch
 		if !ok {
 			return nil, errors.New("not a StrategyLeastLoadConfig").AtError()
 		}
-		leastLoadStrategy := NewLeastLoadStrategy(s)
-		return &Balancer{
-			selectors:   br.OutboundSelector,
-			ohm:         ohm,
-			fallbackTag: br.FallbackTag,
-			strategy:    leastLoadStrategy,
-		}, nil
+		strategy = NewLeastLoadStrategy(s)
+	case "consistenthash":
+		s := &StrategyConsistentHashConfig{}
+		if br.StrategySettings != nil {
+			i, err := br.StrategySettings.GetInstance()
+			if err != nil {
+				return nil, err
+			}
+			var ok bool
+			if s, ok = i.(*StrategyConsistentHashConfig); !ok {
+				return nil, errors.New("not a StrategyConsistentHashConfig").AtError()
+			}
+		}
+		strategy = NewConsistentHashStrategy(s)
 	case "random":
 		fallthrough
 	case "":
-		return &Balancer{
-			selectors:   br.OutboundSelector,
-			ohm:         ohm,
-			fallbackTag: br.FallbackTag,
-			strategy:    &RandomStrategy{FallbackTag: br.FallbackTag},
-		}, nil
+		strategy = &RandomStrategy{FallbackTag: br.FallbackTag}
 	default:
 		return nil, errors.New("unrecognized balancer type")
 	}
+
+	b := &Balancer{
+		selectors:   br.OutboundSelector,
+		strategy:    strategy,
+		fallbackTag: br.FallbackTag,
+		ohm:         ohm,
+	}
+	for _, group := range br.FailoverGroup {
+		b.failoverSelectors = append(b.failoverSelectors, group.OutboundSelector)
+	}
+	if len(b.failoverSelectors) > 0 || br.Hysteresis != nil {
+		h := br.GetHysteresis()
+		b.health = newHealthTracker(time.Duration(h.GetDeadAfter()), time.Duration(h.GetAliveAfter()))
+		b.holdTime = time.Duration(h.GetHoldTime())
+	}
+	return b, nil
 }
@@ -0,0 +1,162 @@
+package router
+
+import (
+	"github.com/xtls/xray-core/common/net"
+	"github.com/xtls/xray-core/common/protocol/tls"
+	"github.com/xtls/xray-core/features/routing"
+	routing_dns "github.com/xtls/xray-core/features/routing/dns"
+)
+
+// ConditionStatus is the result of a condition of a rule in explain mode.
+type ConditionStatus string
+
+const (
+	ConditionMatched = ConditionStatus("matched")
+	ConditionFailed  = ConditionStatus("failed")
+	// The condition is not checked, as an earlier condition of the rule failed.
+	ConditionSkipped = ConditionStatus("skipped")
+)
+
+// ConditionExplanation tells whether a condition of a rule matched.
+type ConditionExplanation struct {
+	Name   string
+	Status ConditionStatus
+}
+
+// RuleExplanation tells whether a rule matched, and why.
+type RuleExplanation struct {
+	RuleTag     string
+	OutboundTag string
+	BalancerTag string
+	Matched     bool
+	Conditions  []ConditionExplanation
+	// The rule is checked again with the resolved IPs of the target domain, due to IPIfNonMatch.
+	AfterResolve bool
+}
+
+// RouteExplanation is the routing decision in explain mode, with every rule checked in order.
+type RouteExplanation struct {
+	Rules []*RuleExplanation
+
+	DomainStrategy Config_DomainStrategy
+	// Whether the target domain is looked up during routing, and the result.
+	Resolved    bool
+	ResolvedIPs []net.IP
+	ResolveErr  error
+
+	OutboundTag string
+	RuleTag     string
+	Err         error
+}
+
+// conditionName returns the name of the condition, which is the field of the rule in JSON config.
+func conditionName(cond Condition) string {
+	switch c := cond.(type) {
+	case *DomainMatcher:
+		return "domain"
+	case *MultiGeoIPMatcher:
+		switch c.asType {
+		case "source":
+			return "source"
+		case "local":
+			return "localIP"
+		default:
+			return "ip"
+		}
+	case *PortMatcher:
+		switch c.asType {
+		case "source":
+			return "sourcePort"
+		case "local":
+			return "localPort"
+		case "vlessRoute":
+			return "vlessRoute"
+		default:
+			return "port"
+		}
+	case NetworkMatcher, *NetworkMatcher:
+		return "network"
+	case *UserMatcher:
+		return "user"
+	case *InboundTagMatcher:
+		return "inboundTag"
+	case *ProtocolMatcher:
+		return "protocol"
+	case *AttributeMatcher:
+		return "attrs"
+	case *RuleSetMatcher:
+		return "ruleSet"
+	case *ScheduleMatcher:
+		return "schedule"
+	case *ProcessMatcher:
+		return "process"
+	case *ProcessOwnerMatcher:
+		return c.asType
+	case *TLSFingerprintMatcher:
+		if c.attribute == tls.AttributeJA3 {
+			return "ja3"
+		}
+		return "ja4"
+	case *ALPNMatcher:
+		return "alpn"
+	case *ConditionChan:
+		return "and"
+	case AnyCondition:
+		return "or"
+	case *NotCondition:
+		return "not"
+	default:
+		return "unknown"
+	}
+}
+
+// explainRule applies the rule to ctx like Rule.Apply, recording the result of every condition.
+func explainRule(rule *Rule, ctx routing.Context, afterResolve bool) *RuleExplanation {
+	e := &RuleExplanation{
+		RuleTag:      rule.RuleTag,
+		OutboundTag:  rule.Tag,
+		AfterResolve: afterResolve,
+	}
+	if rule.Balancer != nil {
+		e.BalancerTag = rule.Balancer.tag
+	}
+
+	conds, ok := rule.Condition.(*ConditionChan)
+	if !ok {
+		e.Matched = rule.Apply(ctx)
+		return e
+	}
+	e.Matched = true
+	for _, cond := range *conds {
+		status := ConditionSkipped
+		if e.Matched {
+			if cond.Apply(ctx) {
+				status = ConditionMatched
+			} else {
+				status = ConditionFailed
+				e.Matched = false
+			}
+		}
+		e.Conditions = append(e.Conditions, ConditionExplanation{
+			Name:   conditionName(cond),
+			Status: status,
+		})
+	}
+	return e
+}
+
+// ExplainRoute makes the routing decision for ctx like PickRoute, and explains how every rule matched.
+func (r *Router) ExplainRoute(ctx routing.Context) *RouteExplanation {
+	e := &RouteExplanation{}
+	rule, ctx, err := r.pickRouteInternal(ctx, e)
+	if rctx, ok := ctx.(*routing_dns.ResolvableContext); ok {
+		e.Resolved, e.ResolvedIPs, e.ResolveErr = rctx.Lookup()
+	}
+	if err != nil {
+		e.Err = err
+		return e
+	}
+	e.RuleTag = rule.RuleTag
+	e.OutboundTag, e.Err = rule.GetTag(ctx)
+	return e
+}
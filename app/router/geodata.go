@@ -0,0 +1,140 @@
+package router
+
+import (
+	"bufio"
+	"bytes"
+	gonet "net"
+	"strings"
+
+	"github.com/xtls/xray-core/common/errors"
+)
+
+// MatchGeoSite returns the domains of site that match domain, with the same semantics as routing.
+func MatchGeoSite(site *GeoSite, domain string) ([]*Domain, error) {
+	domain = strings.ToLower(domain)
+	var matched []*Domain
+	for _, d := range site.Domain {
+		matcher, err := domainToMatcher(d)
+		if err != nil {
+			return nil, errors.New("invalid domain ", d.Value, " in ", site.CountryCode).Base(err)
+		}
+		if matcher.Match(domain) {
+			matched = append(matched, d)
+		}
+	}
+	return matched, nil
+}
+
+// MatchGeoIP returns the CIDRs of geoip that contain ip. Reverse match of geoip is not applied.
+func MatchGeoIP(geoip *GeoIP, ip gonet.IP) []*CIDR {
+	if ip4 := ip.To4(); ip4 != nil {
+		ip = ip4
+	}
+	var matched []*CIDR
+	for _, cidr := range geoip.Cidr {
+		if len(cidr.Ip) != len(ip) {
+			continue
+		}
+		ipNet := gonet.IPNet{
+			IP:   cidr.Ip,
+			Mask: gonet.CIDRMask(int(cidr.Prefix), len(cidr.Ip)*8),
+		}
+		if ipNet.Contains(ip) {
+			matched = append(matched, cidr)
+		}
+	}
+	return matched
+}
+
+// DomainString returns the domain in the text form of rule sets, followed by its attributes like "@cn".
+func DomainString(d *Domain) string {
+	var prefix string
+	switch d.Type {
+	case Domain_Plain:
+		prefix = "keyword:"
+	case Domain_Regex:
+		prefix = "regexp:"
+	case Domain_Domain:
+		prefix = "domain:"
+	case Domain_Full:
+		prefix = "full:"
+	}
+	s := prefix + d.Value
+	for _, attr := range d.Attribute {
+		s += " @" + attr.Key
+	}
+	return s
+}
+
+// CIDRString returns the CIDR in the form of "192.168.0.0/16".
+func CIDRString(cidr *CIDR) string {
+	ipNet := gonet.IPNet{
+		IP:   cidr.Ip,
+		Mask: gonet.CIDRMask(int(cidr.Prefix), len(cidr.Ip)*8),
+	}
+	return ipNet.String()
+}
+
+// ParseGeoSiteText parses a category of geosite in text, one domain per line in the form of rule sets, which may
+// be followed by attributes like "@cn". Lines of "include:name" include other categories, and are returned as is.
+func ParseGeoSiteText(data []byte) ([]*Domain, []string, error) {
+	var domains []*Domain
+	var includes []string
+	err := scanGeoDataText(data, func(entry string) error {
+		fields := strings.Fields(entry)
+		if value, found := strings.CutPrefix(fields[0], "include:"); found {
+			includes = append(includes, value)
+			return nil
+		}
+		domain, _, err := parseRuleSetEntry(fields[0])
+		if err != nil {
+			return err
+		}
+		if domain == nil {
+			return errors.New("not a domain: ", fields[0])
+		}
+		for _, f := range fields[1:] {
+			key, found := strings.CutPrefix(f, "@")
+			if !found || key == "" {
+				return errors.New("invalid attribute: ", f)
+			}
+			domain.Attribute = append(domain.Attribute, &Domain_Attribute{
+				Key:        strings.ToLower(key),
+				TypedValue: &Domain_Attribute_BoolValue{BoolValue: true},
+			})
+		}
+		domains = append(domains, domain)
+		return nil
+	})
+	return domains, includes, err
+}
+
+// ParseGeoIPText parses a category of geoip in text, one IP or CIDR per line.
+func ParseGeoIPText(data []byte) ([]*CIDR, error) {
+	var cidrs []*CIDR
+	err := scanGeoDataText(data, func(entry string) error {
+		cidr, err := parseCIDR(strings.TrimPrefix(entry, "ip:"))
+		if err != nil {
+			return err
+		}
+		cidrs = append(cidrs, cidr)
+		return nil
+	})
+	return cidrs, err
+}
+
+// scanGeoDataText calls parse with every line of data, except empty lines and comments.
+func scanGeoDataText(data []byte, parse func(entry string) error) error {
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for line := 1; scanner.Scan(); line++ {
+		entry, _, _ := strings.Cut(scanner.Text(), "#")
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		if err := parse(entry); err != nil {
+			return errors.New("invalid entry at line ", line).Base(err)
+		}
+	}
+	return scanner.Err()
+}
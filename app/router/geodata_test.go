@@ -0,0 +1,115 @@
+package router_test
+
+import (
+	"net"
+	"testing"
+
+	. "github.com/xtls/xray-core/app/router"
+	"github.com/xtls/xray-core/common"
+)
+
+func TestGeoSiteText(t *testing.T) {
+	domains, includes, err := ParseGeoSiteText([]byte(`
+# comment
+google.com
+full:www.youtube.com @ads @cn
+keyword:goog # trailing comment
+regexp:^ad[0-9]+\.example\.com$
+regexp:^cdn\D+\.example\.com$
+include:extra
+`))
+	common.Must(err)
+	if len(includes) != 1 || includes[0] != "extra" {
+		t.Error("unexpected includes ", includes)
+	}
+
+	expected := []string{
+		"domain:google.com",
+		"full:www.youtube.com @ads @cn",
+		"keyword:goog",
+		`regexp:^ad[0-9]+\.example\.com$`,
+		`regexp:^cdn\D+\.example\.com$`,
+	}
+	if len(domains) != len(expected) {
+		t.Fatal("expect ", len(expected), " domains, but got ", len(domains))
+	}
+	for i, d := range domains {
+		if s := DomainString(d); s != expected[i] {
+			t.Error("expect ", expected[i], ", but got ", s)
+		}
+	}
+
+	site := &GeoSite{CountryCode: "TEST", Domain: domains}
+	cases := []struct {
+		domain  string
+		matched []string
+	}{
+		{"maps.Google.com", []string{"domain:google.com", "keyword:goog"}},
+		{"www.youtube.com", []string{"full:www.youtube.com @ads @cn"}},
+		{"m.youtube.com", nil},
+		{"ad12.example.com", []string{`regexp:^ad[0-9]+\.example\.com$`}},
+		{"cdnx.example.com", []string{`regexp:^cdn\D+\.example\.com$`}},
+		{"cdn1.example.com", nil},
+	}
+	for _, c := range cases {
+		matched, err := MatchGeoSite(site, c.domain)
+		common.Must(err)
+		if len(matched) != len(c.matched) {
+			t.Error("expect ", c.domain, " to match ", c.matched, ", but got ", len(matched), " matches")
+			continue
+		}
+		for i, d := range matched {
+			if s := DomainString(d); s != c.matched[i] {
+				t.Error("expect ", c.matched[i], ", but got ", s)
+			}
+		}
+	}
+
+	if _, _, err := ParseGeoSiteText([]byte("10.0.0.0/8")); err == nil {
+		t.Error("expect error for IP in geosite")
+	}
+	if _, _, err := ParseGeoSiteText([]byte("example.com cn")); err == nil {
+		t.Error("expect error for attribute without @")
+	}
+}
+
+func TestGeoIPText(t *testing.T) {
+	cidrs, err := ParseGeoIPText([]byte(`
+10.0.0.0/8
+ip:192.168.1.1 # comment
+fd00::/8
+`))
+	common.Must(err)
+
+	expected := []string{"10.0.0.0/8", "192.168.1.1/32", "fd00::/8"}
+	if len(cidrs) != len(expected) {
+		t.Fatal("expect ", len(expected), " CIDRs, but got ", len(cidrs))
+	}
+	for i, cidr := range cidrs {
+		if s := CIDRString(cidr); s != expected[i] {
+			t.Error("expect ", expected[i], ", but got ", s)
+		}
+	}
+
+	geoip := &GeoIP{CountryCode: "TEST", Cidr: cidrs}
+	cases := []struct {
+		ip      string
+		matched int
+	}{
+		{"10.1.2.3", 1},
+		{"192.168.1.1", 1},
+		{"192.168.1.2", 0},
+		{"fd00::1", 1},
+		{"::ffff:10.0.0.1", 1},
+		{"2001:db8::1", 0},
+	}
+	for _, c := range cases {
+		if matched := MatchGeoIP(geoip, net.ParseIP(c.ip)); len(matched) != c.matched {
+			t.Error("expect ", c.ip, " to match ", c.matched, " CIDRs, but got ", len(matched))
+		}
+	}
+
+	if _, err := ParseGeoIPText([]byte("example.com")); err == nil {
+		t.Error("expect error for domain in geoip")
+	}
+}
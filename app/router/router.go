@@ -12,6 +12,7 @@ import (
 	"github.com/xtls/xray-core/features/outbound"
 	"github.com/xtls/xray-core/features/routing"
 	routing_dns "github.com/xtls/xray-core/features/routing/dns"
+	"github.com/xtls/xray-core/features/stats"
 )
 
 // Router is an implementation of routing.Router.
@@ -19,12 +20,16 @@ type Router struct {
 	domainStrategy Config_DomainStrategy
 	rules          []*Rule
 	balancers      map[string]*Balancer
+	ruleSets       map[string]*ruleSetProvider
+	ruleStats      *ruleStatsRegistry
 	dns            dns.Client
 
 	ctx        context.Context
 	ohm        outbound.Manager
 	dispatcher routing.Dispatcher
-	mu         sync.Mutex
+	// mu guards the rules, balancers and rule sets. The rules slice is replaced rather than modified in place, so
+	// that it can be used after the lock is released.
+	mu sync.RWMutex
 }
 
 // Route is an implementation of routing.Route.
@@ -33,15 +38,26 @@ type Route struct {
 	outboundGroupTags []string
 	outboundTag       string
 	ruleTag           string
+	ruleStats         *ruleStats
 }
 
 // Init initializes the Router.
-func (r *Router) Init(ctx context.Context, config *Config, d dns.Client, ohm outbound.Manager, dispatcher routing.Dispatcher) error {
+func (r *Router) Init(ctx context.Context, config *Config, d dns.Client, ohm outbound.Manager, dispatcher routing.Dispatcher) (err error) {
 	r.domainStrategy = config.DomainStrategy
 	r.dns = d
 	r.ctx = ctx
 	r.ohm = ohm
 	r.dispatcher = dispatcher
+	if r.ruleStats == nil {
+		r.ruleStats = newRuleStatsRegistry()
+	}
+
+	defer func() {
+		if err != nil {
+			r.closeBalancers()
+			r.closeRuleSets()
+		}
+	}()
 
 	r.balancers = make(map[string]*Balancer, len(config.BalancingRule))
 	for _, rule := range config.BalancingRule {
@@ -49,13 +65,27 @@ func (r *Router) Init(ctx context.Context, config *Config, d dns.Client, ohm out
 		if err != nil {
 			return err
 		}
+		balancer.tag = rule.Tag
 		balancer.InjectContext(ctx)
 		r.balancers[rule.Tag] = balancer
 	}
 
+	r.ruleSets = make(map[string]*ruleSetProvider, len(config.RuleSet))
+	for _, ruleSet := range config.RuleSet {
+		if _, found := r.ruleSets[ruleSet.Tag]; found {
+			return errors.New("duplicate rule set tag ", ruleSet.Tag)
+		}
+		p, err := newRuleSetProvider(ruleSet)
+		if err != nil {
+			return err
+		}
+		common.Must(p.Start())
+		r.ruleSets[ruleSet.Tag] = p
+	}
+
 	r.rules = make([]*Rule, 0, len(config.Rule))
 	for _, rule := range config.Rule {
-		cond, err := rule.BuildCondition()
+		cond, err := rule.buildCondition(r.ruleSets)
 		if err != nil {
 			return err
 		}
@@ -63,6 +93,7 @@ func (r *Router) Init(ctx context.Context, config *Config, d dns.Client, ohm out
 			Condition: cond,
 			Tag:       rule.GetTag(),
 			RuleTag:   rule.GetRuleTag(),
+			stats:     r.ruleStats.get(rule.GetRuleTag()),
 		}
 		btag := rule.GetBalancingTag()
 		if len(btag) > 0 {
@@ -80,15 +111,28 @@ func (r *Router) Init(ctx context.Context, config *Config, d dns.Client, ohm out
 
 // PickRoute implements routing.Router.
 func (r *Router) PickRoute(ctx routing.Context) (routing.Route, error) {
-	rule, ctx, err := r.pickRouteInternal(ctx)
+	return r.pickRoute(ctx, true)
+}
+
+// TestRoute makes the routing decision for ctx like PickRoute, but does not count it as a hit of the rule,
+// as no connection is routed.
+func (r *Router) TestRoute(ctx routing.Context) (routing.Route, error) {
+	return r.pickRoute(ctx, false)
+}
+
+func (r *Router) pickRoute(ctx routing.Context, hit bool) (routing.Route, error) {
+	rule, ctx, err := r.pickRouteInternal(ctx, nil)
 	if err != nil {
 		return nil, err
 	}
-	tag, err := rule.GetTag()
+	tag, err := rule.GetTag(ctx)
 	if err != nil {
 		return nil, err
 	}
-	return &Route{Context: ctx, outboundTag: tag, ruleTag: rule.RuleTag}, nil
+	if hit && rule.stats != nil {
+		rule.stats.hit()
+	}
+	return &Route{Context: ctx, outboundTag: tag, ruleTag: rule.RuleTag, ruleStats: rule.stats}, nil
 }
 
 // AddRule implements routing.Router.
@@ -109,6 +153,7 @@ func (r *Router) ReloadRules(config *Config, shouldAppend bool) error {
 	defer r.mu.Unlock()
 
 	if !shouldAppend {
+		r.closeBalancers()
 		r.balancers = make(map[string]*Balancer, len(config.BalancingRule))
 		r.rules = make([]*Rule, 0, len(config.Rule))
 	}
@@ -121,6 +166,7 @@ func (r *Router) ReloadRules(config *Config, shouldAppend bool) error {
 		if err != nil {
 			return err
 		}
+		balancer.tag = rule.Tag
 		balancer.InjectContext(r.ctx)
 		r.balancers[rule.Tag] = balancer
 	}
@@ -129,7 +175,7 @@ func (r *Router) ReloadRules(config *Config, shouldAppend bool) error {
 		if r.RuleExists(rule.GetRuleTag()) {
 			return errors.New("duplicate ruleTag ", rule.GetRuleTag())
 		}
-		cond, err := rule.BuildCondition()
+		cond, err := rule.buildCondition(r.ruleSets)
 		if err != nil {
 			return err
 		}
@@ -137,6 +183,7 @@ func (r *Router) ReloadRules(config *Config, shouldAppend bool) error {
 			Condition: cond,
 			Tag:       rule.GetTag(),
 			RuleTag:   rule.GetRuleTag(),
+			stats:     r.ruleStats.get(rule.GetRuleTag()),
 		}
 		btag := rule.GetBalancingTag()
 		if len(btag) > 0 {
@@ -152,6 +199,43 @@ func (r *Router) ReloadRules(config *Config, shouldAppend bool) error {
 	return nil
 }
 
+// Reload implements features.Reloadable.
+func (r *Router) Reload(config interface{}) error {
+	c, ok := config.(*Config)
+	if !ok {
+		return errors.New("Reload: config type error")
+	}
+	nr := &Router{ruleStats: r.ruleStats}
+	if err := nr.Init(r.ctx, c, r.dns, r.ohm, r.dispatcher); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.closeBalancers()
+	r.closeRuleSets()
+	r.domainStrategy = nr.domainStrategy
+	r.balancers = nr.balancers
+	r.ruleSets = nr.ruleSets
+	r.rules = nr.rules
+	return nil
+}
+
+// closeBalancers stops tracking the health of outbounds for the balancers.
+func (r *Router) closeBalancers() {
+	for _, b := range r.balancers {
+		b.Close()
+	}
+}
+
+// closeRuleSets stops refreshing the rule sets.
+func (r *Router) closeRuleSets() {
+	for _, p := range r.ruleSets {
+		p.Close()
+	}
+}
+
 func (r *Router) RuleExists(tag string) bool {
 	if tag != "" {
 		for _, rule := range r.rules {
@@ -181,31 +265,50 @@ func (r *Router) RemoveRule(tag string) error {
 	return errors.New("empty tag name!")
 
 }
-func (r *Router) pickRouteInternal(ctx routing.Context) (*Rule, routing.Context, error) {
+
+// pickRouteInternal finds the first rule matching ctx. If explanation is not nil, the result of every rule checked
+// is recorded in it.
+func (r *Router) pickRouteInternal(ctx routing.Context, explanation *RouteExplanation) (*Rule, routing.Context, error) {
+	r.mu.RLock()
+	rules, domainStrategy := r.rules, r.domainStrategy
+	r.mu.RUnlock()
+	if explanation != nil {
+		explanation.DomainStrategy = domainStrategy
+	}
+
 	// SkipDNSResolve is set from DNS module.
 	// the DOH remote server maybe a domain name,
 	// this prevents cycle resolving dead loop
 	skipDNSResolve := ctx.GetSkipDNSResolve()
 
-	if r.domainStrategy == Config_IpOnDemand && !skipDNSResolve {
+	if domainStrategy == Config_IpOnDemand && !skipDNSResolve {
 		ctx = routing_dns.ContextWithDNSClient(ctx, r.dns)
 	}
 
-	for _, rule := range r.rules {
-		if rule.Apply(ctx) {
+	apply := func(rule *Rule, afterResolve bool) bool {
+		if explanation == nil {
+			return rule.Apply(ctx)
+		}
+		e := explainRule(rule, ctx, afterResolve)
+		explanation.Rules = append(explanation.Rules, e)
+		return e.Matched
+	}
+
+	for _, rule := range rules {
+		if apply(rule, false) {
 			return rule, ctx, nil
 		}
 	}
 
-	if r.domainStrategy != Config_IpIfNonMatch || len(ctx.GetTargetDomain()) == 0 || skipDNSResolve {
+	if domainStrategy != Config_IpIfNonMatch || len(ctx.GetTargetDomain()) == 0 || skipDNSResolve {
 		return nil, ctx, common.ErrNoClue
 	}
 
 	ctx = routing_dns.ContextWithDNSClient(ctx, r.dns)
 
 	// Try applying rules again if we have IPs.
-	for _, rule := range r.rules {
-		if rule.Apply(ctx) {
+	for _, rule := range rules {
+		if apply(rule, true) {
 			return rule, ctx, nil
 		}
 	}
@@ -220,6 +323,11 @@ func (r *Router) Start() error {
 
 // Close implements common.Closable.
 func (r *Router) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.closeBalancers()
+	r.closeRuleSets()
 	return nil
 }
 
@@ -242,9 +350,23 @@ func (r *Route) GetRuleTag() string {
 	return r.ruleTag
 }
 
+// GetTrafficCounters implements routing.TrafficCountedRoute.
+func (r *Route) GetTrafficCounters() (stats.Counter, stats.Counter) {
+	if r.ruleStats == nil {
+		return nil, nil
+	}
+	return r.ruleStats.uplink, r.ruleStats.downlink
+}
+
 func init() {
 	common.Must(common.RegisterConfig((*Config)(nil), func(ctx context.Context, config interface{}) (interface{}, error) {
-		r := new(Router)
+		r := &Router{ruleStats: newRuleStatsRegistry()}
+		if err := core.OptionalFeatures(ctx, func(sm stats.Manager) error {
+			r.ruleStats.setManager(sm)
+			return nil
+		}); err != nil {
+			return nil, err
+		}
 		if err := core.RequireFeatures(ctx, func(d dns.Client, ohm outbound.Manager, dispatcher routing.Dispatcher) error {
 			return r.Init(ctx, config.(*Config), d, ohm, dispatcher)
 		}); err != nil {
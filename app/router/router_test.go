@@ -2,15 +2,26 @@ package router_test
 
 import (
 	"context"
+	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
+	"github.com/xtls/xray-core/app/dispatcher"
+	"github.com/xtls/xray-core/app/proxyman"
+	_ "github.com/xtls/xray-core/app/proxyman/outbound"
 	. "github.com/xtls/xray-core/app/router"
+	"github.com/xtls/xray-core/app/stats"
 	"github.com/xtls/xray-core/common"
+	"github.com/xtls/xray-core/common/errors"
 	"github.com/xtls/xray-core/common/net"
+	"github.com/xtls/xray-core/common/serial"
 	"github.com/xtls/xray-core/common/session"
+	"github.com/xtls/xray-core/core"
 	"github.com/xtls/xray-core/features/dns"
 	"github.com/xtls/xray-core/features/outbound"
+	"github.com/xtls/xray-core/features/routing"
 	routing_session "github.com/xtls/xray-core/features/routing/session"
 	"github.com/xtls/xray-core/testing/mocks"
 )
@@ -276,3 +287,212 @@ func TestIPIfNonMatchIP(t *testing.T) {
 		t.Error("expect tag 'test', bug actually ", tag)
 	}
 }
+
+func TestExplainRoute(t *testing.T) {
+	config := &Config{
+		DomainStrategy: Config_IpIfNonMatch,
+		Rule: []*RoutingRule{
+			{
+				RuleTag:   "tcp-8080",
+				TargetTag: &RoutingRule_Tag{Tag: "port"},
+				Networks:  []net.Network{net.Network_TCP},
+				PortList:  &net.PortList{Range: []*net.PortRange{{From: 8080, To: 8080}}},
+			},
+			{
+				RuleTag:   "private",
+				TargetTag: &RoutingRule_Tag{Tag: "test"},
+				Geoip: []*GeoIP{
+					{
+						Cidr: []*CIDR{
+							{
+								Ip:     []byte{192, 168, 0, 0},
+								Prefix: 16,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	mockCtl := gomock.NewController(t)
+	defer mockCtl.Finish()
+
+	mockDNS := mocks.NewDNSClient(mockCtl)
+	mockDNS.EXPECT().LookupIP(gomock.Eq("example.com"), dns.IPOption{
+		IPv4Enable: true,
+		IPv6Enable: true,
+		FakeEnable: false,
+	}).Return([]net.IP{{192, 168, 0, 1}}, uint32(600), nil).AnyTimes()
+
+	r := new(Router)
+	common.Must(r.Init(context.TODO(), config, mockDNS, nil, nil))
+
+	ctx := session.ContextWithOutbounds(context.Background(), []*session.Outbound{{
+		Target: net.TCPDestination(net.DomainAddress("example.com"), 80),
+	}})
+	e := r.ExplainRoute(routing_session.AsRoutingContext(ctx))
+	common.Must(e.Err)
+	if e.OutboundTag != "test" || e.RuleTag != "private" {
+		t.Error("expect outbound 'test' by rule 'private', but actually ", e.OutboundTag, " by ", e.RuleTag)
+	}
+	if !e.Resolved || len(e.ResolvedIPs) != 1 {
+		t.Error("expect target domain to be resolved, but actually ", e.Resolved, e.ResolvedIPs)
+	}
+	// Both rules fail before resolving, then the first one is checked again
+	if len(e.Rules) != 4 {
+		t.Fatal("expect 4 rule explanations, but actually ", len(e.Rules))
+	}
+	first := e.Rules[0]
+	if first.Matched || len(first.Conditions) != 2 ||
+		first.Conditions[0] != (ConditionExplanation{Name: "port", Status: ConditionFailed}) ||
+		first.Conditions[1] != (ConditionExplanation{Name: "network", Status: ConditionSkipped}) {
+		t.Error("unexpected explanation of first rule: ", first)
+	}
+	if last := e.Rules[3]; !last.Matched || !last.AfterResolve || last.RuleTag != "private" {
+		t.Error("unexpected explanation of last rule: ", last)
+	}
+}
+
+func TestRuleStats(t *testing.T) {
+	config := &Config{
+		Rule: []*RoutingRule{
+			{
+				RuleTag:   "udp",
+				TargetTag: &RoutingRule_Tag{Tag: "test"},
+				Networks:  []net.Network{net.Network_UDP},
+			},
+			{
+				RuleTag:   "tcp",
+				TargetTag: &RoutingRule_Tag{Tag: "test"},
+				Networks:  []net.Network{net.Network_TCP},
+			},
+		},
+	}
+
+	r := new(Router)
+	common.Must(r.Init(context.TODO(), config, nil, nil, nil))
+
+	ctx := session.ContextWithOutbounds(context.Background(), []*session.Outbound{{
+		Target: net.TCPDestination(net.DomainAddress("example.com"), 80),
+	}})
+	for i := 0; i < 3; i++ {
+		route, err := r.PickRoute(routing_session.AsRoutingContext(ctx))
+		common.Must(err)
+		uplink, downlink := route.(routing.TrafficCountedRoute).GetTrafficCounters()
+		uplink.Add(10)
+		downlink.Add(100)
+	}
+	if _, err := r.TestRoute(routing_session.AsRoutingContext(ctx)); err != nil {
+		t.Error(err)
+	}
+
+	stats := r.GetRuleStats(nil, true)
+	if len(stats) != 2 {
+		t.Fatal("expect stats of 2 rules, but actually ", len(stats))
+	}
+	if stats[0].RuleTag != "udp" || stats[0].Hits != 0 || !stats[0].LastHit.IsZero() {
+		t.Error("unexpected stats of rule 'udp': ", stats[0])
+	}
+	if stats[1].RuleTag != "tcp" || stats[1].Hits != 3 || stats[1].LastHit.IsZero() || stats[1].Uplink != 30 || stats[1].Downlink != 300 {
+		t.Error("unexpected stats of rule 'tcp': ", stats[1])
+	}
+
+	stats = r.GetRuleStats([]string{"tcp"}, false)
+	if len(stats) != 1 || stats[0].Hits != 0 || !stats[0].LastHit.IsZero() || stats[0].Uplink != 0 {
+		t.Error("expect stats of rule 'tcp' to be reset, but actually ", stats)
+	}
+}
+
+func TestRuleStatsKeepPersistedCounters(t *testing.T) {
+	persistPath := filepath.Join(t.TempDir(), "stats.json")
+	common.Must(os.WriteFile(persistPath, []byte(`{"counters":{"rule>>>tcp>>>hits":5,"rule>>>tcp>>>traffic>>>uplink":50}}`), 0o600))
+
+	v, err := core.New(&core.Config{
+		App: []*serial.TypedMessage{
+			serial.ToTypedMessage(&stats.Config{PersistPath: persistPath}),
+			serial.ToTypedMessage(&dispatcher.Config{}),
+			serial.ToTypedMessage(&proxyman.OutboundConfig{}),
+			serial.ToTypedMessage(&Config{
+				Rule: []*RoutingRule{
+					{
+						RuleTag:   "tcp",
+						TargetTag: &RoutingRule_Tag{Tag: "test"},
+						Networks:  []net.Network{net.Network_TCP},
+					},
+				},
+			}),
+		},
+	})
+	common.Must(err)
+	r := v.GetFeature(routing.RouterType()).(*Router)
+
+	ctx := session.ContextWithOutbounds(context.Background(), []*session.Outbound{{
+		Target: net.TCPDestination(net.DomainAddress("example.com"), 80),
+	}})
+	route, err := r.PickRoute(routing_session.AsRoutingContext(ctx))
+	common.Must(err)
+	uplink, _ := route.(routing.TrafficCountedRoute).GetTrafficCounters()
+	uplink.Add(10)
+
+	stats := r.GetRuleStats(nil, false)
+	if len(stats) != 1 || stats[0].Hits != 6 || stats[0].Uplink != 60 {
+		t.Error("expect persisted stats to be kept, but actually ", stats)
+	}
+}
+
+func TestRouterReloadWhileRouting(t *testing.T) {
+	newConfig := func(tag string) *Config {
+		return &Config{
+			DomainStrategy: Config_AsIs,
+			Rule: []*RoutingRule{
+				{
+					TargetTag: &RoutingRule_Tag{Tag: tag},
+					Networks:  []net.Network{net.Network_TCP},
+				},
+			},
+		}
+	}
+
+	r := new(Router)
+	common.Must(r.Init(context.TODO(), newConfig("a"), nil, nil, nil))
+
+	ctx := session.ContextWithOutbounds(context.Background(), []*session.Outbound{{
+		Target: net.TCPDestination(net.DomainAddress("example.com"), 80),
+	}})
+	done := make(chan struct{})
+	errCh := make(chan error, 1)
+	go func() {
+		for {
+			select {
+			case <-done:
+				errCh <- nil
+				return
+			default:
+			}
+			route, err := r.PickRoute(routing_session.AsRoutingContext(ctx))
+			if err != nil {
+				errCh <- err
+				return
+			}
+			if tag := route.GetOutboundTag(); tag != "a" && tag != "b" {
+				errCh <- errors.New("unexpected outbound tag ", tag)
+				return
+			}
+		}
+	}()
+
+	for i := 0; i < 100; i++ {
+		tag := "a"
+		if i%2 == 0 {
+			tag = "b"
+		}
+		common.Must(r.Reload(newConfig(tag)))
+		common.Must(r.AddRule(serial.ToTypedMessage(newConfig(tag)), true))
+		time.Sleep(time.Millisecond)
+	}
+	close(done)
+	if err := <-errCh; err != nil {
+		t.Error(err)
+	}
+}
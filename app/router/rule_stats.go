@@ -0,0 +1,151 @@
+package router
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/xtls/xray-core/app/stats"
+	feature_stats "github.com/xtls/xray-core/features/stats"
+)
+
+// ruleStats is the statistics of a tagged rule.
+type ruleStats struct {
+	hits     feature_stats.Counter
+	uplink   feature_stats.Counter
+	downlink feature_stats.Counter
+	// Unix time in nanoseconds of the last hit, or 0 if never hit.
+	lastHit atomic.Int64
+}
+
+func newRuleStats() *ruleStats {
+	return &ruleStats{
+		hits:     new(stats.Counter),
+		uplink:   new(stats.Counter),
+		downlink: new(stats.Counter),
+	}
+}
+
+func (s *ruleStats) hit() {
+	s.hits.Add(1)
+	s.lastHit.Store(time.Now().UnixNano())
+}
+
+// register replaces the counters with the ones in the stats manager, so that they can be queried with the stats
+// API. Values already in the manager, e.g. restored from the persisted stats, are kept, and the local values are
+// added to them.
+func (s *ruleStats) register(manager feature_stats.Manager, tag string) {
+	replace := func(counter *feature_stats.Counter, name string) {
+		c, _ := feature_stats.GetOrRegisterCounter(manager, "rule>>>"+tag+">>>"+name)
+		if c == nil || c == *counter {
+			return
+		}
+		if v := (*counter).Value(); v != 0 {
+			c.Add(v)
+		}
+		*counter = c
+	}
+	replace(&s.hits, "hits")
+	replace(&s.uplink, "traffic>>>uplink")
+	replace(&s.downlink, "traffic>>>downlink")
+}
+
+// ruleStatsRegistry keeps the statistics of rules by rule tag, so that they survive reloads of rules.
+type ruleStatsRegistry struct {
+	access  sync.Mutex
+	manager feature_stats.Manager
+	stats   map[string]*ruleStats
+}
+
+func newRuleStatsRegistry() *ruleStatsRegistry {
+	return &ruleStatsRegistry{
+		stats: make(map[string]*ruleStats),
+	}
+}
+
+// get returns the statistics of the rule with tag, or nil if the rule has no tag.
+func (r *ruleStatsRegistry) get(tag string) *ruleStats {
+	if tag == "" {
+		return nil
+	}
+	r.access.Lock()
+	defer r.access.Unlock()
+
+	s, found := r.stats[tag]
+	if !found {
+		s = newRuleStats()
+		if r.manager != nil {
+			s.register(r.manager, tag)
+		}
+		r.stats[tag] = s
+	}
+	return s
+}
+
+// setManager registers the counters of all rules in manager. It is called when the features are resolved, before
+// any connection is routed.
+func (r *ruleStatsRegistry) setManager(manager feature_stats.Manager) {
+	r.access.Lock()
+	defer r.access.Unlock()
+
+	r.manager = manager
+	for tag, s := range r.stats {
+		s.register(manager, tag)
+	}
+}
+
+// RuleStat is the statistics of a tagged rule.
+type RuleStat struct {
+	RuleTag     string
+	OutboundTag string
+	BalancerTag string
+	Hits        int64
+	// Zero if never hit.
+	LastHit  time.Time
+	Uplink   int64
+	Downlink int64
+}
+
+// GetRuleStats returns the statistics of the tagged rules in order, or only of the rules in tags if it is not empty.
+// The statistics are cleared after read if reset is true.
+func (r *Router) GetRuleStats(tags []string, reset bool) []*RuleStat {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	wanted := make(map[string]bool, len(tags))
+	for _, tag := range tags {
+		wanted[tag] = true
+	}
+	value := func(c feature_stats.Counter) int64 {
+		if reset {
+			return c.Set(0)
+		}
+		return c.Value()
+	}
+
+	var result []*RuleStat
+	for _, rule := range r.rules {
+		if rule.stats == nil || (len(wanted) > 0 && !wanted[rule.RuleTag]) {
+			continue
+		}
+		stat := &RuleStat{
+			RuleTag:     rule.RuleTag,
+			OutboundTag: rule.Tag,
+			Hits:        value(rule.stats.hits),
+			Uplink:      value(rule.stats.uplink),
+			Downlink:    value(rule.stats.downlink),
+		}
+		if rule.Balancer != nil {
+			stat.BalancerTag = rule.Balancer.tag
+		}
+		lastHit := rule.stats.lastHit.Load()
+		if reset {
+			lastHit = rule.stats.lastHit.Swap(0)
+		}
+		if lastHit != 0 {
+			stat.LastHit = time.Unix(0, lastHit)
+		}
+		result = append(result, stat)
+	}
+	return result
+}
@@ -0,0 +1,216 @@
+package router
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	gonet "net"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/xtls/xray-core/common/errors"
+	"github.com/xtls/xray-core/common/platform"
+	"github.com/xtls/xray-core/common/task"
+	"github.com/xtls/xray-core/features/routing"
+	"google.golang.org/protobuf/proto"
+)
+
+const defaultRuleSetRefreshInterval = 10 * time.Second
+
+// ruleSetMatcher holds the matchers built from one version of a rule set file.
+type ruleSetMatcher struct {
+	domain *DomainMatcher
+	ip     *MultiGeoIPMatcher
+}
+
+// ruleSetProvider loads a rule set file, and rebuilds its matchers when the file changes.
+type ruleSetProvider struct {
+	config *RuleSet
+	path   string
+
+	matcher atomic.Pointer[ruleSetMatcher]
+	modTime time.Time
+	size    int64
+	refresh *task.Periodic
+}
+
+func newRuleSetProvider(config *RuleSet) (*ruleSetProvider, error) {
+	path := config.Path
+	if !filepath.IsAbs(path) {
+		path = platform.GetAssetLocation(path)
+	}
+	p := &ruleSetProvider{
+		config: config,
+		path:   path,
+	}
+	if _, err := p.reload(); err != nil {
+		return nil, errors.New("failed to load rule set ", config.Tag).Base(err)
+	}
+
+	interval := time.Duration(config.RefreshInterval) * time.Second
+	if interval <= 0 {
+		interval = defaultRuleSetRefreshInterval
+	}
+	p.refresh = &task.Periodic{
+		Interval: interval,
+		Execute:  p.checkFile,
+	}
+	return p, nil
+}
+
+// reload rebuilds the matchers if the file has changed since last load.
+func (p *ruleSetProvider) reload() (bool, error) {
+	info, err := os.Stat(p.path)
+	if err != nil {
+		return false, err
+	}
+	if p.matcher.Load() != nil && info.ModTime().Equal(p.modTime) && info.Size() == p.size {
+		return false, nil
+	}
+	data, err := os.ReadFile(p.path)
+	if err != nil {
+		return false, err
+	}
+	content, err := parseRuleSet(data, p.config.Format)
+	if err != nil {
+		return false, err
+	}
+
+	m := &ruleSetMatcher{}
+	if len(content.Domain) > 0 {
+		if m.domain, err = NewMphMatcherGroup(content.Domain); err != nil {
+			return false, err
+		}
+	}
+	if len(content.Cidr) > 0 {
+		if m.ip, err = NewMultiGeoIPMatcher([]*GeoIP{{Cidr: content.Cidr}}, "target"); err != nil {
+			return false, err
+		}
+	}
+	p.matcher.Store(m)
+	p.modTime = info.ModTime()
+	p.size = info.Size()
+	errors.LogInfo(context.Background(), "loaded rule set ", p.config.Tag, " with ", len(content.Domain), " domains and ", len(content.Cidr), " IPs")
+	return true, nil
+}
+
+// checkFile is the periodic task of refreshing the rule set. Failures are logged, and the last loaded version is
+// kept.
+func (p *ruleSetProvider) checkFile() error {
+	if _, err := p.reload(); err != nil {
+		errors.LogWarningInner(context.Background(), err, "failed to refresh rule set ", p.config.Tag)
+	}
+	return nil
+}
+
+func (p *ruleSetProvider) Start() error {
+	return p.refresh.Start()
+}
+
+func (p *ruleSetProvider) Close() error {
+	return p.refresh.Close()
+}
+
+func parseRuleSet(data []byte, format RuleSet_Format) (*RuleSetFile, error) {
+	content := &RuleSetFile{}
+	if format == RuleSet_Protobuf {
+		if err := proto.Unmarshal(data, content); err != nil {
+			return nil, err
+		}
+		return content, nil
+	}
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for line := 1; scanner.Scan(); line++ {
+		entry := strings.TrimSpace(scanner.Text())
+		if entry == "" || strings.HasPrefix(entry, "#") {
+			continue
+		}
+		domain, cidr, err := parseRuleSetEntry(entry)
+		if err != nil {
+			return nil, errors.New("invalid entry at line ", line).Base(err)
+		}
+		if domain != nil {
+			content.Domain = append(content.Domain, domain)
+		} else {
+			content.Cidr = append(content.Cidr, cidr)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return content, nil
+}
+
+var ruleSetDomainTypes = map[string]Domain_Type{
+	"domain":  Domain_Domain,
+	"full":    Domain_Full,
+	"keyword": Domain_Plain,
+	"regexp":  Domain_Regex,
+}
+
+// parseRuleSetEntry parses a line of a text rule set into either a domain or a CIDR.
+func parseRuleSetEntry(entry string) (*Domain, *CIDR, error) {
+	if prefix, value, found := strings.Cut(entry, ":"); found {
+		if domainType, ok := ruleSetDomainTypes[strings.ToLower(prefix)]; ok {
+			// Regular expressions are case sensitive, e.g. \D is not \d.
+			if domainType != Domain_Regex {
+				value = strings.ToLower(value)
+			}
+			return &Domain{Type: domainType, Value: value}, nil, nil
+		}
+		if strings.ToLower(prefix) == "ip" {
+			cidr, err := parseCIDR(value)
+			return nil, cidr, err
+		}
+		// Not a known prefix, e.g. an IPv6 address
+	}
+	if cidr, err := parseCIDR(entry); err == nil {
+		return nil, cidr, nil
+	}
+	return &Domain{Type: Domain_Domain, Value: strings.ToLower(entry)}, nil, nil
+}
+
+// parseCIDR parses an IP or a CIDR.
+func parseCIDR(s string) (*CIDR, error) {
+	if _, ipNet, err := gonet.ParseCIDR(s); err == nil {
+		ones, _ := ipNet.Mask.Size()
+		ip := ipNet.IP
+		if ip4 := ip.To4(); ip4 != nil {
+			ip = ip4
+		}
+		return &CIDR{Ip: ip, Prefix: uint32(ones)}, nil
+	}
+	ip := gonet.ParseIP(s)
+	if ip == nil {
+		return nil, errors.New("invalid IP or CIDR: ", s)
+	}
+	if ip4 := ip.To4(); ip4 != nil {
+		ip = ip4
+	}
+	return &CIDR{Ip: ip, Prefix: uint32(len(ip) * 8)}, nil
+}
+
+// RuleSetMatcher matches the target domain or IP against rule sets.
+type RuleSetMatcher struct {
+	providers []*ruleSetProvider
+}
+
+// Apply implements Condition.
+func (m *RuleSetMatcher) Apply(ctx routing.Context) bool {
+	domain := ctx.GetTargetDomain()
+	for _, p := range m.providers {
+		if matcher := p.matcher.Load(); matcher.domain != nil && len(domain) > 0 && matcher.domain.ApplyDomain(domain) {
+			return true
+		}
+	}
+	for _, p := range m.providers {
+		if matcher := p.matcher.Load(); matcher.ip != nil && matcher.ip.Apply(ctx) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,81 @@
+package router
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/xtls/xray-core/common"
+	"github.com/xtls/xray-core/common/net"
+	"github.com/xtls/xray-core/common/session"
+	routing_session "github.com/xtls/xray-core/features/routing/session"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestParseRuleSet(t *testing.T) {
+	content, err := parseRuleSet([]byte(`
+# comment
+example.com
+full:www.example.org
+keyword:google
+regexp:^ads\.
+REGEXP:^cdn\D+\.Example\.com$
+10.0.0.0/8
+ip:192.168.1.1
+2001:db8::/32
+`), RuleSet_Text)
+	common.Must(err)
+
+	expected := &RuleSetFile{
+		Domain: []*Domain{
+			{Type: Domain_Domain, Value: "example.com"},
+			{Type: Domain_Full, Value: "www.example.org"},
+			{Type: Domain_Plain, Value: "google"},
+			{Type: Domain_Regex, Value: `^ads\.`},
+			{Type: Domain_Regex, Value: `^cdn\D+\.Example\.com$`},
+		},
+		Cidr: []*CIDR{
+			{Ip: []byte{10, 0, 0, 0}, Prefix: 8},
+			{Ip: []byte{192, 168, 1, 1}, Prefix: 32},
+			{Ip: []byte{0x20, 0x01, 0x0d, 0xb8, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0}, Prefix: 32},
+		},
+	}
+	if !proto.Equal(content, expected) {
+		t.Error("unexpected rule set ", content)
+	}
+}
+
+func TestRuleSetRefresh(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "rules.txt")
+	common.Must(os.WriteFile(path, []byte("example.com\n"), 0o600))
+
+	p, err := newRuleSetProvider(&RuleSet{Tag: "test", Path: path})
+	common.Must(err)
+	matcher := &RuleSetMatcher{providers: []*ruleSetProvider{p}}
+
+	ctxFor := func(address net.Address) *routing_session.Context {
+		return &routing_session.Context{Outbound: &session.Outbound{Target: net.TCPDestination(address, 443)}}
+	}
+	if !matcher.Apply(ctxFor(net.DomainAddress("www.example.com"))) {
+		t.Error("expected www.example.com to match")
+	}
+	if matcher.Apply(ctxFor(net.ParseAddress("10.1.2.3"))) {
+		t.Error("expected 10.1.2.3 not to match")
+	}
+
+	common.Must(os.WriteFile(path, []byte("example.org\n10.0.0.0/8\n"), 0o600))
+	common.Must(p.checkFile())
+	if matcher.Apply(ctxFor(net.DomainAddress("www.example.com"))) {
+		t.Error("expected www.example.com not to match after refresh")
+	}
+	if !matcher.Apply(ctxFor(net.ParseAddress("10.1.2.3"))) {
+		t.Error("expected 10.1.2.3 to match after refresh")
+	}
+
+	// A broken file keeps the last version.
+	common.Must(os.WriteFile(path, []byte("ip:invalid\n"), 0o600))
+	common.Must(p.checkFile())
+	if !matcher.Apply(ctxFor(net.DomainAddress("example.org"))) {
+		t.Error("expected example.org to still match")
+	}
+}
@@ -0,0 +1,221 @@
+package router
+
+import (
+	"context"
+	"hash/fnv"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/xtls/xray-core/app/observatory"
+	"github.com/xtls/xray-core/common"
+	"github.com/xtls/xray-core/core"
+	"github.com/xtls/xray-core/features/extension"
+	"github.com/xtls/xray-core/features/routing"
+	"golang.org/x/net/publicsuffix"
+)
+
+const defaultVirtualNodes = 100
+
+// maxHashRings is the number of candidate sets whose rings are cached, e.g. the alive outbounds of failover groups
+// as their health changes.
+const maxHashRings = 16
+
+// ConsistentHashStrategy picks outbounds by hashing a key of the connection on a consistent hash ring, so that
+// connections with the same key stick to the same outbound, and adding or removing an outbound only remaps a small
+// fraction of keys. Outbounds marked dead by the observatory are skipped.
+type ConsistentHashStrategy struct {
+	settings *StrategyConsistentHashConfig
+
+	ctx         context.Context
+	observatory extension.Observatory
+
+	access sync.Mutex
+	rings  map[string]*hashRing
+}
+
+func NewConsistentHashStrategy(settings *StrategyConsistentHashConfig) *ConsistentHashStrategy {
+	return &ConsistentHashStrategy{
+		settings: settings,
+	}
+}
+
+func (s *ConsistentHashStrategy) InjectContext(ctx context.Context) {
+	s.ctx = ctx
+	common.Must(core.OptionalFeatures(s.ctx, func(observatory extension.Observatory) error {
+		s.observatory = observatory
+		return nil
+	}))
+}
+
+func (s *ConsistentHashStrategy) GetPrincipleTarget(strings []string) []string {
+	return strings
+}
+
+// PickOutbound implements BalancingStrategy. Without a routing context, the key is empty.
+func (s *ConsistentHashStrategy) PickOutbound(candidates []string) string {
+	return s.PickOutboundByContext(nil, candidates)
+}
+
+// PickOutboundByContext implements ContextBalancingStrategy.
+func (s *ConsistentHashStrategy) PickOutboundByContext(ctx routing.Context, candidates []string) string {
+	if len(candidates) == 0 {
+		return ""
+	}
+	alive := s.aliveTags()
+	return s.getRing(candidates).pick(s.hashKey(ctx), func(tag string) bool {
+		if alive == nil {
+			return true
+		}
+		isAlive, found := alive[tag]
+		// unfound candidate is considered alive
+		return !found || isAlive
+	})
+}
+
+// aliveTags returns the health of the outbounds in the last observation, or nil if there is none.
+func (s *ConsistentHashStrategy) aliveTags() map[string]bool {
+	if s.observatory == nil {
+		return nil
+	}
+	observeReport, err := s.observatory.GetObservation(s.ctx)
+	if err != nil {
+		return nil
+	}
+	result, ok := observeReport.(*observatory.ObservationResult)
+	if !ok {
+		return nil
+	}
+	alive := make(map[string]bool, len(result.Status))
+	for _, status := range result.Status {
+		alive[status.OutboundTag] = status.Alive
+	}
+	return alive
+}
+
+func (s *ConsistentHashStrategy) hashKey(ctx routing.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	sourceIP := func() string {
+		if ips := ctx.GetSourceIPs(); len(ips) > 0 {
+			return ips[0].String()
+		}
+		return ""
+	}
+	targetIP := func() string {
+		if ips := ctx.GetTargetIPs(); len(ips) > 0 {
+			return ips[0].String()
+		}
+		return ""
+	}
+
+	switch s.settings.GetKey() {
+	case StrategyConsistentHashConfig_Email:
+		if user := ctx.GetUser(); user != "" {
+			return user
+		}
+		return sourceIP()
+	case StrategyConsistentHashConfig_Domain:
+		if domain := ctx.GetTargetDomain(); domain != "" {
+			return strings.ToLower(domain)
+		}
+		return targetIP()
+	case StrategyConsistentHashConfig_RegistrableDomain:
+		if domain := ctx.GetTargetDomain(); domain != "" {
+			domain = strings.ToLower(domain)
+			if etld1, err := publicsuffix.EffectiveTLDPlusOne(domain); err == nil {
+				return etld1
+			}
+			return domain
+		}
+		return targetIP()
+	default:
+		return sourceIP()
+	}
+}
+
+// getRing returns the hash ring of the candidates. Rings are cached by the set of candidates, in whatever order they
+// come, so that switching between sets does not rebuild them.
+func (s *ConsistentHashStrategy) getRing(candidates []string) *hashRing {
+	tags := append([]string(nil), candidates...)
+	sort.Strings(tags)
+	key := strings.Join(tags, "\x00")
+
+	s.access.Lock()
+	defer s.access.Unlock()
+
+	if r, found := s.rings[key]; found {
+		return r
+	}
+	if s.rings == nil || len(s.rings) >= maxHashRings {
+		s.rings = make(map[string]*hashRing)
+	}
+	virtualNodes := int(s.settings.GetVirtualNodes())
+	if virtualNodes <= 0 {
+		virtualNodes = defaultVirtualNodes
+	}
+	r := newHashRing(tags, virtualNodes)
+	s.rings[key] = r
+	return r
+}
+
+type hashRingPoint struct {
+	hash uint64
+	tag  string
+}
+
+// hashRing is an immutable consistent hash ring.
+type hashRing struct {
+	tags   []string
+	points []hashRingPoint
+}
+
+func newHashRing(tags []string, virtualNodes int) *hashRing {
+	r := &hashRing{
+		tags:   tags,
+		points: make([]hashRingPoint, 0, len(tags)*virtualNodes),
+	}
+	for _, tag := range tags {
+		for i := 0; i < virtualNodes; i++ {
+			r.points = append(r.points, hashRingPoint{
+				hash: hashString(tag + "#" + strconv.Itoa(i)),
+				tag:  tag,
+			})
+		}
+	}
+	sort.Slice(r.points, func(i, j int) bool {
+		return r.points[i].hash < r.points[j].hash
+	})
+	return r
+}
+
+// pick returns the first node clockwise from the hash of key which is usable, or "" if none is.
+func (r *hashRing) pick(key string, usable func(string) bool) string {
+	if len(r.points) == 0 {
+		return ""
+	}
+	h := hashString(key)
+	start := sort.Search(len(r.points), func(i int) bool {
+		return r.points[i].hash >= h
+	})
+	tried := make(map[string]bool, len(r.tags))
+	for i := 0; i < len(r.points) && len(tried) < len(r.tags); i++ {
+		tag := r.points[(start+i)%len(r.points)].tag
+		if tried[tag] {
+			continue
+		}
+		if usable(tag) {
+			return tag
+		}
+		tried[tag] = true
+	}
+	return ""
+}
+
+func hashString(s string) uint64 {
+	h := fnv.New64a()
+	h.Write([]byte(s))
+	return h.Sum64()
+}
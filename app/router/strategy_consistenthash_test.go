@@ -0,0 +1,78 @@
+package router
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	"github.com/xtls/xray-core/common/net"
+	"github.com/xtls/xray-core/common/session"
+	routing_session "github.com/xtls/xray-core/features/routing/session"
+)
+
+func TestConsistentHashStrategy(t *testing.T) {
+	strategy := NewConsistentHashStrategy(&StrategyConsistentHashConfig{
+		Key: StrategyConsistentHashConfig_RegistrableDomain,
+	})
+	candidates := []string{"a", "b", "c", "d"}
+
+	pick := func(domain string, candidates []string) string {
+		ctx := session.ContextWithOutbounds(context.Background(), []*session.Outbound{{
+			Target: net.TCPDestination(net.DomainAddress(domain), 443),
+		}})
+		return strategy.PickOutboundByContext(routing_session.AsRoutingContext(ctx), candidates)
+	}
+
+	if tag1, tag2 := pick("www.example.com", candidates), pick("api.example.com", candidates); tag1 != tag2 {
+		t.Error("expected the same outbound for the same registrable domain, but got ", tag1, " and ", tag2)
+	}
+
+	before := make(map[string]string)
+	for i := 0; i < 1000; i++ {
+		domain := "site" + strconv.Itoa(i) + ".com"
+		before[domain] = pick(domain, candidates)
+	}
+	remapped := 0
+	for domain, tag := range before {
+		after := pick(domain, candidates[:3])
+		if tag != "d" && after != tag {
+			t.Fatal("expected ", domain, " to stay on ", tag, ", but moved to ", after)
+		}
+		if tag != after {
+			remapped++
+		}
+	}
+	if remapped == 0 || remapped > 400 {
+		t.Error("expected about a quarter of keys to be remapped, but actually ", remapped)
+	}
+}
+
+func TestHashRingSkipsUnusable(t *testing.T) {
+	ring := newHashRing([]string{"a", "b", "c"}, defaultVirtualNodes)
+	for i := 0; i < 100; i++ {
+		key := strconv.Itoa(i)
+		tag := ring.pick(key, func(string) bool { return true })
+		next := ring.pick(key, func(t string) bool { return t != tag })
+		if next == "" || next == tag {
+			t.Fatal("expected another outbound than ", tag, ", but actually ", next)
+		}
+	}
+	if tag := ring.pick("x", func(string) bool { return false }); tag != "" {
+		t.Error("expected no outbound, but actually ", tag)
+	}
+}
+
+func TestConsistentHashRingCache(t *testing.T) {
+	strategy := NewConsistentHashStrategy(&StrategyConsistentHashConfig{})
+	ring := strategy.getRing([]string{"a", "b", "c"})
+	strategy.getRing([]string{"a", "b"})
+	if r := strategy.getRing([]string{"c", "a", "b"}); r != ring {
+		t.Error("expected the ring of the same candidates to be cached")
+	}
+	for i := 0; i < maxHashRings; i++ {
+		strategy.getRing([]string{strconv.Itoa(i)})
+	}
+	if len(strategy.rings) > maxHashRings {
+		t.Error("expected at most ", maxHashRings, " rings cached, but actually ", len(strategy.rings))
+	}
+}
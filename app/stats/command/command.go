@@ -134,6 +134,94 @@ func (s *statsServer) GetSysStats(ctx context.Context, request *SysStatsRequest)
 	return response, nil
 }
 
+func (s *statsServer) SubscribeStats(request *SubscribeStatsRequest, stream grpc.ServerStreamingServer[SubscribeStatsResponse]) error {
+	matcher, err := strmatcher.Substr.New(request.Pattern)
+	if err != nil {
+		return err
+	}
+
+	manager, ok := s.stats.(*stats.Manager)
+	if !ok {
+		return errors.New("SubscribeStats only works its own stats.Manager.")
+	}
+
+	interval := time.Second
+	if request.Interval > 0 {
+		interval = time.Duration(request.Interval) * time.Second
+	}
+
+	var events chan interface{}
+	if c := manager.GetChannel(stats.OnlineEventChannel); c != nil {
+		sub, err := feature_stats.SubscribeRunnableChannel(c)
+		if err != nil {
+			return err
+		}
+		defer feature_stats.UnsubscribeClosableChannel(c, sub)
+		events = sub
+	}
+
+	response := &SubscribeStatsResponse{}
+	manager.VisitOnlineMaps(func(name string, om feature_stats.OnlineMap) bool {
+		if matcher.Match(name) && om.Count() > 0 {
+			response.Online = append(response.Online, &OnlineEvent{
+				Name:   name,
+				Online: true,
+			})
+		}
+		return true
+	})
+
+	last := make(map[string]int64)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for first := true; ; first = false {
+		manager.VisitCounters(func(name string, c feature_stats.Counter) bool {
+			if !matcher.Match(name) {
+				return true
+			}
+			value := c.Value()
+			previous, found := last[name]
+			delta := value - previous
+			if value < previous {
+				// The counter was reset in the meantime.
+				delta = value
+			}
+			last[name] = value
+			if !found || delta != 0 {
+				response.Delta = append(response.Delta, &Stat{
+					Name:  name,
+					Value: delta,
+				})
+			}
+			return true
+		})
+		if first || len(response.Delta) > 0 || len(response.Online) > 0 {
+			response.Timestamp = time.Now().UnixMilli()
+			if err := stream.Send(response); err != nil {
+				return err
+			}
+			response = &SubscribeStatsResponse{}
+		}
+
+	wait:
+		for {
+			select {
+			case <-stream.Context().Done():
+				return nil
+			case <-ticker.C:
+				break wait
+			case msg := <-events:
+				if e, ok := msg.(*stats.OnlineEvent); ok && matcher.Match(e.Name) {
+					response.Online = append(response.Online, &OnlineEvent{
+						Name:   e.Name,
+						Online: e.Online,
+					})
+				}
+			}
+		}
+	}
+}
+
 func (s *statsServer) mustEmbedUnimplementedStatsServiceServer() {}
 
 type service struct {
@@ -3,12 +3,14 @@ package command_test
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/xtls/xray-core/app/stats"
 	. "github.com/xtls/xray-core/app/stats/command"
 	"github.com/xtls/xray-core/common"
+	"google.golang.org/grpc"
 )
 
 func TestGetStats(t *testing.T) {
@@ -89,3 +91,57 @@ func TestQueryStats(t *testing.T) {
 		t.Error(r)
 	}
 }
+
+type subscribeStatsStream struct {
+	grpc.ServerStream
+	ctx       context.Context
+	responses chan *SubscribeStatsResponse
+}
+
+func (s *subscribeStatsStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *subscribeStatsStream) Send(response *SubscribeStatsResponse) error {
+	s.responses <- response
+	return nil
+}
+
+func TestSubscribeStats(t *testing.T) {
+	m, err := stats.NewManager(context.Background(), &stats.Config{})
+	common.Must(err)
+	common.Must(m.Start())
+	defer m.Close()
+
+	sc1, err := m.RegisterCounter("test_counter")
+	common.Must(err)
+	sc1.Set(1)
+	sc2, err := m.RegisterCounter("other_counter")
+	common.Must(err)
+	sc2.Set(2)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	stream := &subscribeStatsStream{
+		ctx:       ctx,
+		responses: make(chan *SubscribeStatsResponse, 4),
+	}
+	s := NewStatsServer(m)
+	go s.SubscribeStats(&SubscribeStatsRequest{Pattern: "test"}, stream)
+
+	resp := <-stream.responses
+	if r := cmp.Diff(resp.Delta, []*Stat{{Name: "test_counter", Value: 1}}, cmpopts.IgnoreUnexported(Stat{})); r != "" {
+		t.Error(r)
+	}
+
+	sc1.Add(5)
+	sc2.Add(5)
+	select {
+	case resp := <-stream.responses:
+		if r := cmp.Diff(resp.Delta, []*Stat{{Name: "test_counter", Value: 5}}, cmpopts.IgnoreUnexported(Stat{})); r != "" {
+			t.Error(r)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("no update received")
+	}
+}
@@ -0,0 +1,69 @@
+package stats
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+
+	"github.com/xtls/xray-core/common/errors"
+	"github.com/xtls/xray-core/common/platform/filesystem"
+)
+
+type persistedStats struct {
+	Counters map[string]int64 `json:"counters"`
+}
+
+// loadCounters registers the counters saved in the persist file with their saved values.
+func (m *Manager) loadCounters() error {
+	data, err := os.ReadFile(m.persistPath)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	var saved persistedStats
+	if err := json.Unmarshal(data, &saved); err != nil {
+		return err
+	}
+
+	m.access.Lock()
+	defer m.access.Unlock()
+	for name, value := range saved.Counters {
+		c := new(Counter)
+		c.Set(value)
+		m.counters[name] = c
+	}
+	errors.LogInfo(context.Background(), "restored ", len(saved.Counters), " counters from ", m.persistPath)
+	return nil
+}
+
+// saveCounters writes the values of all counters to the persist file.
+func (m *Manager) saveCounters() error {
+	saved := persistedStats{
+		Counters: make(map[string]int64),
+	}
+	m.access.RLock()
+	for name, c := range m.counters {
+		saved.Counters[name] = c.Value()
+	}
+	m.access.RUnlock()
+
+	data, err := json.Marshal(&saved)
+	if err != nil {
+		return err
+	}
+	if err := filesystem.WriteFileAtomic(m.persistPath, data); err != nil {
+		return errors.New("failed to save counters").Base(err)
+	}
+	return nil
+}
+
+// persistCounters saves counters on every tick of the persister. An error is only logged, because returning it
+// would stop the task and leave counters unsaved until shutdown.
+func (m *Manager) persistCounters() error {
+	if err := m.saveCounters(); err != nil {
+		errors.LogWarningInner(context.Background(), err, "failed to persist stats to ", m.persistPath)
+	}
+	return nil
+}
@@ -3,12 +3,24 @@ package stats
 import (
 	"context"
 	"sync"
+	"time"
 
 	"github.com/xtls/xray-core/common"
 	"github.com/xtls/xray-core/common/errors"
+	"github.com/xtls/xray-core/common/task"
 	"github.com/xtls/xray-core/features/stats"
 )
 
+// OnlineEventChannel is the name of the channel publishing an OnlineEvent whenever an online map becomes
+// non-empty or empty, i.e. a user comes online or goes offline.
+const OnlineEventChannel = "stats>>>online"
+
+// OnlineEvent is published on OnlineEventChannel.
+type OnlineEvent struct {
+	Name   string
+	Online bool
+}
+
 // Manager is an implementation of stats.Manager.
 type Manager struct {
 	access    sync.RWMutex
@@ -16,6 +28,12 @@ type Manager struct {
 	onlineMap map[string]*OnlineMap
 	channels  map[string]*Channel
 	running   bool
+
+	onlineWatcher *task.Periodic
+	onlineState   map[string]bool
+
+	persistPath string
+	persister   *task.Periodic
 }
 
 // NewManager creates an instance of Statistics Manager.
@@ -25,6 +43,25 @@ func NewManager(ctx context.Context, config *Config) (*Manager, error) {
 		onlineMap: make(map[string]*OnlineMap),
 		channels:  make(map[string]*Channel),
 	}
+	m.onlineWatcher = &task.Periodic{
+		Interval: time.Second,
+		Execute:  m.watchOnlineMaps,
+	}
+
+	if len(config.PersistPath) > 0 {
+		m.persistPath = config.PersistPath
+		if err := m.loadCounters(); err != nil {
+			return nil, errors.New("failed to load stats from ", m.persistPath).Base(err)
+		}
+		interval := time.Minute
+		if config.PersistInterval > 0 {
+			interval = time.Duration(config.PersistInterval) * time.Second
+		}
+		m.persister = &task.Periodic{
+			Interval: interval,
+			Execute:  m.persistCounters,
+		}
+	}
 
 	return m, nil
 }
@@ -83,6 +120,18 @@ func (m *Manager) VisitCounters(visitor func(string, stats.Counter) bool) {
 	}
 }
 
+// VisitOnlineMaps calls visitor function on all managed online maps.
+func (m *Manager) VisitOnlineMaps(visitor func(string, stats.OnlineMap) bool) {
+	m.access.RLock()
+	defer m.access.RUnlock()
+
+	for name, om := range m.onlineMap {
+		if !visitor(name, om) {
+			break
+		}
+	}
+}
+
 // RegisterOnlineMap implements stats.Manager.
 func (m *Manager) RegisterOnlineMap(name string) (stats.OnlineMap, error) {
 	m.access.Lock()
@@ -161,17 +210,68 @@ func (m *Manager) GetChannel(name string) stats.Channel {
 	return nil
 }
 
+// watchOnlineMaps publishes an OnlineEvent for each online map that became non-empty or empty since the last call.
+func (m *Manager) watchOnlineMaps() error {
+	m.access.RLock()
+	channel := m.channels[OnlineEventChannel]
+	maps := make(map[string]*OnlineMap, len(m.onlineMap))
+	for name, om := range m.onlineMap {
+		maps[name] = om
+	}
+	m.access.RUnlock()
+
+	if m.onlineState == nil {
+		m.onlineState = make(map[string]bool)
+	}
+	for name, om := range maps {
+		om.RemoveExpiredIPs()
+		online := om.Count() > 0
+		if online == m.onlineState[name] {
+			continue
+		}
+		if online {
+			m.onlineState[name] = true
+		} else {
+			delete(m.onlineState, name)
+		}
+		if channel != nil {
+			channel.Publish(context.Background(), &OnlineEvent{Name: name, Online: online})
+		}
+	}
+	for name := range m.onlineState {
+		if _, found := maps[name]; !found {
+			delete(m.onlineState, name)
+			if channel != nil {
+				channel.Publish(context.Background(), &OnlineEvent{Name: name, Online: false})
+			}
+		}
+	}
+	return nil
+}
+
 // Start implements common.Runnable.
 func (m *Manager) Start() error {
 	m.access.Lock()
-	defer m.access.Unlock()
 	m.running = true
+	if _, found := m.channels[OnlineEventChannel]; !found {
+		m.channels[OnlineEventChannel] = NewChannel(&ChannelConfig{BufferSize: 64, Blocking: false})
+	}
 	errs := []error{}
 	for _, channel := range m.channels {
 		if err := channel.Start(); err != nil {
 			errs = append(errs, err)
 		}
 	}
+	m.access.Unlock()
+
+	if err := m.onlineWatcher.Start(); err != nil {
+		errs = append(errs, err)
+	}
+	if m.persister != nil {
+		if err := m.persister.Start(); err != nil {
+			errs = append(errs, err)
+		}
+	}
 	if len(errs) != 0 {
 		return errors.Combine(errs...)
 	}
@@ -180,6 +280,14 @@ func (m *Manager) Start() error {
 
 // Close implement common.Closable.
 func (m *Manager) Close() error {
+	m.onlineWatcher.Close()
+	if m.persister != nil {
+		m.persister.Close()
+		if err := m.saveCounters(); err != nil {
+			errors.LogWarningInner(context.Background(), err, "failed to persist stats to ", m.persistPath)
+		}
+	}
+
 	m.access.Lock()
 	defer m.access.Unlock()
 	m.running = false
@@ -2,6 +2,7 @@ package stats_test
 
 import (
 	"context"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -84,3 +85,24 @@ func TestStatsChannelRunnable(t *testing.T) {
 		t.Fatalf("unexpected running channel: test.channel.%d", 3)
 	}
 }
+
+func TestPersistCounters(t *testing.T) {
+	config := &Config{
+		PersistPath: filepath.Join(t.TempDir(), "stats.json"),
+	}
+	m, err := NewManager(context.Background(), config)
+	common.Must(err)
+	common.Must(m.Start())
+
+	c, err := m.RegisterCounter("user>>>test>>>traffic>>>uplink")
+	common.Must(err)
+	c.Add(100)
+	common.Must(m.Close())
+
+	m, err = NewManager(context.Background(), config)
+	common.Must(err)
+	c = m.GetCounter("user>>>test>>>traffic>>>uplink")
+	if c == nil || c.Value() != 100 {
+		t.Fatal("expect counter to be restored with value 100, but got ", c)
+	}
+}
@@ -1,6 +1,7 @@
 package buf
 
 import (
+	"github.com/xtls/xray-core/common"
 	"github.com/xtls/xray-core/common/net"
 )
 
@@ -36,3 +37,15 @@ func (w *EndpointOverrideWriter) WriteMultiBuffer(mb MultiBuffer) error {
 	}
 	return w.Writer.WriteMultiBuffer(mb)
 }
+
+func (r *EndpointOverrideReader) Interrupt() {
+	common.Interrupt(r.Reader)
+}
+
+func (w *EndpointOverrideWriter) Close() error {
+	return common.Close(w.Writer)
+}
+
+func (w *EndpointOverrideWriter) Interrupt() {
+	common.Interrupt(w.Writer)
+}
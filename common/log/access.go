@@ -2,6 +2,7 @@ package log
 
 import (
 	"context"
+	"strconv"
 	"strings"
 
 	"github.com/xtls/xray-core/common/serial"
@@ -18,6 +19,7 @@ type AccessStatus string
 const (
 	AccessAccepted = AccessStatus("accepted")
 	AccessRejected = AccessStatus("rejected")
+	AccessClosed   = AccessStatus("closed")
 )
 
 type AccessMessage struct {
@@ -27,6 +29,13 @@ type AccessMessage struct {
 	Reason interface{}
 	Email  string
 	Detour string
+
+	// Structured fields, filled in by the dispatcher.
+	InboundTag  string
+	OutboundTag string
+	Domain      string // Sniffed domain, if any
+	Uplink      int64  // Bytes sent to the outbound, only set when the connection is closed
+	Downlink    int64  // Bytes received from the outbound, only set when the connection is closed
 }
 
 func (m *AccessMessage) String() string {
@@ -55,6 +64,13 @@ func (m *AccessMessage) String() string {
 		builder.WriteString(m.Email)
 	}
 
+	if m.Status == AccessClosed {
+		builder.WriteString(" uplink: ")
+		builder.WriteString(strconv.FormatInt(m.Uplink, 10))
+		builder.WriteString(" downlink: ")
+		builder.WriteString(strconv.FormatInt(m.Downlink, 10))
+	}
+
 	return builder.String()
 }
 
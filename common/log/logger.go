@@ -142,11 +142,23 @@ func (w *fileLogWriter) Close() error {
 	return w.file.Close()
 }
 
+const defaultLogFlags = log.Ldate | log.Ltime | log.Lmicroseconds
+
 // CreateStdoutLogWriter returns a LogWriterCreator that creates LogWriter for stdout.
 func CreateStdoutLogWriter() WriterCreator {
+	return createStdoutLogWriter(defaultLogFlags)
+}
+
+// CreatePlainStdoutLogWriter returns a LogWriterCreator that creates LogWriter for stdout,
+// which writes messages without the timestamp prefix.
+func CreatePlainStdoutLogWriter() WriterCreator {
+	return createStdoutLogWriter(0)
+}
+
+func createStdoutLogWriter(flags int) WriterCreator {
 	return func() Writer {
 		return &consoleLogWriter{
-			logger: log.New(os.Stdout, "", log.Ldate|log.Ltime|log.Lmicroseconds),
+			logger: log.New(os.Stdout, "", flags),
 		}
 	}
 }
@@ -155,7 +167,7 @@ func CreateStdoutLogWriter() WriterCreator {
 func CreateStderrLogWriter() WriterCreator {
 	return func() Writer {
 		return &consoleLogWriter{
-			logger: log.New(os.Stderr, "", log.Ldate|log.Ltime|log.Lmicroseconds),
+			logger: log.New(os.Stderr, "", defaultLogFlags),
 		}
 	}
 }
@@ -174,7 +186,7 @@ func CreateFileLogWriter(path string) (WriterCreator, error) {
 		}
 		return &fileLogWriter{
 			file:   file,
-			logger: log.New(file, "", log.Ldate|log.Ltime|log.Lmicroseconds),
+			logger: log.New(file, "", defaultLogFlags),
 		}
 	}, nil
 }
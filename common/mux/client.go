@@ -312,7 +312,7 @@ func (m *ClientWorker) Dispatch(ctx context.Context, link *transport.Link) bool
 	}
 	s.input = link.Reader
 	s.output = link.Writer
-	if _, ok := link.Reader.(*pipe.Reader); ok {
+	if _, ok := pipe.UnwrapReader(link.Reader); ok {
 		go fetchInput(ctx, s, m.link.Writer, m.timer)
 	} else {
 		fetchInput(ctx, s, m.link.Writer, m.timer)
@@ -6,6 +6,8 @@ import (
 	"time"
 
 	"github.com/golang/mock/gomock"
+	"github.com/xtls/xray-core/app/dispatcher"
+	"github.com/xtls/xray-core/app/stats"
 	"github.com/xtls/xray-core/common"
 	"github.com/xtls/xray-core/common/errors"
 	"github.com/xtls/xray-core/common/mux"
@@ -114,3 +116,34 @@ func TestClientWorkerClose(t *testing.T) {
 
 	common.Must(w2.Close())
 }
+
+func TestClientWorkerDispatchWrappedPipe(t *testing.T) {
+	r, w := pipe.New(pipe.WithoutSizeLimit())
+	worker, err := mux.NewClientWorker(transport.Link{Reader: r, Writer: w}, mux.ClientStrategy{})
+	common.Must(err)
+	defer w.Close()
+
+	tr, tw := pipe.New(pipe.WithoutSizeLimit())
+	defer tw.Close()
+	ctx := session.ContextWithOutbounds(context.Background(), []*session.Outbound{{
+		Target: net.TCPDestination(net.DomainAddress("www.example.com"), 80),
+	}})
+	link := &transport.Link{
+		// e.g. wrapped by the dispatcher to count the traffic of a connection
+		Reader: &dispatcher.SizeStatReader{Counter: new(stats.Counter), Reader: tr},
+		Writer: tw,
+	}
+
+	dispatched := make(chan bool)
+	go func() {
+		dispatched <- worker.Dispatch(ctx, link)
+	}()
+	select {
+	case ok := <-dispatched:
+		if !ok {
+			t.Error("failed to dispatch")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("dispatching is blocked by reading the input")
+	}
+}
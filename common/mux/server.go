@@ -90,7 +90,7 @@ func NewServerWorker(ctx context.Context, d routing.Dispatcher, link *transport.
 	if inbound := session.InboundFromContext(ctx); inbound != nil {
 		inbound.CanSpliceCopy = 3
 	}
-	if _, ok := link.Reader.(*pipe.Reader); ok {
+	if _, ok := pipe.UnwrapReader(link.Reader); ok {
 		go worker.run(ctx)
 	} else {
 		worker.run(ctx)
@@ -176,11 +176,12 @@ func (s *Session) Close(locked bool) error {
 		common.Close(s.output)
 	} else {
 		// Stop existing handle(), then trigger writer.Close().
-		// Note that s.output may be dispatcher.SizeStatWriter.
-		s.input.(*pipe.Reader).ReturnAnError(io.EOF)
+		// Note that s.output may be dispatcher.SizeStatWriter, and s.input may wrap the pipe as well.
+		input, _ := pipe.UnwrapReader(s.input)
+		input.ReturnAnError(io.EOF)
 		runtime.Gosched()
 		// If the error set by ReturnAnError still exists, clear it.
-		s.input.(*pipe.Reader).Recover()
+		input.Recover()
 		XUDPManager.Lock()
 		if s.XUDP.Status == Active {
 			s.XUDP.Expire = time.Now().Add(time.Minute)
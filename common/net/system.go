@@ -21,6 +21,8 @@ var (
 
 var LookupIP = net.LookupIP
 
+var InterfaceAddrs = net.InterfaceAddrs
+
 var FileConn = net.FileConn
 
 // ParseIP is an alias of net.ParseIP
@@ -0,0 +1,79 @@
+// Package process finds the local process owning a socket, for routing by process.
+package process
+
+import (
+	"sync"
+	"time"
+
+	"github.com/xtls/xray-core/common/errors"
+	"github.com/xtls/xray-core/common/net"
+)
+
+// Info is the information of a local process.
+type Info struct {
+	// PID is 0 if the socket is found but its process is not, e.g. the process is in another PID namespace. Only
+	// UID is valid then.
+	PID  int
+	Name string
+	// Path is the executable of the process. It is empty if it cannot be read, e.g. without the permission.
+	Path string
+	UID  uint32
+	GID  uint32
+}
+
+var ErrNotFound = errors.New("process not found")
+
+// FindProcess returns the local process owning the socket bound to source, which is the source of a connection
+// from this host. Sources of other hosts are not looked up.
+func FindProcess(network net.Network, source net.Destination) (*Info, error) {
+	if !source.IsValid() || !source.Address.Family().IsIP() || !isLocalIP(source.Address.IP()) {
+		return nil, ErrNotFound
+	}
+	return findProcess(network, source.Address.IP(), source.Port)
+}
+
+// localAddrsTTL is how long the addresses of this host are cached. They rarely change, while connections are looked
+// up often.
+const localAddrsTTL = 10 * time.Second
+
+var localAddrs struct {
+	sync.Mutex
+	ips    []net.IP
+	expire time.Time
+}
+
+// isLocalIP returns whether ip is an address of this host. A socket bound to the unspecified address matches any IP,
+// so a connection from another host would be attributed to the local process using the same port otherwise.
+func isLocalIP(ip net.IP) bool {
+	if ip.IsLoopback() {
+		return true
+	}
+	for _, local := range localIPs() {
+		if local.Equal(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+func localIPs() []net.IP {
+	localAddrs.Lock()
+	defer localAddrs.Unlock()
+
+	if now := time.Now(); now.After(localAddrs.expire) {
+		addrs, err := net.InterfaceAddrs()
+		if err != nil {
+			return nil
+		}
+		// A new slice, as callers may still hold the old one.
+		ips := make([]net.IP, 0, len(addrs))
+		for _, addr := range addrs {
+			if ipNet, ok := addr.(*net.IPNet); ok {
+				ips = append(ips, ipNet.IP)
+			}
+		}
+		localAddrs.ips = ips
+		localAddrs.expire = now.Add(localAddrsTTL)
+	}
+	return localAddrs.ips
+}
@@ -0,0 +1,235 @@
+package process
+
+import (
+	"bufio"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/xtls/xray-core/common/errors"
+	"github.com/xtls/xray-core/common/net"
+)
+
+// tcpListen is the state of listening sockets in /proc/net/tcp.
+const tcpListen = "0A"
+
+func findProcess(network net.Network, ip net.IP, port net.Port) (*Info, error) {
+	var tables []string
+	switch network {
+	case net.Network_TCP:
+		tables = []string{"/proc/net/tcp", "/proc/net/tcp6"}
+	case net.Network_UDP:
+		tables = []string{"/proc/net/udp", "/proc/net/udp6"}
+	default:
+		return nil, ErrNotFound
+	}
+
+	for _, table := range tables {
+		inode, uid, found, err := findSocket(table, network, ip, port)
+		if err != nil {
+			return nil, errors.New("failed to read ", table).Base(err)
+		}
+		if !found {
+			continue
+		}
+		info := &Info{UID: uid}
+		if pid := findSocketOwner(inode); pid != 0 {
+			readProcess(pid, info)
+		}
+		return info, nil
+	}
+	return nil, ErrNotFound
+}
+
+// findSocket looks up the socket bound to ip:port in the table of /proc/net, and returns its inode and owner.
+// Unconnected UDP sockets bound to the unspecified address also match.
+func findSocket(table string, network net.Network, ip net.IP, port net.Port) (inode string, uid uint32, found bool, err error) {
+	file, err := os.Open(table)
+	if err != nil {
+		return "", 0, false, err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	scanner.Scan() // header
+	for scanner.Scan() {
+		// sl local_address rem_address st tx_queue:rx_queue tr:tm->when retrnsmt uid timeout inode ...
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 10 {
+			continue
+		}
+		if network == net.Network_TCP && fields[3] == tcpListen {
+			continue
+		}
+		localIP, localPort, err := parseSocketAddress(fields[1])
+		if err != nil || localPort != port {
+			continue
+		}
+		if !localIP.Equal(ip) && !(network == net.Network_UDP && localIP.IsUnspecified()) {
+			continue
+		}
+		if fields[9] == "0" { // e.g. TIME_WAIT
+			continue
+		}
+		id, err := strconv.ParseUint(fields[7], 10, 32)
+		if err != nil {
+			continue
+		}
+		return fields[9], uint32(id), true, nil
+	}
+	return "", 0, false, scanner.Err()
+}
+
+// parseSocketAddress parses an address of /proc/net tables, e.g. "0100007F:1F90". The IP is in words of host byte
+// order, which is little endian on all platforms Xray runs on.
+func parseSocketAddress(s string) (net.IP, net.Port, error) {
+	host, port, found := strings.Cut(s, ":")
+	if !found {
+		return nil, 0, errors.New("invalid socket address: ", s)
+	}
+	ip, err := hex.DecodeString(host)
+	if err != nil || (len(ip) != net.IPv4len && len(ip) != net.IPv6len) {
+		return nil, 0, errors.New("invalid socket address: ", s)
+	}
+	for i := 0; i < len(ip); i += 4 {
+		ip[i], ip[i+1], ip[i+2], ip[i+3] = ip[i+3], ip[i+2], ip[i+1], ip[i]
+	}
+	p, err := strconv.ParseUint(port, 16, 16)
+	if err != nil {
+		return nil, 0, errors.New("invalid socket address: ", s).Base(err)
+	}
+	return net.IP(ip), net.Port(p), nil
+}
+
+// socketOwnersTTL is how long the owners of sockets seen while scanning /proc are remembered.
+const socketOwnersTTL = 10 * time.Second
+
+// maxRecentOwners is the number of processes remembered as owners of sockets looked up, which are scanned first,
+// as processes making a connection usually make more.
+const maxRecentOwners = 16
+
+type socketOwner struct {
+	pid int
+	fd  string
+}
+
+// socketOwners remembers the descriptors of sockets seen while scanning /proc. Entries are checked before use, as the
+// descriptor may have been closed and reused since.
+var socketOwners struct {
+	sync.Mutex
+	sockets map[string]socketOwner
+	recent  []int
+	expire  time.Time
+}
+
+// findSocketOwner returns the PID of the process having the socket of inode open, or 0 if there is none visible.
+func findSocketOwner(inode string) int {
+	target := "socket:[" + inode + "]"
+
+	socketOwners.Lock()
+	defer socketOwners.Unlock()
+
+	if now := time.Now(); now.After(socketOwners.expire) {
+		socketOwners.sockets = make(map[string]socketOwner)
+		socketOwners.expire = now.Add(socketOwnersTTL)
+	}
+	if owner, found := socketOwners.sockets[inode]; found {
+		if link, err := os.Readlink(owner.fd); err == nil && link == target {
+			return owner.pid
+		}
+		delete(socketOwners.sockets, inode)
+	}
+
+	scanned := make(map[int]bool, len(socketOwners.recent))
+	for _, pid := range socketOwners.recent {
+		scanned[pid] = true
+		if scanProcessSockets(pid, inode) {
+			addRecentOwner(pid)
+			return pid
+		}
+	}
+	procs, err := os.ReadDir("/proc")
+	if err != nil {
+		return 0
+	}
+	for _, proc := range procs {
+		pid, err := strconv.Atoi(proc.Name())
+		if err != nil || !proc.IsDir() || scanned[pid] {
+			continue
+		}
+		if scanProcessSockets(pid, inode) {
+			addRecentOwner(pid)
+			return pid
+		}
+	}
+	return 0
+}
+
+// scanProcessSockets remembers the sockets process pid has open, and returns whether the socket of inode is one.
+// It must be called with socketOwners locked.
+func scanProcessSockets(pid int, inode string) bool {
+	fdDir := filepath.Join("/proc", strconv.Itoa(pid), "fd")
+	fds, err := os.ReadDir(fdDir)
+	if err != nil {
+		return false
+	}
+	found := false
+	for _, fd := range fds {
+		path := filepath.Join(fdDir, fd.Name())
+		link, err := os.Readlink(path)
+		if err != nil {
+			continue
+		}
+		if socket, ok := strings.CutPrefix(link, "socket:["); ok {
+			socket = strings.TrimSuffix(socket, "]")
+			socketOwners.sockets[socket] = socketOwner{pid: pid, fd: path}
+			found = found || socket == inode
+		}
+	}
+	return found
+}
+
+// addRecentOwner puts pid first in the processes scanned first. It must be called with socketOwners locked.
+func addRecentOwner(pid int) {
+	recent := socketOwners.recent
+	for i, p := range recent {
+		if p == pid {
+			recent = append(recent[:i], recent[i+1:]...)
+			break
+		}
+	}
+	if len(recent) >= maxRecentOwners {
+		recent = recent[:maxRecentOwners-1]
+	}
+	socketOwners.recent = append([]int{pid}, recent...)
+}
+
+// readProcess fills info with the details of process pid. Failures are ignored, leaving the fields empty.
+func readProcess(pid int, info *Info) {
+	info.PID = pid
+	dir := filepath.Join("/proc", strconv.Itoa(pid))
+	if comm, err := os.ReadFile(filepath.Join(dir, "comm")); err == nil {
+		info.Name = strings.TrimSpace(string(comm))
+	}
+	if path, err := os.Readlink(filepath.Join(dir, "exe")); err == nil {
+		info.Path = strings.TrimSuffix(path, " (deleted)")
+		// comm is truncated to 15 bytes
+		info.Name = filepath.Base(info.Path)
+	}
+	if status, err := os.ReadFile(filepath.Join(dir, "status")); err == nil {
+		for _, line := range strings.Split(string(status), "\n") {
+			if ids, found := strings.CutPrefix(line, "Gid:"); found {
+				if fields := strings.Fields(ids); len(fields) > 0 {
+					if gid, err := strconv.ParseUint(fields[0], 10, 32); err == nil {
+						info.GID = uint32(gid)
+					}
+				}
+				break
+			}
+		}
+	}
+}
@@ -0,0 +1,87 @@
+package process_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/xtls/xray-core/common"
+	"github.com/xtls/xray-core/common/net"
+	. "github.com/xtls/xray-core/common/process"
+)
+
+func TestFindProcess(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	common.Must(err)
+	defer listener.Close()
+
+	conn, err := net.Dial("tcp", listener.Addr().String())
+	common.Must(err)
+	defer conn.Close()
+
+	info, err := FindProcess(net.Network_TCP, net.DestinationFromAddr(conn.LocalAddr()))
+	common.Must(err)
+	if info.PID != os.Getpid() {
+		t.Error("expect pid ", os.Getpid(), ", but actually ", info.PID)
+	}
+	if info.UID != uint32(os.Getuid()) || info.GID != uint32(os.Getgid()) {
+		t.Error("expect uid ", os.Getuid(), " and gid ", os.Getgid(), ", but actually ", info.UID, " and ", info.GID)
+	}
+	executable, err := os.Executable()
+	common.Must(err)
+	if info.Path != executable || info.Name != filepath.Base(executable) {
+		t.Error("expect executable ", executable, ", but actually ", info.Path, " named ", info.Name)
+	}
+
+	udpConn, err := net.ListenUDP("udp", &net.UDPAddr{IP: net.IP{127, 0, 0, 1}})
+	common.Must(err)
+	defer udpConn.Close()
+
+	info, err = FindProcess(net.Network_UDP, net.DestinationFromAddr(udpConn.LocalAddr()))
+	common.Must(err)
+	if info.PID != os.Getpid() {
+		t.Error("expect pid ", os.Getpid(), ", but actually ", info.PID)
+	}
+
+	if _, err := FindProcess(net.Network_UDP, net.UDPDestination(net.LocalHostIP, 1)); err != ErrNotFound {
+		t.Error("expect ErrNotFound, but actually ", err)
+	}
+}
+
+func TestFindProcessAgain(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	common.Must(err)
+	defer listener.Close()
+
+	// Sockets already seen, and new sockets of the same process, are found without scanning all processes.
+	for i := 0; i < 3; i++ {
+		conn, err := net.Dial("tcp", listener.Addr().String())
+		common.Must(err)
+		for j := 0; j < 2; j++ {
+			info, err := FindProcess(net.Network_TCP, net.DestinationFromAddr(conn.LocalAddr()))
+			common.Must(err)
+			if info.PID != os.Getpid() {
+				t.Error("expect pid ", os.Getpid(), ", but actually ", info.PID)
+			}
+		}
+		conn.Close()
+	}
+}
+
+func TestFindProcessOfOtherHost(t *testing.T) {
+	udpConn, err := net.ListenUDP("udp4", &net.UDPAddr{IP: net.AnyIP.IP()})
+	common.Must(err)
+	defer udpConn.Close()
+	port := net.Port(udpConn.LocalAddr().(*net.UDPAddr).Port)
+
+	info, err := FindProcess(net.Network_UDP, net.UDPDestination(net.LocalHostIP, port))
+	common.Must(err)
+	if info.PID != os.Getpid() {
+		t.Error("expect pid ", os.Getpid(), ", but actually ", info.PID)
+	}
+
+	// The socket bound to 0.0.0.0 must not be taken for the source of a connection from another host.
+	if _, err := FindProcess(net.Network_UDP, net.UDPDestination(net.ParseAddress("192.0.2.1"), port)); err != ErrNotFound {
+		t.Error("expect ErrNotFound, but actually ", err)
+	}
+}
@@ -0,0 +1,13 @@
+//go:build !linux
+// +build !linux
+
+package process
+
+import (
+	"github.com/xtls/xray-core/common/errors"
+	"github.com/xtls/xray-core/common/net"
+)
+
+func findProcess(network net.Network, ip net.IP, port net.Port) (*Info, error) {
+	return nil, errors.New("finding process is not supported on this platform")
+}
@@ -18,6 +18,7 @@ import (
 
 type SniffHeader struct {
 	domain string
+	hello  *ptls.ClientHello
 }
 
 func (s SniffHeader) Protocol() string {
@@ -28,6 +29,22 @@ func (s SniffHeader) Domain() string {
 	return s.domain
 }
 
+// Attributes returns the fingerprints of the client hello as content attributes.
+func (s SniffHeader) Attributes() map[string]string {
+	if s.hello == nil {
+		return nil
+	}
+	return s.hello.Attributes(true)
+}
+
+// ALPN returns the protocols offered by the client hello.
+func (s SniffHeader) ALPN() []string {
+	if s.hello == nil {
+		return nil
+	}
+	return s.hello.ALPN
+}
+
 const (
 	versionDraft29 uint32 = 0xff00001d
 	version1       uint32 = 0x1
@@ -260,7 +277,7 @@ func SniffQUIC(b []byte) (*SniffHeader, error) {
 			b = restPayload
 			continue
 		}
-		return &SniffHeader{domain: tlsHdr.Domain()}, nil
+		return &SniffHeader{domain: tlsHdr.Domain(), hello: tlsHdr.ClientHello()}, nil
 	}
 	// All payload is parsed as valid QUIC packets, but we need more packets for crypto data to read client hello.
 	return nil, protocol.ErrProtoNeedMoreData
@@ -3,11 +3,13 @@ package quic_test
 import (
 	"encoding/hex"
 	"errors"
+	"strings"
 	"testing"
 
 	"github.com/xtls/xray-core/common"
 	"github.com/xtls/xray-core/common/protocol"
 	"github.com/xtls/xray-core/common/protocol/quic"
+	"github.com/xtls/xray-core/common/protocol/tls"
 )
 
 func TestSniffQUIC(t *testing.T) {
@@ -17,6 +19,9 @@ func TestSniffQUIC(t *testing.T) {
 	if err != nil || quicHdr.Domain() != "www.google.com" {
 		t.Error("failed")
 	}
+	if ja4 := quicHdr.Attributes()[tls.AttributeJA4]; !strings.HasPrefix(ja4, "q13d") {
+		t.Error("unexpected JA4 ", ja4)
+	}
 }
 
 func TestSniffQUICComplex(t *testing.T) {
@@ -0,0 +1,184 @@
+package tls
+
+import (
+	"crypto/md5"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Attributes of the content for routing, set from the sniffed client hello.
+const (
+	AttributeJA3 = ":tls-ja3"
+	AttributeJA4 = ":tls-ja4"
+)
+
+// ClientHello is the fields of a TLS client hello used for fingerprinting.
+type ClientHello struct {
+	ServerName          string
+	Version             uint16
+	CipherSuites        []uint16
+	Extensions          []uint16
+	SupportedGroups     []uint16
+	PointFormats        []byte
+	SignatureAlgorithms []uint16
+	SupportedVersions   []uint16
+	ALPN                []string
+}
+
+// isGREASE returns whether v is a GREASE value, which is random and ignored in fingerprints. See RFC 8701.
+func isGREASE(v uint16) bool {
+	return v&0x0f0f == 0x0a0a && v>>8 == v&0xff
+}
+
+func withoutGREASE(list []uint16) []uint16 {
+	result := make([]uint16, 0, len(list))
+	for _, v := range list {
+		if !isGREASE(v) {
+			result = append(result, v)
+		}
+	}
+	return result
+}
+
+func joinDecimal(list []uint16) string {
+	s := make([]string, len(list))
+	for i, v := range list {
+		s[i] = strconv.Itoa(int(v))
+	}
+	return strings.Join(s, "-")
+}
+
+func joinHex(list []uint16) string {
+	s := make([]string, len(list))
+	for i, v := range list {
+		s[i] = fmt.Sprintf("%04x", v)
+	}
+	return strings.Join(s, ",")
+}
+
+// JA3String returns the JA3 fingerprint before hashing.
+// See https://github.com/salesforce/ja3.
+func (c *ClientHello) JA3String() string {
+	formats := make([]string, len(c.PointFormats))
+	for i, f := range c.PointFormats {
+		formats[i] = strconv.Itoa(int(f))
+	}
+	return strings.Join([]string{
+		strconv.Itoa(int(c.Version)),
+		joinDecimal(withoutGREASE(c.CipherSuites)),
+		joinDecimal(withoutGREASE(c.Extensions)),
+		joinDecimal(withoutGREASE(c.SupportedGroups)),
+		strings.Join(formats, "-"),
+	}, ",")
+}
+
+// JA3 returns the JA3 fingerprint, which is the MD5 of JA3String in hex.
+func (c *ClientHello) JA3() string {
+	sum := md5.Sum([]byte(c.JA3String()))
+	return hex.EncodeToString(sum[:])
+}
+
+func ja4Version(v uint16) string {
+	switch v {
+	case 0x0304:
+		return "13"
+	case 0x0303:
+		return "12"
+	case 0x0302:
+		return "11"
+	case 0x0301:
+		return "10"
+	case 0x0300:
+		return "s3"
+	case 0x0002:
+		return "s2"
+	case 0xfeff:
+		return "d1"
+	case 0xfefd:
+		return "d2"
+	case 0xfefc:
+		return "d3"
+	default:
+		return "00"
+	}
+}
+
+func isAlphanumeric(b byte) bool {
+	return b >= '0' && b <= '9' || b >= 'A' && b <= 'Z' || b >= 'a' && b <= 'z'
+}
+
+func ja4Hash(s string) string {
+	if s == "" {
+		return "000000000000"
+	}
+	sum := sha256.Sum256([]byte(s))
+	return hex.EncodeToString(sum[:])[:12]
+}
+
+// JA4 returns the JA4 fingerprint of the client hello, over QUIC if quic is true.
+// See https://github.com/FoxIO-LLC/ja4/blob/main/technical_details/JA4.md.
+func (c *ClientHello) JA4(quic bool) string {
+	var a strings.Builder
+	if quic {
+		a.WriteByte('q')
+	} else {
+		a.WriteByte('t')
+	}
+
+	version := c.Version
+	if versions := withoutGREASE(c.SupportedVersions); len(versions) > 0 {
+		version = slices.Max(versions)
+	}
+	a.WriteString(ja4Version(version))
+
+	if c.ServerName != "" {
+		a.WriteByte('d')
+	} else {
+		a.WriteByte('i')
+	}
+
+	ciphers := withoutGREASE(c.CipherSuites)
+	extensions := withoutGREASE(c.Extensions)
+	fmt.Fprintf(&a, "%02d%02d", min(len(ciphers), 99), min(len(extensions), 99))
+
+	alpn := "00"
+	if len(c.ALPN) > 0 && c.ALPN[0] != "" {
+		first := c.ALPN[0]
+		if isAlphanumeric(first[0]) && isAlphanumeric(first[len(first)-1]) {
+			alpn = string([]byte{first[0], first[len(first)-1]})
+		} else {
+			h := hex.EncodeToString([]byte(first))
+			alpn = string([]byte{h[0], h[len(h)-1]})
+		}
+	}
+	a.WriteString(alpn)
+
+	slices.Sort(ciphers)
+
+	// The server name and ALPN are excluded, as they are already in the first part.
+	sorted := make([]uint16, 0, len(extensions))
+	for _, e := range extensions {
+		if e != 0x00 && e != 0x10 {
+			sorted = append(sorted, e)
+		}
+	}
+	slices.Sort(sorted)
+	extensionsString := joinHex(sorted)
+	if len(sorted) > 0 && len(c.SignatureAlgorithms) > 0 {
+		extensionsString += "_" + joinHex(c.SignatureAlgorithms)
+	}
+
+	return a.String() + "_" + ja4Hash(joinHex(ciphers)) + "_" + ja4Hash(extensionsString)
+}
+
+// Attributes returns the fingerprints of the client hello as content attributes, over QUIC if quic is true.
+func (c *ClientHello) Attributes(quic bool) map[string]string {
+	return map[string]string{
+		AttributeJA3: c.JA3(),
+		AttributeJA4: c.JA4(quic),
+	}
+}
@@ -10,6 +10,8 @@ import (
 
 type SniffHeader struct {
 	domain string
+	// The client hello for fingerprinting, if it is parsed completely.
+	hello *ClientHello
 }
 
 func (h *SniffHeader) Protocol() string {
@@ -20,6 +22,27 @@ func (h *SniffHeader) Domain() string {
 	return h.domain
 }
 
+// ClientHello returns the parsed client hello, or nil if it is not parsed completely.
+func (h *SniffHeader) ClientHello() *ClientHello {
+	return h.hello
+}
+
+// Attributes returns the fingerprints of the client hello as content attributes.
+func (h *SniffHeader) Attributes() map[string]string {
+	if h.hello == nil {
+		return nil
+	}
+	return h.hello.Attributes(false)
+}
+
+// ALPN returns the protocols offered by the client hello.
+func (h *SniffHeader) ALPN() []string {
+	if h.hello == nil {
+		return nil
+	}
+	return h.hello.ALPN
+}
+
 var (
 	errNotTLS         = errors.New("not TLS header")
 	errNotClientHello = errors.New("not client hello")
@@ -30,75 +53,107 @@ func IsValidTLSVersion(major, minor byte) bool {
 }
 
 // ReadClientHello returns server name (if any) from TLS client hello message.
+// The client hello is kept in h for fingerprinting if it is parsed completely.
 // https://github.com/golang/go/blob/master/src/crypto/tls/handshake_messages.go#L300
 func ReadClientHello(data []byte, h *SniffHeader) error {
+	hello, err := parseClientHello(data)
+	if hello != nil && hello.ServerName != "" {
+		// The server name is enough for sniffing, even if the rest is malformed or not recovered yet.
+		h.domain = hello.ServerName
+		if err == nil {
+			h.hello = hello
+		}
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	h.hello = hello
+	return errNotTLS
+}
+
+func parseClientHello(data []byte) (*ClientHello, error) {
 	if len(data) < 42 {
-		return common.ErrNoClue
+		return nil, common.ErrNoClue
+	}
+	hello := &ClientHello{
+		Version: binary.BigEndian.Uint16(data[4:6]),
 	}
 	sessionIDLen := int(data[38])
 	if sessionIDLen > 32 || len(data) < 39+sessionIDLen {
-		return common.ErrNoClue
+		return nil, common.ErrNoClue
 	}
 	data = data[39+sessionIDLen:]
 	if len(data) < 2 {
-		return common.ErrNoClue
+		return nil, common.ErrNoClue
 	}
 	// cipherSuiteLen is the number of bytes of cipher suite numbers. Since
 	// they are uint16s, the number must be even.
 	cipherSuiteLen := int(data[0])<<8 | int(data[1])
 	if cipherSuiteLen%2 == 1 || len(data) < 2+cipherSuiteLen {
-		return errNotClientHello
+		return nil, errNotClientHello
 	}
+	hello.CipherSuites = readUint16s(data[2 : 2+cipherSuiteLen])
 	data = data[2+cipherSuiteLen:]
 	if len(data) < 1 {
-		return common.ErrNoClue
+		return nil, common.ErrNoClue
 	}
 	compressionMethodsLen := int(data[0])
 	if len(data) < 1+compressionMethodsLen {
-		return common.ErrNoClue
+		return nil, common.ErrNoClue
 	}
 	data = data[1+compressionMethodsLen:]
 
 	if len(data) < 2 {
-		return errNotClientHello
+		return nil, errNotClientHello
 	}
 
 	extensionsLength := int(data[0])<<8 | int(data[1])
 	data = data[2:]
 	if extensionsLength != len(data) {
-		return errNotClientHello
+		return nil, errNotClientHello
 	}
 
+	// Extensions only for fingerprinting being malformed do not stop reading the server name.
+	malformed := false
+	seen := make(map[uint16]bool)
 	for len(data) != 0 {
 		if len(data) < 4 {
-			return errNotClientHello
+			return hello, errNotClientHello
 		}
 		extension := uint16(data[0])<<8 | uint16(data[1])
 		length := int(data[2])<<8 | int(data[3])
 		data = data[4:]
 		if len(data) < length {
-			return errNotClientHello
+			return hello, errNotClientHello
+		}
+		// Duplicated extensions are not allowed, and are likely zeros of client hello not recovered yet in QUIC.
+		if seen[extension] {
+			return hello, errNotClientHello
 		}
+		seen[extension] = true
+		hello.Extensions = append(hello.Extensions, extension)
 
-		if extension == 0x00 { /* extensionServerName */
-			d := data[:length]
+		d := data[:length]
+		switch extension {
+		case 0x00: /* extensionServerName */
 			if len(d) < 2 {
-				return errNotClientHello
+				return hello, errNotClientHello
 			}
 			namesLen := int(d[0])<<8 | int(d[1])
 			d = d[2:]
 			if len(d) != namesLen {
-				return errNotClientHello
+				return hello, errNotClientHello
 			}
 			for len(d) > 0 {
 				if len(d) < 3 {
-					return errNotClientHello
+					return hello, errNotClientHello
 				}
 				nameType := d[0]
 				nameLen := int(d[1])<<8 | int(d[2])
 				d = d[3:]
 				if len(d) < nameLen {
-					return errNotClientHello
+					return hello, errNotClientHello
 				}
 				if nameType == 0 {
 					// QUIC separated across packets
@@ -106,29 +161,91 @@ func ReadClientHello(data []byte, h *SniffHeader) error {
 					b := byte(0)
 					for _, b = range d[:nameLen] {
 						if b <= ' ' {
-							return protocol.ErrProtoNeedMoreData
+							return hello, protocol.ErrProtoNeedMoreData
 						}
 					}
 					// An SNI value may not include a
 					// trailing dot. See
 					// https://tools.ietf.org/html/rfc6066#section-3.
 					if b == '.' {
-						return errNotClientHello
+						return hello, errNotClientHello
 					}
-					serverName := string(d[:nameLen])
-					h.domain = serverName
-					return nil
+					hello.ServerName = string(d[:nameLen])
+					break
 				}
 				d = d[nameLen:]
 			}
+		case 0x0a: /* extensionSupportedCurves */
+			list, ok := readUint16List(d)
+			if !ok {
+				malformed = true
+			}
+			hello.SupportedGroups = list
+		case 0x0b: /* extensionSupportedPoints */
+			if len(d) < 1 || len(d) != 1+int(d[0]) {
+				malformed = true
+				break
+			}
+			hello.PointFormats = append([]byte(nil), d[1:]...)
+		case 0x0d: /* extensionSignatureAlgorithms */
+			list, ok := readUint16List(d)
+			if !ok {
+				malformed = true
+			}
+			hello.SignatureAlgorithms = list
+		case 0x10: /* extensionALPN */
+			if len(d) < 2 || len(d) != 2+(int(d[0])<<8|int(d[1])) {
+				malformed = true
+				break
+			}
+			d = d[2:]
+			for len(d) > 0 {
+				protoLen := int(d[0])
+				if protoLen == 0 || len(d) < 1+protoLen {
+					malformed = true
+					break
+				}
+				hello.ALPN = append(hello.ALPN, string(d[1:1+protoLen]))
+				d = d[1+protoLen:]
+			}
+		case 0x2b: /* extensionSupportedVersions */
+			if len(d) < 1 || len(d) != 1+int(d[0]) || d[0]%2 == 1 {
+				malformed = true
+				break
+			}
+			hello.SupportedVersions = readUint16s(d[1:])
 		}
 		data = data[length:]
 	}
 
-	return errNotTLS
+	if malformed {
+		return hello, errNotClientHello
+	}
+	return hello, nil
 }
 
-func SniffTLS(b []byte) (*SniffHeader, error) {
+// readUint16List reads a list of uint16s prefixed with its length in bytes.
+func readUint16List(d []byte) ([]uint16, bool) {
+	if len(d) < 2 {
+		return nil, false
+	}
+	length := int(d[0])<<8 | int(d[1])
+	if length%2 == 1 || len(d) != 2+length {
+		return nil, false
+	}
+	return readUint16s(d[2:]), true
+}
+
+func readUint16s(d []byte) []uint16 {
+	list := make([]uint16, 0, len(d)/2)
+	for i := 0; i+1 < len(d); i += 2 {
+		list = append(list, binary.BigEndian.Uint16(d[i:]))
+	}
+	return list
+}
+
+// readRecord reads the client hello in the TLS record at the start of b.
+func readRecord(b []byte) (*SniffHeader, error) {
 	if len(b) < 5 {
 		return nil, common.ErrNoClue
 	}
@@ -145,9 +262,26 @@ func SniffTLS(b []byte) (*SniffHeader, error) {
 	}
 
 	h := &SniffHeader{}
-	err := ReadClientHello(b[5:5+headerLen], h)
+	return h, ReadClientHello(b[5:5+headerLen], h)
+}
+
+func SniffTLS(b []byte) (*SniffHeader, error) {
+	h, err := readRecord(b)
+	if err != nil {
+		return nil, err
+	}
+	return h, nil
+}
+
+// SniffClientHello returns the client hello at the start of b for fingerprinting, if it is parsed completely. Unlike
+// SniffTLS, it also returns client hellos without server name, e.g. of scanners.
+func SniffClientHello(b []byte) (*ClientHello, error) {
+	h, err := readRecord(b)
+	if h != nil && h.hello != nil {
+		return h.hello, nil
+	}
 	if err == nil {
-		return h, nil
+		err = errNotClientHello
 	}
 	return nil, err
 }
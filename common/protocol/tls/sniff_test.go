@@ -1,6 +1,10 @@
 package tls_test
 
 import (
+	"crypto/tls"
+	"io"
+	"net"
+	"strings"
 	"testing"
 
 	. "github.com/xtls/xray-core/common/protocol/tls"
@@ -11,6 +15,8 @@ func TestTLSHeaders(t *testing.T) {
 		input  []byte
 		domain string
 		err    bool
+		ja3    string
+		ja4    string
 	}{
 		{
 			input: []byte{
@@ -43,6 +49,8 @@ func TestTLSHeaders(t *testing.T) {
 			},
 			domain: "c.s-microsoft.com",
 			err:    false,
+			ja3:    "b8f81673c0e1d29908346f3bab892b9b",
+			ja4:    "t12d1510h2_f0daf39aad75_e69ac49eb88f",
 		},
 		{
 			input: []byte{
@@ -156,6 +164,62 @@ func TestTLSHeaders(t *testing.T) {
 			if header.Domain() != test.domain {
 				t.Error("expect domain ", test.domain, " but got ", header.Domain())
 			}
+			if test.ja3 != "" {
+				attrs := header.Attributes()
+				if attrs[AttributeJA3] != test.ja3 {
+					t.Error("expect JA3 ", test.ja3, " but got ", attrs[AttributeJA3])
+				}
+				if attrs[AttributeJA4] != test.ja4 {
+					t.Error("expect JA4 ", test.ja4, " but got ", attrs[AttributeJA4])
+				}
+				if alpn := header.ALPN(); len(alpn) != 2 || alpn[0] != "h2" || alpn[1] != "http/1.1" {
+					t.Error("expect ALPN [h2 http/1.1] but got ", alpn)
+				}
+			}
+		}
+	}
+}
+
+func TestTLSWithoutServerName(t *testing.T) {
+	client, server := net.Pipe()
+	go func() {
+		tls.Client(client, &tls.Config{
+			InsecureSkipVerify: true,
+			NextProtos:         []string{"h2"},
+			MinVersion:         tls.VersionTLS12,
+		}).Handshake()
+	}()
+	defer client.Close()
+	defer server.Close()
+
+	b := make([]byte, 2048)
+	n, err := io.ReadAtLeast(server, b, 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for n < 5+(int(b[3])<<8|int(b[4])) {
+		m, err := server.Read(b[n:])
+		if err != nil {
+			t.Fatal(err)
 		}
+		n += m
+	}
+
+	if _, err := SniffTLS(b[:n]); err == nil {
+		t.Error("expect client hello without server name not to be sniffed")
+	}
+	hello, err := SniffClientHello(b[:n])
+	if err != nil {
+		t.Fatal("expect client hello without server name to be fingerprinted, but got ", err)
+	}
+	ja4 := hello.JA4(false)
+	if !strings.HasPrefix(ja4, "t13i") || ja4[8:10] != "h2" {
+		t.Error("unexpected JA4 ", ja4)
+	}
+	if ja3 := hello.Attributes(false)[AttributeJA3]; len(ja3) != 32 {
+		t.Error("unexpected JA3 ", ja3)
+	}
+	if len(hello.ALPN) != 1 || hello.ALPN[0] != "h2" {
+		t.Error("unexpected ALPN ", hello.ALPN)
 	}
 }
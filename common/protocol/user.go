@@ -1,6 +1,8 @@
 package protocol
 
 import (
+	"time"
+
 	"github.com/xtls/xray-core/common/errors"
 	"github.com/xtls/xray-core/common/serial"
 )
@@ -32,6 +34,8 @@ func (u *User) ToMemoryUser() (*MemoryUser, error) {
 		Account: account,
 		Email:   u.Email,
 		Level:   u.Level,
+		Quota:   u.Quota,
+		Expire:  u.Expire,
 	}, nil
 }
 
@@ -43,6 +47,8 @@ func ToProtoUser(mu *MemoryUser) *User {
 		Account: serial.ToTypedMessage(mu.Account.ToProto()),
 		Email:   mu.Email,
 		Level:   mu.Level,
+		Quota:   mu.Quota,
+		Expire:  mu.Expire,
 	}
 }
 
@@ -52,4 +58,13 @@ type MemoryUser struct {
 	Account Account
 	Email   string
 	Level   uint32
+	// Quota is the traffic quota in bytes. 0 for unlimited.
+	Quota uint64
+	// Expire is the unix time in seconds after which the user is rejected. 0 for never.
+	Expire int64
+}
+
+// Expired returns true if the user has an expiry time that is not after now.
+func (u *MemoryUser) Expired(now time.Time) bool {
+	return u.Expire > 0 && now.Unix() >= u.Expire
 }
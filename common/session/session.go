@@ -95,6 +95,9 @@ type Content struct {
 	// HTTP traffic sniffed headers
 	Attributes map[string]string
 
+	// ALPN offered by the sniffed TLS client hello
+	ALPN []string
+
 	// SkipDNSResolve is set from DNS module. the DOH remote server maybe a domain name, this prevents cycle resolving dead loop
 	SkipDNSResolve bool
 }
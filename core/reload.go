@@ -0,0 +1,376 @@
+package core
+
+import (
+	"bytes"
+	"context"
+
+	"github.com/xtls/xray-core/common"
+	"github.com/xtls/xray-core/common/errors"
+	"github.com/xtls/xray-core/common/serial"
+	"github.com/xtls/xray-core/features"
+	"github.com/xtls/xray-core/features/inbound"
+	"github.com/xtls/xray-core/features/outbound"
+	"google.golang.org/protobuf/proto"
+)
+
+// Reload applies the given config to a running Instance.
+// Inbound and outbound handlers are matched by tag against the running ones, including those changed through the
+// API: handlers whose settings are unchanged are kept together with their connections, the others are removed,
+// replaced or added. App settings are handed over to features implementing features.Reloadable, e.g. router and
+// DNS; other app settings can only change with a restart.
+// All new handlers and app settings are built before anything is applied. If applying fails, the changes already
+// applied are rolled back, so that the instance keeps running with its previous config.
+//
+// xray:api:beta
+func (s *Instance) Reload(config *Config) error {
+	s.reloadLock.Lock()
+	defer s.reloadLock.Unlock()
+
+	if s.config == nil {
+		return errors.New("instance is not created from a config")
+	}
+
+	inbounds, err := s.planInbounds(config.Inbound)
+	if err != nil {
+		return errors.New("failed to reload inbounds").Base(err)
+	}
+	outbounds, err := s.planOutbounds(config.Outbound)
+	if err != nil {
+		inbounds.discard()
+		return errors.New("failed to reload outbounds").Base(err)
+	}
+	apps, err := s.planApps(config.App)
+	if err != nil {
+		inbounds.discard()
+		outbounds.discard()
+		return errors.New("failed to reload app settings").Base(err)
+	}
+
+	if err := apps.apply(s); err != nil {
+		inbounds.discard()
+		outbounds.discard()
+		return errors.New("failed to reload app settings").Base(err)
+	}
+	if err := inbounds.apply(s.ctx); err != nil {
+		outbounds.discard()
+		apps.rollback(s)
+		return errors.New("failed to reload inbounds").Base(err)
+	}
+	if err := outbounds.apply(s.ctx); err != nil {
+		inbounds.rollback(s.ctx, len(inbounds.add))
+		apps.rollback(s)
+		return errors.New("failed to reload outbounds").Base(err)
+	}
+
+	s.config.App = apps.applied
+	errors.LogWarning(s.ctx, "Xray ", Version(), " reloaded")
+	return nil
+}
+
+// appChanges are the app settings to hand over to reloadable features.
+type appChanges struct {
+	reload   []*serial.TypedMessage
+	settings []proto.Message
+	// old are the settings replaced, in the order of reload, to restore them on rollback.
+	old []*serial.TypedMessage
+	// applied are the app settings to record once the reload succeeds.
+	applied []*serial.TypedMessage
+	// reloaded is the number of features reloaded so far.
+	reloaded int
+}
+
+func (s *Instance) planApps(apps []*serial.TypedMessage) (*appChanges, error) {
+	current := make(map[string]*serial.TypedMessage, len(s.config.App))
+	for _, app := range s.config.App {
+		current[app.Type] = app
+	}
+
+	c := &appChanges{applied: make([]*serial.TypedMessage, 0, len(apps))}
+	for _, app := range apps {
+		old, found := current[app.Type]
+		delete(current, app.Type)
+		if found && proto.Equal(old, app) {
+			c.applied = append(c.applied, app)
+			continue
+		}
+		_, ok := s.appFeatures[app.Type].(features.Reloadable)
+		if !found || !ok {
+			errors.LogWarning(s.ctx, "settings of ", app.Type, " changed, restart to apply")
+			if old != nil {
+				c.applied = append(c.applied, old)
+			}
+			continue
+		}
+		settings, err := app.GetInstance()
+		if err != nil {
+			return nil, errors.New("failed to load settings of ", app.Type).Base(err)
+		}
+		c.reload = append(c.reload, app)
+		c.settings = append(c.settings, settings)
+		c.old = append(c.old, old)
+		c.applied = append(c.applied, app)
+	}
+	for t, old := range current {
+		errors.LogWarning(s.ctx, "settings of ", t, " removed, restart to apply")
+		c.applied = append(c.applied, old)
+	}
+	return c, nil
+}
+
+// apply reloads the features. If one fails, those reloaded before it are restored.
+func (c *appChanges) apply(s *Instance) error {
+	for i, app := range c.reload {
+		if err := s.appFeatures[app.Type].(features.Reloadable).Reload(c.settings[i]); err != nil {
+			c.rollback(s)
+			return errors.New("failed to reload ", app.Type).Base(err)
+		}
+		c.reloaded++
+		errors.LogInfo(s.ctx, "reloaded ", app.Type)
+	}
+	return nil
+}
+
+// rollback restores the settings of the features reloaded.
+func (c *appChanges) rollback(s *Instance) {
+	for i := c.reloaded - 1; i >= 0; i-- {
+		old := c.old[i]
+		settings, err := old.GetInstance()
+		if err == nil {
+			err = s.appFeatures[old.Type].(features.Reloadable).Reload(settings)
+		}
+		if err != nil {
+			errors.LogErrorInner(s.ctx, err, "failed to restore settings of ", old.Type)
+		}
+	}
+	c.reloaded = 0
+}
+
+// handlerManager is the part of inbound.Manager and outbound.Manager needed to swap handlers.
+type handlerManager[H any] interface {
+	AddHandler(ctx context.Context, handler H) error
+	RemoveHandler(ctx context.Context, tag string) error
+}
+
+// handlerChanges are the changes of the handlers of a manager. The new handlers are built, but not started.
+type handlerChanges[H interface{ Tag() string }] struct {
+	kind    string
+	manager handlerManager[H]
+	build   func(config proto.Message) (H, error)
+	// remove are the tags of the running handlers to remove, and restore their settings, to rebuild them on rollback.
+	remove  []string
+	restore []proto.Message
+	removed int
+	add     []H
+}
+
+// apply removes the handlers replaced and adds the new ones. If one fails, the changes are rolled back.
+func (c *handlerChanges[H]) apply(ctx context.Context) error {
+	for _, tag := range c.remove {
+		if err := c.manager.RemoveHandler(ctx, tag); err != nil {
+			c.rollback(ctx, 0)
+			return errors.New("failed to remove ", c.kind, " ", tag).Base(err)
+		}
+		c.removed++
+		errors.LogInfo(ctx, "removed ", c.kind, " ", tag)
+	}
+	for i, h := range c.add {
+		if err := c.manager.AddHandler(ctx, h); err != nil {
+			// The manager may keep the handler failing to start.
+			c.rollback(ctx, i+1)
+			return errors.New("failed to add ", c.kind, " ", h.Tag()).Base(err)
+		}
+		errors.LogInfo(ctx, "reloaded ", c.kind, " ", h.Tag())
+	}
+	return nil
+}
+
+// rollback removes the first added handlers, closes the others, and rebuilds the handlers removed.
+// Handlers rebuilt lose the users added to them through the API.
+func (c *handlerChanges[H]) rollback(ctx context.Context, added int) {
+	for _, h := range c.add[:added] {
+		c.manager.RemoveHandler(ctx, h.Tag())
+	}
+	c.add = c.add[added:]
+	c.discard()
+	for i, tag := range c.remove[:c.removed] {
+		h, err := c.build(c.restore[i])
+		if err == nil {
+			err = c.manager.AddHandler(ctx, h)
+		}
+		if err != nil {
+			errors.LogErrorInner(ctx, err, "failed to restore ", c.kind, " ", tag)
+		}
+	}
+	c.removed = 0
+}
+
+// discard closes the new handlers not added.
+func (c *handlerChanges[H]) discard() {
+	for _, h := range c.add {
+		common.Close(h)
+	}
+	c.add = nil
+}
+
+// buildHandler creates a handler of type H from its config.
+func buildHandler[H any](s *Instance, config proto.Message) (H, error) {
+	var handler H
+	rawHandler, err := CreateObject(s, config)
+	if err != nil {
+		return handler, err
+	}
+	handler, ok := rawHandler.(H)
+	if !ok {
+		common.Close(rawHandler)
+		return handler, errors.New("unexpected handler type ", serial.GetMessageType(config))
+	}
+	return handler, nil
+}
+
+func (s *Instance) planInbounds(configs []*InboundHandlerConfig) (*handlerChanges[inbound.Handler], error) {
+	ihm := s.GetFeature(inbound.ManagerType()).(inbound.Manager)
+	c := &handlerChanges[inbound.Handler]{
+		kind:    "inbound",
+		manager: ihm,
+		build: func(config proto.Message) (inbound.Handler, error) {
+			return buildHandler[inbound.Handler](s, config)
+		},
+	}
+
+	current := make(map[string]*InboundHandlerConfig)
+	for _, h := range ihm.ListHandlers(s.ctx) {
+		if h.Tag() == "" {
+			continue
+		}
+		current[h.Tag()] = &InboundHandlerConfig{
+			Tag:              h.Tag(),
+			ReceiverSettings: h.ReceiverSettings(),
+			ProxySettings:    h.ProxySettings(),
+		}
+	}
+
+	keep := make(map[string]bool, len(configs))
+	seen := make(map[string]bool, len(configs))
+	for _, config := range configs {
+		if config.Tag == "" {
+			errors.LogWarning(s.ctx, "untagged inbound can not be reloaded, restart to apply")
+			continue
+		}
+		if seen[config.Tag] {
+			c.discard()
+			return nil, errors.New("duplicate inbound tag ", config.Tag)
+		}
+		seen[config.Tag] = true
+		if old, found := current[config.Tag]; found && sameSettings(old.ReceiverSettings, config.ReceiverSettings) && sameSettings(old.ProxySettings, config.ProxySettings) {
+			keep[config.Tag] = true
+			continue
+		}
+		h, err := c.build(config)
+		if err != nil {
+			c.discard()
+			return nil, errors.New("failed to build inbound ", config.Tag).Base(err)
+		}
+		c.add = append(c.add, h)
+	}
+	for tag, old := range current {
+		if !keep[tag] {
+			c.remove = append(c.remove, tag)
+			c.restore = append(c.restore, old)
+		}
+	}
+	return c, nil
+}
+
+func (s *Instance) planOutbounds(configs []*OutboundHandlerConfig) (*handlerChanges[outbound.Handler], error) {
+	ohm := s.GetFeature(outbound.ManagerType()).(outbound.Manager)
+	c := &handlerChanges[outbound.Handler]{
+		kind:    "outbound",
+		manager: ohm,
+		build: func(config proto.Message) (outbound.Handler, error) {
+			return buildHandler[outbound.Handler](s, config)
+		},
+	}
+
+	current := make(map[string]*OutboundHandlerConfig)
+	for _, h := range ohm.ListHandlers(s.ctx) {
+		if h.Tag() == "" {
+			continue
+		}
+		current[h.Tag()] = &OutboundHandlerConfig{
+			Tag:            h.Tag(),
+			SenderSettings: h.SenderSettings(),
+			ProxySettings:  h.ProxySettings(),
+		}
+	}
+
+	keep := make(map[string]bool, len(configs))
+	seen := make(map[string]bool, len(configs))
+	// The first outbound is the default one. If it changed, re-add both the old and the new one,
+	// so that the manager picks up the new default handler.
+	var defaultTag string
+	if h := ohm.GetDefaultHandler(); h != nil {
+		defaultTag = h.Tag()
+	}
+	replace := make(map[string]bool, 2)
+	if defaultTag != "" && len(configs) > 0 && defaultTag != configs[0].Tag {
+		replace[defaultTag] = true
+		replace[configs[0].Tag] = true
+	}
+	for _, config := range configs {
+		if config.Tag == "" {
+			errors.LogWarning(s.ctx, "untagged outbound can not be reloaded, restart to apply")
+			continue
+		}
+		if seen[config.Tag] {
+			c.discard()
+			return nil, errors.New("duplicate outbound tag ", config.Tag)
+		}
+		seen[config.Tag] = true
+		if old, found := current[config.Tag]; found && !replace[config.Tag] && sameSettings(old.SenderSettings, config.SenderSettings) && sameSettings(old.ProxySettings, config.ProxySettings) {
+			keep[config.Tag] = true
+			continue
+		}
+		h, err := c.build(config)
+		if err != nil {
+			c.discard()
+			return nil, errors.New("failed to build outbound ", config.Tag).Base(err)
+		}
+		c.add = append(c.add, h)
+	}
+	for tag, old := range current {
+		if keep[tag] {
+			continue
+		}
+		c.remove = append(c.remove, tag)
+		c.restore = append(c.restore, old)
+		// Rebuild the default handler first on rollback, so that it becomes the default again.
+		if n := len(c.remove) - 1; tag == defaultTag && n > 0 {
+			c.remove[0], c.remove[n] = c.remove[n], c.remove[0]
+			c.restore[0], c.restore[n] = c.restore[n], c.restore[0]
+		}
+	}
+	return c, nil
+}
+
+// sameSettings reports whether two typed messages hold equal settings. Missing settings equal empty ones.
+// Messages are compared decoded, as settings encoded again by a running handler may order map entries differently.
+func sameSettings(a, b *serial.TypedMessage) bool {
+	if a == nil || b == nil {
+		return len(a.GetValue()) == 0 && len(b.GetValue()) == 0
+	}
+	if a.Type != b.Type {
+		return false
+	}
+	if bytes.Equal(a.Value, b.Value) {
+		return true
+	}
+	x, err := a.GetInstance()
+	if err != nil {
+		return false
+	}
+	y, err := b.GetInstance()
+	if err != nil {
+		return false
+	}
+	return proto.Equal(x, y)
+}
@@ -0,0 +1,195 @@
+package core_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/xtls/xray-core/app/dispatcher"
+	"github.com/xtls/xray-core/app/proxyman"
+	"github.com/xtls/xray-core/app/router"
+	"github.com/xtls/xray-core/common"
+	"github.com/xtls/xray-core/common/net"
+	"github.com/xtls/xray-core/common/serial"
+	. "github.com/xtls/xray-core/core"
+	"github.com/xtls/xray-core/features/inbound"
+	"github.com/xtls/xray-core/features/outbound"
+	"github.com/xtls/xray-core/proxy/dokodemo"
+	"github.com/xtls/xray-core/proxy/freedom"
+	"github.com/xtls/xray-core/testing/servers/tcp"
+)
+
+func reloadInboundConfig(tag string, port net.Port) *InboundHandlerConfig {
+	return &InboundHandlerConfig{
+		Tag: tag,
+		ReceiverSettings: serial.ToTypedMessage(&proxyman.ReceiverConfig{
+			PortList: &net.PortList{
+				Range: []*net.PortRange{net.SinglePortRange(port)},
+			},
+			Listen: net.NewIPOrDomain(net.LocalHostIP),
+		}),
+		ProxySettings: serial.ToTypedMessage(&dokodemo.Config{
+			Address:  net.NewIPOrDomain(net.LocalHostIP),
+			Port:     uint32(80),
+			Networks: []net.Network{net.Network_TCP},
+		}),
+	}
+}
+
+func reloadOutboundConfig(tag string) *OutboundHandlerConfig {
+	return &OutboundHandlerConfig{
+		Tag:           tag,
+		ProxySettings: serial.ToTypedMessage(&freedom.Config{}),
+	}
+}
+
+func reloadApps(domainStrategy router.Config_DomainStrategy) []*serial.TypedMessage {
+	return []*serial.TypedMessage{
+		serial.ToTypedMessage(&dispatcher.Config{}),
+		serial.ToTypedMessage(&proxyman.InboundConfig{}),
+		serial.ToTypedMessage(&proxyman.OutboundConfig{}),
+		serial.ToTypedMessage(&router.Config{DomainStrategy: domainStrategy}),
+	}
+}
+
+func TestInstanceReload(t *testing.T) {
+	inboundConfig, outboundConfig, apps := reloadInboundConfig, reloadOutboundConfig, reloadApps
+
+	port1 := tcp.PickPort()
+	server, err := New(&Config{
+		App:      apps(router.Config_AsIs),
+		Inbound:  []*InboundHandlerConfig{inboundConfig("in1", port1), inboundConfig("in2", tcp.PickPort()), inboundConfig("in3", tcp.PickPort())},
+		Outbound: []*OutboundHandlerConfig{outboundConfig("out1"), outboundConfig("out2")},
+	})
+	common.Must(err)
+	common.Must(server.Start())
+	defer server.Close()
+
+	ihm := server.GetFeature(inbound.ManagerType()).(inbound.Manager)
+	ohm := server.GetFeature(outbound.ManagerType()).(outbound.Manager)
+	in1, err := ihm.GetHandler(context.Background(), "in1")
+	common.Must(err)
+	in2, err := ihm.GetHandler(context.Background(), "in2")
+	common.Must(err)
+
+	common.Must(server.Reload(&Config{
+		App:      apps(router.Config_IpIfNonMatch),
+		Inbound:  []*InboundHandlerConfig{inboundConfig("in1", port1), inboundConfig("in2", tcp.PickPort()), inboundConfig("in4", tcp.PickPort())},
+		Outbound: []*OutboundHandlerConfig{outboundConfig("out2"), outboundConfig("out3")},
+	}))
+
+	if h, err := ihm.GetHandler(context.Background(), "in1"); err != nil || h != in1 {
+		t.Error("expected in1 to be kept")
+	}
+	if h, err := ihm.GetHandler(context.Background(), "in2"); err != nil || h == in2 {
+		t.Error("expected in2 to be replaced")
+	}
+	if _, err := ihm.GetHandler(context.Background(), "in3"); err == nil {
+		t.Error("expected in3 to be removed")
+	}
+	if _, err := ihm.GetHandler(context.Background(), "in4"); err != nil {
+		t.Error("expected in4 to be added: ", err)
+	}
+	if ohm.GetHandler("out1") != nil {
+		t.Error("expected out1 to be removed")
+	}
+	if ohm.GetHandler("out3") == nil {
+		t.Error("expected out3 to be added")
+	}
+	if tag := ohm.GetDefaultHandler().Tag(); tag != "out2" {
+		t.Error("expected out2 to be the default outbound, but got ", tag)
+	}
+}
+
+func TestInstanceReloadAfterAPIChanges(t *testing.T) {
+	port1, port2 := tcp.PickPort(), tcp.PickPort()
+	config := &Config{
+		App:      reloadApps(router.Config_AsIs),
+		Inbound:  []*InboundHandlerConfig{reloadInboundConfig("in1", port1), reloadInboundConfig("in2", port2)},
+		Outbound: []*OutboundHandlerConfig{reloadOutboundConfig("out1")},
+	}
+	server, err := New(config)
+	common.Must(err)
+	common.Must(server.Start())
+	defer server.Close()
+
+	ihm := server.GetFeature(inbound.ManagerType()).(inbound.Manager)
+	common.Must(ihm.RemoveHandler(context.Background(), "in2"))
+	common.Must(AddInboundHandler(server, reloadInboundConfig("in3", tcp.PickPort())))
+	in1, err := ihm.GetHandler(context.Background(), "in1")
+	common.Must(err)
+
+	// The config is unchanged, but the running inbounds are not.
+	common.Must(server.Reload(config))
+
+	if h, err := ihm.GetHandler(context.Background(), "in1"); err != nil || h != in1 {
+		t.Error("expected in1 to be kept")
+	}
+	if _, err := ihm.GetHandler(context.Background(), "in2"); err != nil {
+		t.Error("expected in2 removed through the API to be added again: ", err)
+	}
+	if _, err := ihm.GetHandler(context.Background(), "in3"); err == nil {
+		t.Error("expected in3 added through the API to be removed")
+	}
+}
+
+func TestInstanceReloadRollback(t *testing.T) {
+	port2 := tcp.PickPort()
+	server, err := New(&Config{
+		App:      reloadApps(router.Config_AsIs),
+		Inbound:  []*InboundHandlerConfig{reloadInboundConfig("in1", tcp.PickPort()), reloadInboundConfig("in2", port2)},
+		Outbound: []*OutboundHandlerConfig{reloadOutboundConfig("out1"), reloadOutboundConfig("out2")},
+	})
+	common.Must(err)
+	common.Must(server.Start())
+	defer server.Close()
+
+	ihm := server.GetFeature(inbound.ManagerType()).(inbound.Manager)
+	ohm := server.GetFeature(outbound.ManagerType()).(outbound.Manager)
+	in1, err := ihm.GetHandler(context.Background(), "in1")
+	common.Must(err)
+
+	// A config failing to build changes nothing.
+	invalid := reloadOutboundConfig("out3")
+	invalid.ProxySettings = &serial.TypedMessage{Type: "xray.unknown.Config"}
+	if err := server.Reload(&Config{
+		App:      reloadApps(router.Config_AsIs),
+		Inbound:  []*InboundHandlerConfig{reloadInboundConfig("in2", port2)},
+		Outbound: []*OutboundHandlerConfig{reloadOutboundConfig("out1"), invalid},
+	}); err == nil {
+		t.Fatal("expected error reloading an invalid outbound")
+	}
+	if h, err := ihm.GetHandler(context.Background(), "in1"); err != nil || h != in1 {
+		t.Error("expected in1 to be kept after a failed reload")
+	}
+	if ohm.GetHandler("out2") == nil {
+		t.Error("expected out2 to be kept after a failed reload")
+	}
+
+	// An inbound failing to start rolls back the inbounds replaced.
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	common.Must(err)
+	defer listener.Close()
+	busy := net.Port(listener.Addr().(*net.TCPAddr).Port)
+	if err := server.Reload(&Config{
+		App:      reloadApps(router.Config_IpIfNonMatch),
+		Inbound:  []*InboundHandlerConfig{reloadInboundConfig("in1", busy)},
+		Outbound: []*OutboundHandlerConfig{reloadOutboundConfig("out2")},
+	}); err == nil {
+		t.Fatal("expected error reloading an inbound on a port in use")
+	}
+	if _, err := ihm.GetHandler(context.Background(), "in1"); err != nil {
+		t.Error("expected in1 to be restored: ", err)
+	}
+	if _, err := ihm.GetHandler(context.Background(), "in2"); err != nil {
+		t.Error("expected in2 to be restored: ", err)
+	}
+	if ohm.GetHandler("out1") == nil || ohm.GetDefaultHandler().Tag() != "out1" {
+		t.Error("expected out1 to be kept as the default outbound")
+	}
+	conn, err := net.Dial("tcp", net.TCPDestination(net.LocalHostIP, port2).NetAddr())
+	if err != nil {
+		t.Error("expected in2 to listen again: ", err)
+	} else {
+		conn.Close()
+	}
+}
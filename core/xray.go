@@ -87,6 +87,11 @@ type Instance struct {
 	running                    bool
 	resolveLock                sync.Mutex
 
+	// config and appFeatures record the app settings the instance runs with, so that Reload can tell what changed.
+	reloadLock  sync.Mutex
+	config      *Config
+	appFeatures map[string]features.Feature
+
 	ctx context.Context
 }
 
@@ -189,6 +194,12 @@ func NewWithContext(ctx context.Context, config *Config) (*Instance, error) {
 func initInstanceWithConfig(config *Config, server *Instance) (bool, error) {
 	server.ctx = context.WithValue(server.ctx, "cone",
 		platform.NewEnvFlag(platform.UseCone).GetValue(func() string { return "" }) != "true")
+	server.config = &Config{
+		App:      config.App,
+		Inbound:  config.Inbound,
+		Outbound: config.Outbound,
+	}
+	server.appFeatures = make(map[string]features.Feature)
 
 	for _, appSettings := range config.App {
 		settings, err := appSettings.GetInstance()
@@ -203,6 +214,7 @@ func initInstanceWithConfig(config *Config, server *Instance) (bool, error) {
 			if err := server.AddFeature(feature); err != nil {
 				return true, err
 			}
+			server.appFeatures[appSettings.Type] = feature
 		}
 	}
 
@@ -5,6 +5,7 @@ import (
 	"github.com/xtls/xray-core/common/net"
 	"github.com/xtls/xray-core/common/serial"
 	"github.com/xtls/xray-core/features"
+	"golang.org/x/net/dns/dnsmessage"
 )
 
 // IPOption is an object for IP query options.
@@ -31,6 +32,16 @@ func ClientType() interface{} {
 	return (*Client)(nil)
 }
 
+// RecordClient is a Client which also queries records of types other than A and AAAA.
+//
+// xray:api:beta
+type RecordClient interface {
+	Client
+
+	// LookupRecords returns the answers of qType records, like MX and HTTPS, for the given domain.
+	LookupRecords(domain string, qType dnsmessage.Type) ([]dnsmessage.Resource, uint32, error)
+}
+
 // ErrEmptyResponse indicates that DNS query succeeded but no answer was returned.
 var ErrEmptyResponse = errors.New("empty response")
 
@@ -10,3 +10,10 @@ type Feature interface {
 	common.HasType
 	common.Runnable
 }
+
+// Reloadable is implemented by features that can apply a new config in place, without being recreated.
+// Other features keep their references to the feature, so its state must be swapped atomically.
+type Reloadable interface {
+	// Reload applies the given config, which has the same type as the one the feature was created from.
+	Reload(config interface{}) error
+}
@@ -2,11 +2,13 @@ package policy
 
 import (
 	"context"
+	"io"
 	"runtime"
 	"time"
 
 	"github.com/xtls/xray-core/common/platform"
 	"github.com/xtls/xray-core/features"
+	"golang.org/x/time/rate"
 )
 
 // Timeout contains limits for connection timeout.
@@ -37,6 +39,31 @@ type Buffer struct {
 	PerConnection int32
 }
 
+// RateLimit contains settings for bandwidth limits, in bytes per second. 0 for unlimited.
+type RateLimit struct {
+	Uplink   uint64
+	Downlink uint64
+	// Maximum number of bytes that can pass at once. 0 for one second of traffic.
+	UplinkBurst   uint64
+	DownlinkBurst uint64
+}
+
+// Quota contains settings for enforcing user traffic quota and expiry.
+type Quota struct {
+	// Whether or not to close existing connections of users that run out of quota or expire.
+	CloseConnections bool
+}
+
+// ConnectionLimit contains limits on simultaneous connections of a user. 0 for unlimited.
+type ConnectionLimit struct {
+	// Maximum number of distinct source IPs.
+	MaxIPs uint32
+	// Maximum number of simultaneous connections.
+	MaxConnections uint32
+	// Whether or not to close the oldest connections when a limit is reached, instead of rejecting the newest.
+	EvictOldest bool
+}
+
 // SystemStats contains stat policy settings on system level.
 type SystemStats struct {
 	// Whether or not to enable stat counter for uplink traffic in inbound handlers.
@@ -53,13 +80,19 @@ type SystemStats struct {
 type System struct {
 	Stats  SystemStats
 	Buffer Buffer
+	// Bandwidth limits shared by all connections of an inbound, keyed by inbound tag.
+	InboundRateLimit map[string]RateLimit
 }
 
 // Session is session based settings for controlling Xray requests. It contains various settings (or limits) that may differ for different users in the context.
 type Session struct {
-	Timeouts Timeout // Timeout settings
-	Stats    Stats
-	Buffer   Buffer
+	Timeouts  Timeout // Timeout settings
+	Stats     Stats
+	Buffer    Buffer
+	RateLimit RateLimit // Bandwidth limits shared by all connections of a user
+	Quota     Quota
+
+	ConnectionLimit ConnectionLimit
 }
 
 // Manager is a feature that provides Policy for the given user by its id or level.
@@ -75,6 +108,24 @@ type Manager interface {
 	ForSystem() System
 }
 
+// Limiter is an optional interface of Manager, for enforcing bandwidth limits.
+// Limiters are shared by all connections they apply to, and are updated in place when the limits change.
+type Limiter interface {
+	// ForUser returns the uplink and downlink limiters of the given user. Nil for unlimited.
+	ForUser(level uint32, email string) (uplink *rate.Limiter, downlink *rate.Limiter)
+
+	// ForInbound returns the uplink and downlink limiters of the given inbound. Nil for unlimited.
+	ForInbound(tag string) (uplink *rate.Limiter, downlink *rate.Limiter)
+}
+
+// ConnectionLimiter is an optional interface of Manager, for enforcing connection limits of users.
+type ConnectionLimiter interface {
+	// AcquireConnection registers a connection of the given user from the given source IP. It fails if the connection
+	// is over the limits of the user level, unless the oldest connections of the user are evicted by closing them.
+	// The returned function must be called when the connection ends.
+	AcquireConnection(level uint32, email string, source string, conn io.Closer) (release func(), err error)
+}
+
 // ManagerType returns the type of Manager interface. Can be used to implement common.HasType.
 //
 // xray:api:stable
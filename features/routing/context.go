@@ -2,6 +2,7 @@ package routing
 
 import (
 	"github.com/xtls/xray-core/common/net"
+	"github.com/xtls/xray-core/common/process"
 )
 
 // Context is a feature to store connection information for routing.
@@ -47,6 +48,12 @@ type Context interface {
 	// GetAttributes returns extra attributes from the conneciont content.
 	GetAttributes() map[string]string
 
+	// GetALPN returns the protocols offered by the sniffed TLS client hello, if exists.
+	GetALPN() []string
+
 	// GetSkipDNSResolve returns a flag switch for weather skip dns resolve during route pick.
 	GetSkipDNSResolve() bool
+
+	// GetProcess returns the local process the connection was from, or nil if it is not found.
+	GetProcess() *process.Info
 }
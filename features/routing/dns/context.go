@@ -14,6 +14,8 @@ type ResolvableContext struct {
 	routing.Context
 	dnsClient   dns.Client
 	resolvedIPs []net.IP
+	looked      bool
+	lookupErr   error
 }
 
 // GetTargetIPs overrides original routing.Context's implementation.
@@ -23,6 +25,7 @@ func (ctx *ResolvableContext) GetTargetIPs() []net.IP {
 	}
 
 	if domain := ctx.GetTargetDomain(); len(domain) != 0 {
+		ctx.looked = true
 		ips, _, err := ctx.dnsClient.LookupIP(domain, dns.IPOption{
 			IPv4Enable: true,
 			IPv6Enable: true,
@@ -32,6 +35,7 @@ func (ctx *ResolvableContext) GetTargetIPs() []net.IP {
 			ctx.resolvedIPs = ips
 			return ips
 		}
+		ctx.lookupErr = err
 		errors.LogInfoInner(context.Background(), err, "resolve ip for ", domain)
 	}
 
@@ -42,6 +46,11 @@ func (ctx *ResolvableContext) GetTargetIPs() []net.IP {
 	return nil
 }
 
+// Lookup returns whether the target domain has been looked up, and the result of the last lookup.
+func (ctx *ResolvableContext) Lookup() (bool, []net.IP, error) {
+	return ctx.looked, ctx.resolvedIPs, ctx.lookupErr
+}
+
 // ContextWithDNSClient creates a new routing context with domain resolving capability.
 // Resolved domain IPs can be retrieved by GetTargetIPs().
 func ContextWithDNSClient(ctx routing.Context, client dns.Client) routing.Context {
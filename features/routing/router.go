@@ -4,6 +4,7 @@ import (
 	"github.com/xtls/xray-core/common"
 	"github.com/xtls/xray-core/common/serial"
 	"github.com/xtls/xray-core/features"
+	"github.com/xtls/xray-core/features/stats"
 )
 
 // Router is a feature to choose an outbound tag for the given request.
@@ -35,6 +36,14 @@ type Route interface {
 	GetRuleTag() string
 }
 
+// TrafficCountedRoute is a Route which counts the traffic dispatched by it.
+type TrafficCountedRoute interface {
+	Route
+
+	// GetTrafficCounters returns the counters of uplink and downlink traffic, or nil if traffic is not counted.
+	GetTrafficCounters() (uplink stats.Counter, downlink stats.Counter)
+}
+
 // RouterType return the type of Router interface. Can be used to implement common.HasType.
 //
 // xray:api:stable
@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/xtls/xray-core/common/net"
+	"github.com/xtls/xray-core/common/process"
 	"github.com/xtls/xray-core/common/session"
 	"github.com/xtls/xray-core/features/routing"
 )
@@ -13,6 +14,9 @@ type Context struct {
 	Inbound  *session.Inbound
 	Outbound *session.Outbound
 	Content  *session.Content
+
+	process       *process.Info
+	processLooked bool
 }
 
 // GetInboundTag implements routing.Context.
@@ -144,6 +148,14 @@ func (ctx *Context) GetAttributes() map[string]string {
 	return ctx.Content.Attributes
 }
 
+// GetALPN implements routing.Context.
+func (ctx *Context) GetALPN() []string {
+	if ctx.Content == nil {
+		return nil
+	}
+	return ctx.Content.ALPN
+}
+
 // GetSkipDNSResolve implements routing.Context.
 func (ctx *Context) GetSkipDNSResolve() bool {
 	if ctx.Content == nil {
@@ -152,6 +164,17 @@ func (ctx *Context) GetSkipDNSResolve() bool {
 	return ctx.Content.SkipDNSResolve
 }
 
+// GetProcess implements routing.Context. The process is looked up on first call.
+func (ctx *Context) GetProcess() *process.Info {
+	if !ctx.processLooked {
+		ctx.processLooked = true
+		if ctx.Inbound != nil {
+			ctx.process, _ = process.FindProcess(ctx.GetNetwork(), ctx.Inbound.Source)
+		}
+	}
+	return ctx.process
+}
+
 // AsRoutingContext creates a context from context.context with session info.
 func AsRoutingContext(ctx context.Context) routing.Context {
 	outbounds := session.OutboundsFromContext(ctx)
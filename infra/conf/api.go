@@ -4,6 +4,8 @@ import (
 	"strings"
 
 	"github.com/xtls/xray-core/app/commander"
+	reloadservice "github.com/xtls/xray-core/app/commander/command"
+	connectionservice "github.com/xtls/xray-core/app/dispatcher/command"
 	loggerservice "github.com/xtls/xray-core/app/log/command"
 	observatoryservice "github.com/xtls/xray-core/app/observatory/command"
 	handlerservice "github.com/xtls/xray-core/app/proxyman/command"
@@ -39,6 +41,10 @@ func (c *APIConfig) Build() (*commander.Config, error) {
 			services = append(services, serial.ToTypedMessage(&observatoryservice.Config{}))
 		case "routingservice":
 			services = append(services, serial.ToTypedMessage(&routerservice.Config{}))
+		case "reloadservice":
+			services = append(services, serial.ToTypedMessage(&reloadservice.Config{}))
+		case "connectionservice":
+			services = append(services, serial.ToTypedMessage(&connectionservice.Config{}))
 		}
 	}
 
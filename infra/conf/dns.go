@@ -2,6 +2,7 @@ package conf
 
 import (
 	"bufio"
+	"encoding/base64"
 	"encoding/json"
 	"os"
 	"path/filepath"
@@ -16,19 +17,25 @@ import (
 )
 
 type NameServerConfig struct {
-	Address       *Address   `json:"address"`
-	ClientIP      *Address   `json:"clientIp"`
-	Port          uint16     `json:"port"`
-	SkipFallback  bool       `json:"skipFallback"`
-	Domains       []string   `json:"domains"`
-	ExpectedIPs   StringList `json:"expectedIPs"`
-	ExpectIPs     StringList `json:"expectIPs"`
-	QueryStrategy string     `json:"queryStrategy"`
-	Tag           string     `json:"tag"`
-	TimeoutMs     uint64     `json:"timeoutMs"`
-	DisableCache  bool       `json:"disableCache"`
-	FinalQuery    bool       `json:"finalQuery"`
-	UnexpectedIPs StringList `json:"unexpectedIPs"`
+	Address                              *Address   `json:"address"`
+	ClientIP                             *Address   `json:"clientIp"`
+	Port                                 uint16     `json:"port"`
+	SkipFallback                         bool       `json:"skipFallback"`
+	Domains                              []string   `json:"domains"`
+	ExpectedIPs                          StringList `json:"expectedIPs"`
+	ExpectIPs                            StringList `json:"expectIPs"`
+	QueryStrategy                        string     `json:"queryStrategy"`
+	Tag                                  string     `json:"tag"`
+	TimeoutMs                            uint64     `json:"timeoutMs"`
+	DisableCache                         bool       `json:"disableCache"`
+	FinalQuery                           bool       `json:"finalQuery"`
+	UnexpectedIPs                        StringList `json:"unexpectedIPs"`
+	ServerName                           string     `json:"serverName"`
+	PinnedPeerCertificateChainSha256     []string   `json:"pinnedPeerCertificateChainSha256"`
+	PinnedPeerCertificatePublicKeySha256 []string   `json:"pinnedPeerCertificatePublicKeySha256"`
+	ServeStale                           bool       `json:"serveStale"`
+	MaxStaleSeconds                      uint32     `json:"maxStaleSeconds"`
+	Prefetch                             bool       `json:"prefetch"`
 }
 
 // UnmarshalJSON implements encoding/json.Unmarshaler.UnmarshalJSON
@@ -40,19 +47,25 @@ func (c *NameServerConfig) UnmarshalJSON(data []byte) error {
 	}
 
 	var advanced struct {
-		Address       *Address   `json:"address"`
-		ClientIP      *Address   `json:"clientIp"`
-		Port          uint16     `json:"port"`
-		SkipFallback  bool       `json:"skipFallback"`
-		Domains       []string   `json:"domains"`
-		ExpectedIPs   StringList `json:"expectedIPs"`
-		ExpectIPs     StringList `json:"expectIPs"`
-		QueryStrategy string     `json:"queryStrategy"`
-		Tag           string     `json:"tag"`
-		TimeoutMs     uint64     `json:"timeoutMs"`
-		DisableCache  bool       `json:"disableCache"`
-		FinalQuery    bool       `json:"finalQuery"`
-		UnexpectedIPs StringList `json:"unexpectedIPs"`
+		Address                              *Address   `json:"address"`
+		ClientIP                             *Address   `json:"clientIp"`
+		Port                                 uint16     `json:"port"`
+		SkipFallback                         bool       `json:"skipFallback"`
+		Domains                              []string   `json:"domains"`
+		ExpectedIPs                          StringList `json:"expectedIPs"`
+		ExpectIPs                            StringList `json:"expectIPs"`
+		QueryStrategy                        string     `json:"queryStrategy"`
+		Tag                                  string     `json:"tag"`
+		TimeoutMs                            uint64     `json:"timeoutMs"`
+		DisableCache                         bool       `json:"disableCache"`
+		FinalQuery                           bool       `json:"finalQuery"`
+		UnexpectedIPs                        StringList `json:"unexpectedIPs"`
+		ServerName                           string     `json:"serverName"`
+		PinnedPeerCertificateChainSha256     []string   `json:"pinnedPeerCertificateChainSha256"`
+		PinnedPeerCertificatePublicKeySha256 []string   `json:"pinnedPeerCertificatePublicKeySha256"`
+		ServeStale                           bool       `json:"serveStale"`
+		MaxStaleSeconds                      uint32     `json:"maxStaleSeconds"`
+		Prefetch                             bool       `json:"prefetch"`
 	}
 	if err := json.Unmarshal(data, &advanced); err == nil {
 		c.Address = advanced.Address
@@ -68,6 +81,12 @@ func (c *NameServerConfig) UnmarshalJSON(data []byte) error {
 		c.DisableCache = advanced.DisableCache
 		c.FinalQuery = advanced.FinalQuery
 		c.UnexpectedIPs = advanced.UnexpectedIPs
+		c.ServerName = advanced.ServerName
+		c.PinnedPeerCertificateChainSha256 = advanced.PinnedPeerCertificateChainSha256
+		c.PinnedPeerCertificatePublicKeySha256 = advanced.PinnedPeerCertificatePublicKeySha256
+		c.ServeStale = advanced.ServeStale
+		c.MaxStaleSeconds = advanced.MaxStaleSeconds
+		c.Prefetch = advanced.Prefetch
 		return nil
 	}
 
@@ -157,6 +176,26 @@ func (c *NameServerConfig) Build() (*dns.NameServer, error) {
 		myClientIP = []byte(c.ClientIP.IP())
 	}
 
+	decodePins := func(pins []string) ([][]byte, error) {
+		var hashes [][]byte
+		for _, v := range pins {
+			hashValue, err := base64.StdEncoding.DecodeString(v)
+			if err != nil {
+				return nil, errors.New("invalid pinned SHA256 hash: ", v).Base(err)
+			}
+			hashes = append(hashes, hashValue)
+		}
+		return hashes, nil
+	}
+	pinnedChains, err := decodePins(c.PinnedPeerCertificateChainSha256)
+	if err != nil {
+		return nil, err
+	}
+	pinnedPublicKeys, err := decodePins(c.PinnedPeerCertificatePublicKeySha256)
+	if err != nil {
+		return nil, err
+	}
+
 	return &dns.NameServer{
 		Address: &net.Endpoint{
 			Network: net.Network_UDP,
@@ -176,6 +215,13 @@ func (c *NameServerConfig) Build() (*dns.NameServer, error) {
 		FinalQuery:        c.FinalQuery,
 		UnexpectedGeoip:   unexpectedGeoipList,
 		ActUnprior:        actUnprior,
+		ServerName:        c.ServerName,
+
+		PinnedPeerCertificateChainSha256:     pinnedChains,
+		PinnedPeerCertificatePublicKeySha256: pinnedPublicKeys,
+		ServeStale:                           c.ServeStale,
+		MaxStaleSeconds:                      c.MaxStaleSeconds,
+		Prefetch:                             c.Prefetch,
 	}, nil
 }
 
@@ -197,6 +243,8 @@ type DNSConfig struct {
 	DisableFallback        bool                `json:"disableFallback"`
 	DisableFallbackIfMatch bool                `json:"disableFallbackIfMatch"`
 	UseSystemHosts         bool                `json:"useSystemHosts"`
+	CachePersistPath       string              `json:"cachePersistPath"`
+	CachePersistInterval   uint32              `json:"cachePersistInterval"`
 }
 
 type HostAddress struct {
@@ -394,6 +442,8 @@ func (c *DNSConfig) Build() (*dns.Config, error) {
 		DisableFallback:        c.DisableFallback,
 		DisableFallbackIfMatch: c.DisableFallbackIfMatch,
 		QueryStrategy:          resolveQueryStrategy(c.QueryStrategy),
+		CachePersistPath:       c.CachePersistPath,
+		CachePersistInterval:   c.CachePersistInterval,
 	}
 
 	if c.ClientIP != nil {
@@ -4,6 +4,7 @@ import (
 	"github.com/xtls/xray-core/common/errors"
 	"github.com/xtls/xray-core/common/net"
 	"github.com/xtls/xray-core/proxy/dns"
+	"github.com/xtls/xray-core/transport/internet/tls"
 	"google.golang.org/protobuf/proto"
 )
 
@@ -28,7 +29,7 @@ func (c *DNSOutboundConfig) Build() (proto.Message, error) {
 		config.Server.Address = c.Address.Build()
 	}
 	switch c.NonIPQuery {
-	case "", "reject", "drop", "skip":
+	case "", "reject", "drop", "skip", "resolve":
 	default:
 		return nil, errors.New(`unknown "nonIPQuery": `, c.NonIPQuery)
 	}
@@ -36,3 +37,41 @@ func (c *DNSOutboundConfig) Build() (proto.Message, error) {
 	config.BlockTypes = c.BlockTypes
 	return config, nil
 }
+
+type DNSInboundConfig struct {
+	Protocol    string       `json:"protocol"`
+	Path        string       `json:"path"`
+	Network     *NetworkList `json:"network"`
+	TLSSettings *TLSConfig   `json:"tlsSettings"`
+	UserLevel   uint32       `json:"userLevel"`
+}
+
+func (c *DNSInboundConfig) Build() (proto.Message, error) {
+	config := &dns.ServerConfig{
+		Protocol:  c.Protocol,
+		Path:      c.Path,
+		Networks:  c.Network.Build(),
+		UserLevel: c.UserLevel,
+	}
+	switch config.Protocol {
+	case "dot", "doh":
+	case "":
+		config.Protocol = "doh"
+	default:
+		return nil, errors.New(`unknown "protocol": `, c.Protocol)
+	}
+	if c.Path != "" && (config.Protocol != "doh" || c.Path[0] != '/') {
+		return nil, errors.New(`invalid "path": `, c.Path)
+	}
+	if c.TLSSettings != nil {
+		ts, err := c.TLSSettings.Build()
+		if err != nil {
+			return nil, errors.New("failed to build TLS config").Base(err)
+		}
+		config.TlsSettings = ts.(*tls.Config)
+	}
+	if net.HasNetwork(config.Networks, net.Network_UDP) && (config.Protocol != "doh" || config.TlsSettings == nil) {
+		return nil, errors.New(`"udp" is only for DNS over HTTP/3, which requires "tlsSettings"`)
+	}
+	return config, nil
+}
@@ -6,6 +6,7 @@ import (
 	"github.com/xtls/xray-core/common/net"
 	. "github.com/xtls/xray-core/infra/conf"
 	"github.com/xtls/xray-core/proxy/dns"
+	"github.com/xtls/xray-core/transport/internet/tls"
 )
 
 func TestDnsProxyConfig(t *testing.T) {
@@ -29,5 +30,66 @@ func TestDnsProxyConfig(t *testing.T) {
 				},
 			},
 		},
+		{
+			Input: `{
+				"nonIPQuery": "resolve"
+			}`,
+			Parser: loadJSON(creator),
+			Output: &dns.Config{
+				Server:      &net.Endpoint{},
+				Non_IPQuery: "resolve",
+			},
+		},
 	})
 }
+
+func TestDnsInboundConfig(t *testing.T) {
+	creator := func() Buildable {
+		return new(DNSInboundConfig)
+	}
+
+	runMultiTestCase(t, []TestCase{
+		{
+			Input: `{
+				"protocol": "dot"
+			}`,
+			Parser: loadJSON(creator),
+			Output: &dns.ServerConfig{
+				Protocol: "dot",
+				Networks: []net.Network{net.Network_TCP},
+			},
+		},
+		{
+			Input: `{
+				"path": "/resolve",
+				"network": "tcp,udp",
+				"tlsSettings": {
+					"alpn": ["h2", "http/1.1"]
+				},
+				"userLevel": 1
+			}`,
+			Parser: loadJSON(creator),
+			Output: &dns.ServerConfig{
+				Protocol: "doh",
+				Path:     "/resolve",
+				Networks: []net.Network{net.Network_TCP, net.Network_UDP},
+				TlsSettings: &tls.Config{
+					NextProtocol: []string{"h2", "http/1.1"},
+				},
+				UserLevel: 1,
+			},
+		},
+	})
+}
+
+func TestDnsInboundConfigUDPWithoutTLS(t *testing.T) {
+	_, err := loadJSON(func() Buildable {
+		return new(DNSInboundConfig)
+	})(`{
+		"protocol": "doh",
+		"network": "udp"
+	}`)
+	if err == nil {
+		t.Error("expect an error of DNS over HTTP/3 without TLS settings")
+	}
+}
@@ -103,5 +103,41 @@ func TestDNSConfigParsing(t *testing.T) {
 				DisableFallback: true,
 			},
 		},
+		{
+			Input: `{
+				"servers": [{
+					"address": "tls://dns.example.com",
+					"serverName": "example.com",
+					"pinnedPeerCertificatePublicKeySha256": ["AQID"],
+					"serveStale": true,
+					"maxStaleSeconds": 3600,
+					"prefetch": true
+				}],
+				"cachePersistPath": "dns.json",
+				"cachePersistInterval": 300
+			}`,
+			Parser: parserCreator(),
+			Output: &dns.Config{
+				NameServer: []*dns.NameServer{
+					{
+						Address: &net.Endpoint{
+							Address: &net.IPOrDomain{
+								Address: &net.IPOrDomain_Domain{
+									Domain: "tls://dns.example.com",
+								},
+							},
+							Network: net.Network_UDP,
+						},
+						ServerName:                           "example.com",
+						PinnedPeerCertificatePublicKeySha256: [][]byte{{1, 2, 3}},
+						ServeStale:                           true,
+						MaxStaleSeconds:                      3600,
+						Prefetch:                             true,
+					},
+				},
+				CachePersistPath:     "dns.json",
+				CachePersistInterval: 300,
+			},
+		},
 	})
 }
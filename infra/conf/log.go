@@ -2,9 +2,12 @@ package conf
 
 import (
 	"strings"
+	"time"
 
 	"github.com/xtls/xray-core/app/log"
+	"github.com/xtls/xray-core/common/errors"
 	clog "github.com/xtls/xray-core/common/log"
+	"github.com/xtls/xray-core/infra/conf/cfgcommon/duration"
 )
 
 func DefaultLogConfig() *log.Config {
@@ -16,16 +19,46 @@ func DefaultLogConfig() *log.Config {
 }
 
 type LogConfig struct {
-	AccessLog   string `json:"access"`
-	ErrorLog    string `json:"error"`
-	LogLevel    string `json:"loglevel"`
-	DNSLog      bool   `json:"dnsLog"`
-	MaskAddress string `json:"maskAddress"`
+	AccessLog    string             `json:"access"`
+	ErrorLog     string             `json:"error"`
+	LogLevel     string             `json:"loglevel"`
+	DNSLog       bool               `json:"dnsLog"`
+	MaskAddress  string             `json:"maskAddress"`
+	ErrorFormat  string             `json:"errorFormat"`
+	AccessFormat string             `json:"accessFormat"`
+	Rotate       *LogRotationConfig `json:"rotate"`
 }
 
-func (v *LogConfig) Build() *log.Config {
+type LogRotationConfig struct {
+	MaxSize    uint32            `json:"maxSize"` // In MB
+	MaxAge     duration.Duration `json:"maxAge"`
+	MaxBackups uint32            `json:"maxBackups"`
+	Compress   bool              `json:"compress"`
+}
+
+func (c *LogRotationConfig) Build() *log.LogRotation {
+	return &log.LogRotation{
+		MaxSize:    int64(c.MaxSize) * 1024 * 1024,
+		MaxAge:     int64(time.Duration(c.MaxAge) / time.Second),
+		MaxBackups: c.MaxBackups,
+		Compress:   c.Compress,
+	}
+}
+
+func parseLogFormat(format string) (log.LogFormat, error) {
+	switch strings.ToLower(format) {
+	case "", "text":
+		return log.LogFormat_Text, nil
+	case "json":
+		return log.LogFormat_JSON, nil
+	default:
+		return log.LogFormat_Text, errors.New("unknown log format: ", format)
+	}
+}
+
+func (v *LogConfig) Build() (*log.Config, error) {
 	if v == nil {
-		return nil
+		return nil, nil
 	}
 	config := &log.Config{
 		ErrorLogType:  log.LogType_Console,
@@ -61,5 +94,15 @@ func (v *LogConfig) Build() *log.Config {
 		config.ErrorLogLevel = clog.Severity_Warning
 	}
 	config.MaskAddress = v.MaskAddress
-	return config
+	var err error
+	if config.ErrorLogFormat, err = parseLogFormat(v.ErrorFormat); err != nil {
+		return nil, err
+	}
+	if config.AccessLogFormat, err = parseLogFormat(v.AccessFormat); err != nil {
+		return nil, err
+	}
+	if v.Rotate != nil {
+		config.Rotation = v.Rotate.Build()
+	}
+	return config, nil
 }
@@ -0,0 +1,40 @@
+package conf_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/xtls/xray-core/app/log"
+	"github.com/xtls/xray-core/common"
+	. "github.com/xtls/xray-core/infra/conf"
+)
+
+func TestLogConfigFormat(t *testing.T) {
+	testCases := []struct {
+		input        string
+		errorFormat  log.LogFormat
+		accessFormat log.LogFormat
+		err          bool
+	}{
+		{input: `{}`, errorFormat: log.LogFormat_Text, accessFormat: log.LogFormat_Text},
+		{input: `{"errorFormat": "text", "accessFormat": "JSON"}`, errorFormat: log.LogFormat_Text, accessFormat: log.LogFormat_JSON},
+		{input: `{"errorFormat": "json"}`, errorFormat: log.LogFormat_JSON, accessFormat: log.LogFormat_Text},
+		{input: `{"accessFormat": "logfmt"}`, err: true},
+		{input: `{"errorFormat": "jsno"}`, err: true},
+	}
+	for _, test := range testCases {
+		c := &LogConfig{}
+		common.Must(json.Unmarshal([]byte(test.input), c))
+		config, err := c.Build()
+		if test.err {
+			if err == nil {
+				t.Error("expected error building ", test.input)
+			}
+			continue
+		}
+		common.Must(err)
+		if config.ErrorLogFormat != test.errorFormat || config.AccessLogFormat != test.accessFormat {
+			t.Error("unexpected formats of ", test.input, ": ", config.ErrorLogFormat, " ", config.AccessLogFormat)
+		}
+	}
+}
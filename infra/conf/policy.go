@@ -4,15 +4,61 @@ import (
 	"github.com/xtls/xray-core/app/policy"
 )
 
+// RateLimit is the bandwidth limit in KB per second. 0 for unlimited.
+type RateLimit struct {
+	Uplink        uint64 `json:"uplink"`
+	Downlink      uint64 `json:"downlink"`
+	UplinkBurst   uint64 `json:"uplinkBurst"`
+	DownlinkBurst uint64 `json:"downlinkBurst"`
+}
+
+func (r *RateLimit) Build() *policy.Policy_RateLimit {
+	return &policy.Policy_RateLimit{
+		Uplink:        r.Uplink * 1024,
+		Downlink:      r.Downlink * 1024,
+		UplinkBurst:   r.UplinkBurst * 1024,
+		DownlinkBurst: r.DownlinkBurst * 1024,
+	}
+}
+
+// Quota is the handling of users running out of quota or expiring.
+type Quota struct {
+	CloseConnections bool `json:"closeConnections"`
+}
+
+func (q *Quota) Build() *policy.Policy_Quota {
+	return &policy.Policy_Quota{
+		CloseConnections: q.CloseConnections,
+	}
+}
+
+// ConnectionLimit is the limit on simultaneous connections and source IPs of a user. 0 for unlimited.
+type ConnectionLimit struct {
+	MaxIPs         uint32 `json:"maxIPs"`
+	MaxConnections uint32 `json:"maxConnections"`
+	EvictOldest    bool   `json:"evictOldest"`
+}
+
+func (l *ConnectionLimit) Build() *policy.Policy_ConnectionLimit {
+	return &policy.Policy_ConnectionLimit{
+		MaxIps:         l.MaxIPs,
+		MaxConnections: l.MaxConnections,
+		EvictOldest:    l.EvictOldest,
+	}
+}
+
 type Policy struct {
-	Handshake         *uint32 `json:"handshake"`
-	ConnectionIdle    *uint32 `json:"connIdle"`
-	UplinkOnly        *uint32 `json:"uplinkOnly"`
-	DownlinkOnly      *uint32 `json:"downlinkOnly"`
-	StatsUserUplink   bool    `json:"statsUserUplink"`
-	StatsUserDownlink bool    `json:"statsUserDownlink"`
-	StatsUserOnline   bool    `json:"statsUserOnline"`
-	BufferSize        *int32  `json:"bufferSize"`
+	Handshake         *uint32          `json:"handshake"`
+	ConnectionIdle    *uint32          `json:"connIdle"`
+	UplinkOnly        *uint32          `json:"uplinkOnly"`
+	DownlinkOnly      *uint32          `json:"downlinkOnly"`
+	StatsUserUplink   bool             `json:"statsUserUplink"`
+	StatsUserDownlink bool             `json:"statsUserDownlink"`
+	StatsUserOnline   bool             `json:"statsUserOnline"`
+	BufferSize        *int32           `json:"bufferSize"`
+	RateLimit         *RateLimit       `json:"rateLimit"`
+	Quota             *Quota           `json:"quota"`
+	ConnectionLimit   *ConnectionLimit `json:"connectionLimit"`
 }
 
 func (t *Policy) Build() (*policy.Policy, error) {
@@ -49,25 +95,45 @@ func (t *Policy) Build() (*policy.Policy, error) {
 		}
 	}
 
+	if t.RateLimit != nil {
+		p.RateLimit = t.RateLimit.Build()
+	}
+	if t.Quota != nil {
+		p.Quota = t.Quota.Build()
+	}
+	if t.ConnectionLimit != nil {
+		p.ConnectionLimit = t.ConnectionLimit.Build()
+	}
+
 	return p, nil
 }
 
 type SystemPolicy struct {
-	StatsInboundUplink    bool `json:"statsInboundUplink"`
-	StatsInboundDownlink  bool `json:"statsInboundDownlink"`
-	StatsOutboundUplink   bool `json:"statsOutboundUplink"`
-	StatsOutboundDownlink bool `json:"statsOutboundDownlink"`
+	StatsInboundUplink    bool                  `json:"statsInboundUplink"`
+	StatsInboundDownlink  bool                  `json:"statsInboundDownlink"`
+	StatsOutboundUplink   bool                  `json:"statsOutboundUplink"`
+	StatsOutboundDownlink bool                  `json:"statsOutboundDownlink"`
+	InboundRateLimit      map[string]*RateLimit `json:"inboundRateLimit"`
 }
 
 func (p *SystemPolicy) Build() (*policy.SystemPolicy, error) {
-	return &policy.SystemPolicy{
+	config := &policy.SystemPolicy{
 		Stats: &policy.SystemPolicy_Stats{
 			InboundUplink:    p.StatsInboundUplink,
 			InboundDownlink:  p.StatsInboundDownlink,
 			OutboundUplink:   p.StatsOutboundUplink,
 			OutboundDownlink: p.StatsOutboundDownlink,
 		},
-	}, nil
+	}
+	if len(p.InboundRateLimit) > 0 {
+		config.InboundRateLimit = make(map[string]*policy.Policy_RateLimit, len(p.InboundRateLimit))
+		for tag, r := range p.InboundRateLimit {
+			if r != nil {
+				config.InboundRateLimit[tag] = r.Build()
+			}
+		}
+	}
+	return config, nil
 }
 
 type PolicyConfig struct {
@@ -2,15 +2,18 @@ package conf
 
 import (
 	"encoding/json"
+	"os/user"
 	"runtime"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/xtls/xray-core/app/router"
 	"github.com/xtls/xray-core/common/errors"
 	"github.com/xtls/xray-core/common/net"
 	"github.com/xtls/xray-core/common/platform/filesystem"
 	"github.com/xtls/xray-core/common/serial"
+	"github.com/xtls/xray-core/infra/conf/cfgcommon/duration"
 	"google.golang.org/protobuf/proto"
 )
 
@@ -21,10 +24,29 @@ type StrategyConfig struct {
 }
 
 type BalancingRule struct {
-	Tag         string         `json:"tag"`
-	Selectors   StringList     `json:"selector"`
-	Strategy    StrategyConfig `json:"strategy"`
-	FallbackTag string         `json:"fallbackTag"`
+	Tag         string            `json:"tag"`
+	Selectors   StringList        `json:"selector"`
+	Strategy    StrategyConfig    `json:"strategy"`
+	FallbackTag string            `json:"fallbackTag"`
+	Failover    []StringList      `json:"failover"`
+	Hysteresis  *HysteresisConfig `json:"hysteresis"`
+}
+
+type HysteresisConfig struct {
+	DeadAfter  duration.Duration `json:"deadAfter"`
+	AliveAfter duration.Duration `json:"aliveAfter"`
+	HoldTime   duration.Duration `json:"holdTime"`
+}
+
+func (c *HysteresisConfig) Build() (*router.BalancerHysteresis, error) {
+	if c.DeadAfter < 0 || c.AliveAfter < 0 || c.HoldTime < 0 {
+		return nil, errors.New("negative duration in hysteresis")
+	}
+	return &router.BalancerHysteresis{
+		DeadAfter:  int64(c.DeadAfter),
+		AliveAfter: int64(c.AliveAfter),
+		HoldTime:   int64(c.HoldTime),
+	}, nil
 }
 
 // Build builds the balancing rule
@@ -40,7 +62,7 @@ func (r *BalancingRule) Build() (*router.BalancingRule, error) {
 	switch r.Strategy.Type {
 	case "":
 		r.Strategy.Type = strategyRandom
-	case strategyRandom, strategyLeastLoad, strategyLeastPing, strategyRoundRobin:
+	case strategyRandom, strategyLeastLoad, strategyLeastPing, strategyRoundRobin, strategyConsistentHash:
 	default:
 		return nil, errors.New("unknown balancing strategy: " + r.Strategy.Type)
 	}
@@ -61,19 +83,64 @@ func (r *BalancingRule) Build() (*router.BalancingRule, error) {
 		}
 	}
 
-	return &router.BalancingRule{
+	rule := &router.BalancingRule{
 		Strategy:         r.Strategy.Type,
 		StrategySettings: serial.ToTypedMessage(ts),
 		FallbackTag:      r.FallbackTag,
 		OutboundSelector: r.Selectors,
 		Tag:              r.Tag,
-	}, nil
+	}
+	for _, selectors := range r.Failover {
+		if len(selectors) == 0 {
+			return nil, errors.New("empty selector list in failover group")
+		}
+		rule.FailoverGroup = append(rule.FailoverGroup, &router.FailoverGroup{
+			OutboundSelector: selectors,
+		})
+	}
+	if r.Hysteresis != nil {
+		if rule.Hysteresis, err = r.Hysteresis.Build(); err != nil {
+			return nil, err
+		}
+	}
+	return rule, nil
 }
 
 type RouterConfig struct {
 	RuleList       []json.RawMessage `json:"rules"`
 	DomainStrategy *string           `json:"domainStrategy"`
 	Balancers      []*BalancingRule  `json:"balancers"`
+	RuleSets       []*RuleSetConfig  `json:"ruleSets"`
+}
+
+type RuleSetConfig struct {
+	Tag             string            `json:"tag"`
+	Path            string            `json:"path"`
+	Format          string            `json:"format"`
+	RefreshInterval duration.Duration `json:"refreshInterval"`
+}
+
+func (c *RuleSetConfig) Build() (*router.RuleSet, error) {
+	if c.Tag == "" {
+		return nil, errors.New("empty rule set tag")
+	}
+	if c.Path == "" {
+		return nil, errors.New("empty path of rule set ", c.Tag)
+	}
+	config := &router.RuleSet{
+		Tag:             c.Tag,
+		Path:            c.Path,
+		RefreshInterval: uint32(time.Duration(c.RefreshInterval) / time.Second),
+	}
+	switch strings.ToLower(c.Format) {
+	case "", "text":
+		config.Format = router.RuleSet_Text
+	case "protobuf":
+		config.Format = router.RuleSet_Protobuf
+	default:
+		return nil, errors.New("unknown format of rule set ", c.Tag, ": ", c.Format)
+	}
+	return config, nil
 }
 
 func (c *RouterConfig) getDomainStrategy() router.Config_DomainStrategy {
@@ -118,6 +185,13 @@ func (c *RouterConfig) Build() (*router.Config, error) {
 		}
 		config.BalancingRule = append(config.BalancingRule, balancer)
 	}
+	for _, rawRuleSet := range c.RuleSets {
+		ruleSet, err := rawRuleSet.Build()
+		if err != nil {
+			return nil, err
+		}
+		config.RuleSet = append(config.RuleSet, ruleSet)
+	}
 	return config, nil
 }
 
@@ -127,6 +201,171 @@ type RouterRule struct {
 	BalancerTag string `json:"balancerTag"`
 }
 
+type TimeWindowConfig struct {
+	Days *StringList `json:"days"`
+	Time string      `json:"time"`
+}
+
+var weekdayNames = []string{"sun", "mon", "tue", "wed", "thu", "fri", "sat"}
+
+func parseWeekday(s string) (uint32, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	if len(s) >= 3 {
+		for i, name := range weekdayNames {
+			if strings.HasPrefix(s, name) {
+				return uint32(i), nil
+			}
+		}
+	}
+	return 0, errors.New("invalid weekday: ", s)
+}
+
+// parseTimeOfDay parses "hh:mm" into minutes after midnight. "24:00" is allowed as the end of a day.
+func parseTimeOfDay(s string) (uint32, error) {
+	hour, minute, found := strings.Cut(strings.TrimSpace(s), ":")
+	if !found {
+		return 0, errors.New("invalid time of day: ", s)
+	}
+	h, err := strconv.ParseUint(hour, 10, 32)
+	if err != nil {
+		return 0, errors.New("invalid time of day: ", s).Base(err)
+	}
+	m, err := strconv.ParseUint(minute, 10, 32)
+	if err != nil {
+		return 0, errors.New("invalid time of day: ", s).Base(err)
+	}
+	if m >= 60 || h*60+m > 24*60 {
+		return 0, errors.New("invalid time of day: ", s)
+	}
+	return uint32(h*60 + m), nil
+}
+
+func (c *TimeWindowConfig) Build() (*router.TimeWindow, error) {
+	window := &router.TimeWindow{}
+	if c.Days != nil {
+		for _, days := range *c.Days {
+			first, last, isRange := strings.Cut(days, "-")
+			from, err := parseWeekday(first)
+			if err != nil {
+				return nil, err
+			}
+			to := from
+			if isRange {
+				if to, err = parseWeekday(last); err != nil {
+					return nil, err
+				}
+			}
+			for d := from; ; d = (d + 1) % 7 {
+				window.Weekdays = append(window.Weekdays, d)
+				if d == to {
+					break
+				}
+			}
+		}
+	}
+	if c.Time == "" {
+		return window, nil
+	}
+	start, end, found := strings.Cut(c.Time, "-")
+	if !found {
+		return nil, errors.New("invalid time range: ", c.Time)
+	}
+	var err error
+	if window.Start, err = parseTimeOfDay(start); err != nil {
+		return nil, err
+	}
+	if window.End, err = parseTimeOfDay(end); err != nil {
+		return nil, err
+	}
+	if window.Start == 24*60 {
+		return nil, errors.New("invalid time range: ", c.Time)
+	}
+	return window, nil
+}
+
+type ScheduleConfig struct {
+	TimeZone string              `json:"timeZone"`
+	Windows  []*TimeWindowConfig `json:"windows"`
+}
+
+func (c *ScheduleConfig) Build() (*router.Schedule, error) {
+	if len(c.Windows) == 0 {
+		return nil, errors.New("no time window in schedule")
+	}
+	schedule := &router.Schedule{
+		TimeZone: c.TimeZone,
+	}
+	for _, w := range c.Windows {
+		window, err := w.Build()
+		if err != nil {
+			return nil, err
+		}
+		schedule.Window = append(schedule.Window, window)
+	}
+	return schedule, nil
+}
+
+// IDList is a list of UIDs or GIDs. An entry is either a number, or a name looked up on this host.
+type IDList []string
+
+// UnmarshalJSON implements encoding/json.Unmarshaler.UnmarshalJSON
+func (l *IDList) UnmarshalJSON(data []byte) error {
+	var entries []json.RawMessage
+	if err := json.Unmarshal(data, &entries); err != nil {
+		entries = []json.RawMessage{data}
+	}
+	for _, entry := range entries {
+		var number uint32
+		if err := json.Unmarshal(entry, &number); err == nil {
+			*l = append(*l, strconv.FormatUint(uint64(number), 10))
+			continue
+		}
+		var name string
+		if err := json.Unmarshal(entry, &name); err != nil {
+			return errors.New("invalid ID: ", string(entry))
+		}
+		*l = append(*l, name)
+	}
+	return nil
+}
+
+// Build converts the list to IDs, looking up names with lookup.
+func (l IDList) Build(lookup func(name string) (string, error)) ([]uint32, error) {
+	ids := make([]uint32, 0, len(l))
+	for _, s := range l {
+		if id, err := strconv.ParseUint(s, 10, 32); err == nil {
+			ids = append(ids, uint32(id))
+			continue
+		}
+		idStr, err := lookup(s)
+		if err != nil {
+			return nil, err
+		}
+		id, err := strconv.ParseUint(idStr, 10, 32)
+		if err != nil {
+			return nil, errors.New("invalid ID of ", s, ": ", idStr)
+		}
+		ids = append(ids, uint32(id))
+	}
+	return ids, nil
+}
+
+func lookupUID(name string) (string, error) {
+	u, err := user.Lookup(name)
+	if err != nil {
+		return "", err
+	}
+	return u.Uid, nil
+}
+
+func lookupGID(name string) (string, error) {
+	g, err := user.LookupGroup(name)
+	if err != nil {
+		return "", err
+	}
+	return g.Gid, nil
+}
+
 func ParseIP(s string) (*router.CIDR, error) {
 	var addr, mask string
 	i := strings.Index(s, "/")
@@ -520,8 +759,54 @@ func ToCidrList(ips StringList) ([]*router.GeoIP, error) {
 }
 
 func parseFieldRule(msg json.RawMessage) (*router.RoutingRule, error) {
+	rawRouterRule := new(RouterRule)
+	if err := json.Unmarshal(msg, rawRouterRule); err != nil {
+		return nil, err
+	}
+
+	rule, err := parseRuleCondition(msg)
+	if err != nil {
+		return nil, err
+	}
+	rule.RuleTag = rawRouterRule.RuleTag
+	switch {
+	case len(rawRouterRule.OutboundTag) > 0:
+		rule.TargetTag = &router.RoutingRule_Tag{
+			Tag: rawRouterRule.OutboundTag,
+		}
+	case len(rawRouterRule.BalancerTag) > 0:
+		rule.TargetTag = &router.RoutingRule_BalancingTag{
+			BalancingTag: rawRouterRule.BalancerTag,
+		}
+	default:
+		return nil, errors.New("neither outboundTag nor balancerTag is specified in routing rule")
+	}
+	return rule, nil
+}
+
+// SubRuleList is a list of sub-rules, or a single one.
+type SubRuleList []json.RawMessage
+
+// UnmarshalJSON implements encoding/json.Unmarshaler.UnmarshalJSON
+func (l *SubRuleList) UnmarshalJSON(data []byte) error {
+	var rules []json.RawMessage
+	if err := json.Unmarshal(data, &rules); err == nil {
+		*l = rules
+		return nil
+	}
+	var rule json.RawMessage
+	if err := json.Unmarshal(data, &rule); err != nil {
+		return err
+	}
+	*l = SubRuleList{rule}
+	return nil
+}
+
+// parseRuleCondition parses the condition fields of a rule, which is also the whole of a sub-rule in "and", "or"
+// and "not".
+func parseRuleCondition(msg json.RawMessage) (*router.RoutingRule, error) {
 	type RawFieldRule struct {
-		RouterRule
+		Schedule   *ScheduleConfig   `json:"schedule"`
 		Domain     *StringList       `json:"domain"`
 		Domains    *StringList       `json:"domains"`
 		IP         *StringList       `json:"ip"`
@@ -537,6 +822,16 @@ func parseFieldRule(msg json.RawMessage) (*router.RoutingRule, error) {
 		Attributes map[string]string `json:"attrs"`
 		LocalIP    *StringList       `json:"localIP"`
 		LocalPort  *PortList         `json:"localPort"`
+		RuleSet    *StringList       `json:"ruleSet"`
+		Process    *StringList       `json:"process"`
+		UID        *IDList           `json:"uid"`
+		GID        *IDList           `json:"gid"`
+		JA3        *StringList       `json:"ja3"`
+		JA4        *StringList       `json:"ja4"`
+		ALPN       *StringList       `json:"alpn"`
+		And        SubRuleList       `json:"and"`
+		Or         SubRuleList       `json:"or"`
+		Not        SubRuleList       `json:"not"`
 	}
 	rawFieldRule := new(RawFieldRule)
 	err := json.Unmarshal(msg, rawFieldRule)
@@ -545,19 +840,6 @@ func parseFieldRule(msg json.RawMessage) (*router.RoutingRule, error) {
 	}
 
 	rule := new(router.RoutingRule)
-	rule.RuleTag = rawFieldRule.RuleTag
-	switch {
-	case len(rawFieldRule.OutboundTag) > 0:
-		rule.TargetTag = &router.RoutingRule_Tag{
-			Tag: rawFieldRule.OutboundTag,
-		}
-	case len(rawFieldRule.BalancerTag) > 0:
-		rule.TargetTag = &router.RoutingRule_BalancingTag{
-			BalancingTag: rawFieldRule.BalancerTag,
-		}
-	default:
-		return nil, errors.New("neither outboundTag nor balancerTag is specified in routing rule")
-	}
 
 	if rawFieldRule.Domain != nil {
 		for _, domain := range *rawFieldRule.Domain {
@@ -649,6 +931,81 @@ func parseFieldRule(msg json.RawMessage) (*router.RoutingRule, error) {
 		rule.Attributes = rawFieldRule.Attributes
 	}
 
+	if rawFieldRule.RuleSet != nil {
+		for _, s := range *rawFieldRule.RuleSet {
+			rule.RuleSet = append(rule.RuleSet, s)
+		}
+	}
+
+	if rawFieldRule.Process != nil {
+		for _, s := range *rawFieldRule.Process {
+			rule.Process = append(rule.Process, s)
+		}
+	}
+
+	if rawFieldRule.UID != nil {
+		uids, err := rawFieldRule.UID.Build(lookupUID)
+		if err != nil {
+			return nil, errors.New("failed to parse uid").Base(err)
+		}
+		rule.Uid = uids
+	}
+
+	if rawFieldRule.GID != nil {
+		gids, err := rawFieldRule.GID.Build(lookupGID)
+		if err != nil {
+			return nil, errors.New("failed to parse gid").Base(err)
+		}
+		rule.Gid = gids
+	}
+
+	if rawFieldRule.JA3 != nil {
+		for _, s := range *rawFieldRule.JA3 {
+			rule.Ja3 = append(rule.Ja3, s)
+		}
+	}
+
+	if rawFieldRule.JA4 != nil {
+		for _, s := range *rawFieldRule.JA4 {
+			rule.Ja4 = append(rule.Ja4, s)
+		}
+	}
+
+	if rawFieldRule.ALPN != nil {
+		for _, s := range *rawFieldRule.ALPN {
+			rule.Alpn = append(rule.Alpn, s)
+		}
+	}
+
+	if rawFieldRule.Schedule != nil {
+		schedule, err := rawFieldRule.Schedule.Build()
+		if err != nil {
+			return nil, errors.New("failed to parse schedule").Base(err)
+		}
+		rule.Schedule = schedule
+	}
+
+	parseSubRules := func(name string, msgs []json.RawMessage) ([]*router.RoutingRule, error) {
+		var subRules []*router.RoutingRule
+		for _, msg := range msgs {
+			subRule, err := parseRuleCondition(msg)
+			if err != nil {
+				return nil, errors.New("failed to parse sub-rule of ", name).Base(err)
+			}
+			subRules = append(subRules, subRule)
+		}
+		return subRules, nil
+	}
+	if rule.AndRules, err = parseSubRules("and", rawFieldRule.And); err != nil {
+		return nil, err
+	}
+	if rule.OrRules, err = parseSubRules("or", rawFieldRule.Or); err != nil {
+		return nil, err
+	}
+	if rule.NotRules, err = parseSubRules("not", rawFieldRule.Not); err != nil {
+		return nil, err
+	}
+
 	return rule, nil
 }
 
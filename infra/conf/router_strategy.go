@@ -6,6 +6,7 @@ import (
 
 	"github.com/xtls/xray-core/app/observatory/burst"
 	"github.com/xtls/xray-core/app/router"
+	"github.com/xtls/xray-core/common/errors"
 	"github.com/xtls/xray-core/infra/conf/cfgcommon/duration"
 )
 
@@ -14,6 +15,8 @@ const (
 	strategyLeastPing  string = "leastping"
 	strategyRoundRobin string = "roundrobin"
 	strategyLeastLoad  string = "leastload"
+
+	strategyConsistentHash string = "consistenthash"
 )
 
 var (
@@ -22,6 +25,8 @@ var (
 		strategyLeastPing:  func() interface{} { return new(strategyEmptyConfig) },
 		strategyRoundRobin: func() interface{} { return new(strategyEmptyConfig) },
 		strategyLeastLoad:  func() interface{} { return new(strategyLeastLoadConfig) },
+
+		strategyConsistentHash: func() interface{} { return new(strategyConsistentHashConfig) },
 	}, "type", "settings")
 )
 
@@ -45,6 +50,13 @@ type strategyLeastLoadConfig struct {
 	Tolerance float64 `json:"tolerance,omitempty"`
 }
 
+type strategyConsistentHashConfig struct {
+	// key to hash: sourceIP, email, domain or registrableDomain (eTLD+1)
+	Key string `json:"key,omitempty"`
+	// points of each outbound on the hash ring
+	VirtualNodes uint32 `json:"virtualNodes,omitempty"`
+}
+
 // healthCheckSettings holds settings for health Checker
 type healthCheckSettings struct {
 	Destination   string            `json:"destination"`
@@ -100,3 +112,23 @@ func (v *strategyLeastLoadConfig) Build() (proto.Message, error) {
 	}
 	return config, nil
 }
+
+// Build implements Buildable.
+func (v *strategyConsistentHashConfig) Build() (proto.Message, error) {
+	config := &router.StrategyConsistentHashConfig{
+		VirtualNodes: v.VirtualNodes,
+	}
+	switch strings.ToLower(v.Key) {
+	case "", "sourceip":
+		config.Key = router.StrategyConsistentHashConfig_SourceIp
+	case "email":
+		config.Key = router.StrategyConsistentHashConfig_Email
+	case "domain":
+		config.Key = router.StrategyConsistentHashConfig_Domain
+	case "registrabledomain", "etld+1":
+		config.Key = router.StrategyConsistentHashConfig_RegistrableDomain
+	default:
+		return nil, errors.New("unknown consistent hash key: ", v.Key)
+	}
+	return config, nil
+}
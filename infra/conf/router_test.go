@@ -301,5 +301,192 @@ func TestRouterConfig(t *testing.T) {
 				},
 			},
 		},
+		{
+			Input: `{
+				"rules": [
+					{
+						"network": "tcp",
+						"schedule": {
+							"timeZone": "Asia/Shanghai",
+							"windows": [
+								{"days": "fri-mon", "time": "22:00-06:00"},
+								{"days": ["wed"]}
+							]
+						},
+						"outboundTag": "metered"
+					}
+				]
+			}`,
+			Parser: createParser(),
+			Output: &router.Config{
+				DomainStrategy: router.Config_AsIs,
+				Rule: []*router.RoutingRule{
+					{
+						Networks: []net.Network{net.Network_TCP},
+						Schedule: &router.Schedule{
+							TimeZone: "Asia/Shanghai",
+							Window: []*router.TimeWindow{
+								{Weekdays: []uint32{5, 6, 0, 1}, Start: 22 * 60, End: 6 * 60},
+								{Weekdays: []uint32{3}},
+							},
+						},
+						TargetTag: &router.RoutingRule_Tag{
+							Tag: "metered",
+						},
+					},
+				},
+			},
+		},
+		{
+			Input: `{
+				"rules": [
+					{
+						"process": ["curl", "/usr/bin/wget", "/opt/apps/"],
+						"uid": [0, "1000"],
+						"gid": 100,
+						"outboundTag": "local"
+					}
+				]
+			}`,
+			Parser: createParser(),
+			Output: &router.Config{
+				DomainStrategy: router.Config_AsIs,
+				Rule: []*router.RoutingRule{
+					{
+						Process: []string{"curl", "/usr/bin/wget", "/opt/apps/"},
+						Uid:     []uint32{0, 1000},
+						Gid:     []uint32{100},
+						TargetTag: &router.RoutingRule_Tag{
+							Tag: "local",
+						},
+					},
+				},
+			},
+		},
+		{
+			Input: `{
+				"rules": [
+					{
+						"ja3": "E7D705A3286E19EA42F587B344EE6865",
+						"ja4": ["t13d1516h2_8daaf6152771_02713d6af862"],
+						"alpn": ["h2", "http/1.1"],
+						"outboundTag": "blocked"
+					}
+				]
+			}`,
+			Parser: createParser(),
+			Output: &router.Config{
+				DomainStrategy: router.Config_AsIs,
+				Rule: []*router.RoutingRule{
+					{
+						Ja3:  []string{"E7D705A3286E19EA42F587B344EE6865"},
+						Ja4:  []string{"t13d1516h2_8daaf6152771_02713d6af862"},
+						Alpn: []string{"h2", "http/1.1"},
+						TargetTag: &router.RoutingRule_Tag{
+							Tag: "blocked",
+						},
+					},
+				},
+			},
+		},
+		{
+			Input: `{
+				"rules": [
+					{
+						"ruleTag": "composite",
+						"network": "tcp",
+						"or": [
+							{"domain": ["domain:example.com"]},
+							{"port": 853, "and": {"ip": ["10.0.0.0/8"]}}
+						],
+						"not": [
+							{"domain": ["full:ads.example.com"]},
+							{"user": ["love@xray.com"]}
+						],
+						"outboundTag": "proxy"
+					}
+				]
+			}`,
+			Parser: createParser(),
+			Output: &router.Config{
+				DomainStrategy: router.Config_AsIs,
+				Rule: []*router.RoutingRule{
+					{
+						RuleTag:  "composite",
+						Networks: []net.Network{net.Network_TCP},
+						OrRules: []*router.RoutingRule{
+							{Domain: []*router.Domain{{Type: router.Domain_Domain, Value: "example.com"}}},
+							{
+								PortList: &net.PortList{Range: []*net.PortRange{{From: 853, To: 853}}},
+								AndRules: []*router.RoutingRule{
+									{Geoip: []*router.GeoIP{{Cidr: []*router.CIDR{{Ip: []byte{10, 0, 0, 0}, Prefix: 8}}}}},
+								},
+							},
+						},
+						NotRules: []*router.RoutingRule{
+							{Domain: []*router.Domain{{Type: router.Domain_Full, Value: "ads.example.com"}}},
+							{UserEmail: []string{"love@xray.com"}},
+						},
+						TargetTag: &router.RoutingRule_Tag{
+							Tag: "proxy",
+						},
+					},
+				},
+			},
+		},
+		{
+			Input: `{
+				"balancers": [
+					{
+						"tag": "b3",
+						"selector": ["test"],
+						"strategy": {
+							"type": "leastPing"
+						},
+						"fallbackTag": "fall",
+						"failover": [["backup"], ["last"]],
+						"hysteresis": {
+							"deadAfter": "30s",
+							"aliveAfter": "2m",
+							"holdTime": "5m"
+						}
+					},
+					{
+						"tag": "b4",
+						"selector": ["test"],
+						"failover": [["backup"]]
+					}
+				]
+			}`,
+			Parser: createParser(),
+			Output: &router.Config{
+				DomainStrategy: router.Config_AsIs,
+				BalancingRule: []*router.BalancingRule{
+					{
+						Tag:              "b3",
+						OutboundSelector: []string{"test"},
+						Strategy:         "leastping",
+						FallbackTag:      "fall",
+						FailoverGroup: []*router.FailoverGroup{
+							{OutboundSelector: []string{"backup"}},
+							{OutboundSelector: []string{"last"}},
+						},
+						Hysteresis: &router.BalancerHysteresis{
+							DeadAfter:  int64(30 * time.Second),
+							AliveAfter: int64(2 * time.Minute),
+							HoldTime:   int64(5 * time.Minute),
+						},
+					},
+					{
+						Tag:              "b4",
+						OutboundSelector: []string{"test"},
+						Strategy:         "random",
+						FailoverGroup: []*router.FailoverGroup{
+							{OutboundSelector: []string{"backup"}},
+						},
+					},
+				},
+			},
+		},
 	})
 }
@@ -37,6 +37,8 @@ type ShadowsocksUserConfig struct {
 	Email    string   `json:"email"`
 	Address  *Address `json:"address"`
 	Port     uint16   `json:"port"`
+	Quota    uint64   `json:"quota"`
+	Expire   int64    `json:"expire"`
 }
 
 type ShadowsocksServerConfig struct {
@@ -74,6 +76,8 @@ func (v *ShadowsocksServerConfig) Build() (proto.Message, error) {
 			config.Users = append(config.Users, &protocol.User{
 				Email:   user.Email,
 				Level:   uint32(user.Level),
+				Quota:   user.Quota,
+				Expire:  user.Expire,
 				Account: serial.ToTypedMessage(account),
 			})
 		}
@@ -132,6 +136,8 @@ func buildShadowsocks2022(v *ShadowsocksServerConfig) (proto.Message, error) {
 			config.Users = append(config.Users, &protocol.User{
 				Email:   user.Email,
 				Level:   uint32(user.Level),
+				Quota:   user.Quota,
+				Expire:  user.Expire,
 				Account: serial.ToTypedMessage(account),
 			})
 		}
@@ -89,6 +89,8 @@ type TrojanUserConfig struct {
 	Level    byte   `json:"level"`
 	Email    string `json:"email"`
 	Flow     string `json:"flow"`
+	Quota    uint64 `json:"quota"`
+	Expire   int64  `json:"expire"`
 }
 
 // TrojanServerConfig is Inbound configuration
@@ -109,8 +111,10 @@ func (c *TrojanServerConfig) Build() (proto.Message, error) {
 		}
 
 		config.Users[idx] = &protocol.User{
-			Level: uint32(rawUser.Level),
-			Email: rawUser.Email,
+			Level:  uint32(rawUser.Level),
+			Email:  rawUser.Email,
+			Quota:  rawUser.Quota,
+			Expire: rawUser.Expire,
 			Account: serial.ToTypedMessage(&trojan.Account{
 				Password: rawUser.Password,
 			}),
@@ -14,15 +14,18 @@ import (
 	"github.com/xtls/xray-core/app/stats"
 	"github.com/xtls/xray-core/common/errors"
 	"github.com/xtls/xray-core/common/net"
+	"github.com/xtls/xray-core/common/protocol"
 	"github.com/xtls/xray-core/common/serial"
 	core "github.com/xtls/xray-core/core"
 	"github.com/xtls/xray-core/transport/internet"
+	"google.golang.org/protobuf/reflect/protoreflect"
 )
 
 var (
 	inboundConfigLoader = NewJSONConfigLoader(ConfigCreatorCache{
 		"tunnel":        func() interface{} { return new(DokodemoConfig) },
 		"dokodemo-door": func() interface{} { return new(DokodemoConfig) },
+		"dns":           func() interface{} { return new(DNSInboundConfig) },
 		"http":          func() interface{} { return new(HTTPServerConfig) },
 		"shadowsocks":   func() interface{} { return new(ShadowsocksServerConfig) },
 		"mixed":         func() interface{} { return new(SocksServerConfig) },
@@ -385,11 +388,17 @@ func (c *OutboundDetourConfig) Build() (*core.OutboundHandlerConfig, error) {
 	}, nil
 }
 
-type StatsConfig struct{}
+type StatsConfig struct {
+	PersistPath     string `json:"persistPath"`
+	PersistInterval uint32 `json:"persistInterval"`
+}
 
 // Build implements Buildable.
 func (c *StatsConfig) Build() (*stats.Config, error) {
-	return &stats.Config{}, nil
+	return &stats.Config{
+		PersistPath:     c.PersistPath,
+		PersistInterval: c.PersistInterval,
+	}, nil
 }
 
 type Config struct {
@@ -559,7 +568,11 @@ func (c *Config) Build() (*core.Config, error) {
 
 	var logConfMsg *serial.TypedMessage
 	if c.LogConfig != nil {
-		logConfMsg = serial.ToTypedMessage(c.LogConfig.Build())
+		logConf, err := c.LogConfig.Build()
+		if err != nil {
+			return nil, errors.New("failed to build log configuration").Base(err)
+		}
+		logConfMsg = serial.ToTypedMessage(logConf)
 	} else {
 		logConfMsg = serial.ToTypedMessage(DefaultLogConfig())
 	}
@@ -646,6 +659,9 @@ func (c *Config) Build() (*core.Config, error) {
 		if err != nil {
 			return nil, errors.New("failed to build inbound config with tag ", rawInboundConfig.Tag).Base(err)
 		}
+		if c.Stats == nil && hasUserQuota(ic.ProxySettings) {
+			return nil, errors.New("users of inbound ", rawInboundConfig.Tag, " have traffic quotas, which need the stats object")
+		}
 		config.Inbound = append(config.Inbound, ic)
 	}
 
@@ -666,6 +682,43 @@ func (c *Config) Build() (*core.Config, error) {
 	return config, nil
 }
 
+// hasUserQuota returns whether any user in the proxy settings has a traffic quota, which is counted by the stats
+// app.
+func hasUserQuota(settings *serial.TypedMessage) bool {
+	m, err := settings.GetInstance()
+	if err != nil {
+		return false
+	}
+	return hasUserQuotaIn(m.ProtoReflect())
+}
+
+func hasUserQuotaIn(m protoreflect.Message) bool {
+	if user, ok := m.Interface().(*protocol.User); ok {
+		return user.Quota > 0
+	}
+	found := false
+	m.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		switch {
+		case fd.IsMap():
+			if fd.MapValue().Kind() == protoreflect.MessageKind {
+				v.Map().Range(func(_ protoreflect.MapKey, mv protoreflect.Value) bool {
+					found = hasUserQuotaIn(mv.Message())
+					return !found
+				})
+			}
+		case fd.Kind() != protoreflect.MessageKind:
+		case fd.IsList():
+			for i := 0; i < v.List().Len() && !found; i++ {
+				found = hasUserQuotaIn(v.List().Get(i).Message())
+			}
+		default:
+			found = hasUserQuotaIn(v.Message())
+		}
+		return !found
+	})
+	return found
+}
+
 // Convert string to Address.
 func ParseSendThough(Addr *string) *Address {
 	var addr Address
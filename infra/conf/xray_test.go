@@ -298,3 +298,27 @@ func TestConfig_Override(t *testing.T) {
 		})
 	}
 }
+
+func TestUserQuotaNeedsStats(t *testing.T) {
+	build := func(s string) error {
+		config := new(Config)
+		common.Must(json.Unmarshal([]byte(s), config))
+		_, err := config.Build()
+		return err
+	}
+	inbounds := `"inbounds": [{
+		"tag": "in",
+		"port": 443,
+		"protocol": "vmess",
+		"settings": {
+			"clients": [
+				{"id": "0cdf8a45-303d-4fed-9780-29aa7f54175e", "email": "a@xray.com"},
+				{"id": "1cdf8a45-303d-4fed-9780-29aa7f54175e", "email": "b@xray.com", "quota": 1024}
+			]
+		}
+	}]`
+	if err := build(`{` + inbounds + `}`); err == nil {
+		t.Error("expected error building users with quota without stats")
+	}
+	common.Must(build(`{"stats": {}, ` + inbounds + `}`))
+}
@@ -12,8 +12,11 @@ var CmdAPI = &base.Command{
 `,
 	Commands: []*base.Command{
 		cmdRestartLogger,
+		cmdSetLogFormat,
+		cmdReloadConfig,
 		cmdGetStats,
 		cmdQueryStats,
+		cmdSubscribeStats,
 		cmdSysStats,
 		cmdBalancerInfo,
 		cmdBalancerOverride,
@@ -27,10 +30,16 @@ var CmdAPI = &base.Command{
 		cmdRemoveInboundUsers,
 		cmdInboundUser,
 		cmdInboundUserCount,
+		cmdInboundUserQuota,
+		cmdSetRateLimit,
 		cmdAddRules,
 		cmdRemoveRules,
+		cmdRouteExplain,
+		cmdRuleStats,
 		cmdSourceIpBlock,
 		cmdOnlineStats,
 		cmdOnlineStatsIpList,
+		cmdListConnections,
+		cmdConn,
 	},
 }
@@ -0,0 +1,137 @@
+package api
+
+import (
+	"strconv"
+
+	connectionService "github.com/xtls/xray-core/app/dispatcher/command"
+	"github.com/xtls/xray-core/main/commands/base"
+)
+
+var cmdListConnections = &base.Command{
+	CustomFlags: true,
+	UsageLine:   "{{.Exec}} api conns [--server=127.0.0.1:8080] [-inbound tag] [-outbound tag] [-email email] [-source ip] [-target host]",
+	Short:       "List live connections",
+	Long: `
+List the live connections of Xray, optionally filtered.
+
+Arguments:
+
+	-s, -server <server:port>
+		The API server address. Default 127.0.0.1:8080
+
+	-t, -timeout <seconds>
+		Timeout in seconds for calling API. Default 3
+
+	-inbound
+		Only connections of the inbound with the tag
+
+	-outbound
+		Only connections of the outbound with the tag
+
+	-email
+		Only connections of the user
+
+	-source
+		Only connections whose source contains the string
+
+	-target
+		Only connections whose target or sniffed domain contains the string
+
+Example:
+
+	{{.Exec}} {{.LongName}} --server=127.0.0.1:8080 -email "xray@love.com"
+`,
+	Run: executeListConnections,
+}
+
+var cmdConn = &base.Command{
+	UsageLine: "{{.Exec}} api conn",
+	Short:     "Manage live connections",
+	Long: `{{.Exec}} {{.LongName}} manages live connections of Xray.
+`,
+	Commands: []*base.Command{
+		cmdKillConnections,
+	},
+}
+
+var cmdKillConnections = &base.Command{
+	CustomFlags: true,
+	UsageLine:   "{{.Exec}} api conn kill [--server=127.0.0.1:8080] [-inbound tag] [-outbound tag] [-email email] [-source ip] [-target host] [id]...",
+	Short:       "Close live connections",
+	Long: `
+Forcibly close live connections of Xray, given by ID or by filter.
+
+Arguments:
+
+	-s, -server <server:port>
+		The API server address. Default 127.0.0.1:8080
+
+	-t, -timeout <seconds>
+		Timeout in seconds for calling API. Default 3
+
+	-inbound, -outbound, -email, -source, -target
+		Close all connections matching the filter, as for "{{.Exec}} api conns"
+
+Example:
+
+	{{.Exec}} {{.LongName}} --server=127.0.0.1:8080 12 13
+	{{.Exec}} {{.LongName}} --server=127.0.0.1:8080 -email "xray@love.com"
+`,
+	Run: executeKillConnections,
+}
+
+func setConnectionFilterFlags(cmd *base.Command) *connectionService.ConnectionFilter {
+	f := &connectionService.ConnectionFilter{}
+	cmd.Flag.StringVar(&f.InboundTag, "inbound", "", "")
+	cmd.Flag.StringVar(&f.OutboundTag, "outbound", "", "")
+	cmd.Flag.StringVar(&f.Email, "email", "", "")
+	cmd.Flag.StringVar(&f.Source, "source", "", "")
+	cmd.Flag.StringVar(&f.Target, "target", "", "")
+	return f
+}
+
+func executeListConnections(cmd *base.Command, args []string) {
+	setSharedFlags(cmd)
+	filter := setConnectionFilterFlags(cmd)
+	cmd.Flag.Parse(args)
+
+	conn, ctx, close := dialAPIServer()
+	defer close()
+
+	client := connectionService.NewConnectionServiceClient(conn)
+	r := &connectionService.ListConnectionsRequest{
+		Filter: filter,
+	}
+	resp, err := client.ListConnections(ctx, r)
+	if err != nil {
+		base.Fatalf("failed to list connections: %s", err)
+	}
+	showJSONResponse(resp)
+}
+
+func executeKillConnections(cmd *base.Command, args []string) {
+	setSharedFlags(cmd)
+	filter := setConnectionFilterFlags(cmd)
+	cmd.Flag.Parse(args)
+
+	r := &connectionService.CloseConnectionsRequest{
+		Filter: filter,
+	}
+	for _, arg := range cmd.Flag.Args() {
+		id, err := strconv.ParseUint(arg, 10, 64)
+		if err != nil {
+			base.Fatalf("invalid connection id: %s", arg)
+		}
+		r.Ids = append(r.Ids, id)
+	}
+
+	conn, ctx, close := dialAPIServer()
+	defer close()
+
+	client := connectionService.NewConnectionServiceClient(conn)
+	resp, err := client.CloseConnections(ctx, r)
+	if err != nil {
+		base.Fatalf("failed to close connections: %s", err)
+	}
+	showJSONResponse(resp)
+}
@@ -0,0 +1,112 @@
+package api
+
+import (
+	"context"
+	"io"
+	"os"
+	"strings"
+	"testing"
+
+	connectionService "github.com/xtls/xray-core/app/dispatcher/command"
+	"github.com/xtls/xray-core/common"
+	"github.com/xtls/xray-core/common/net"
+	"github.com/xtls/xray-core/main/commands/base"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+)
+
+type testConnectionServer struct {
+	connectionService.UnimplementedConnectionServiceServer
+	listRequest  *connectionService.ListConnectionsRequest
+	closeRequest *connectionService.CloseConnectionsRequest
+}
+
+func (s *testConnectionServer) ListConnections(ctx context.Context, request *connectionService.ListConnectionsRequest) (*connectionService.ListConnectionsResponse, error) {
+	s.listRequest = request
+	return &connectionService.ListConnectionsResponse{
+		Connections: []*connectionService.Connection{
+			{Id: 12, InboundTag: "in", Email: "love@xray.com", Target: "tcp:example.com:443"},
+		},
+	}, nil
+}
+
+func (s *testConnectionServer) CloseConnections(ctx context.Context, request *connectionService.CloseConnectionsRequest) (*connectionService.CloseConnectionsResponse, error) {
+	s.closeRequest = request
+	return &connectionService.CloseConnectionsResponse{Closed: uint32(len(request.Ids))}, nil
+}
+
+func startTestConnectionServer(t *testing.T) (*testConnectionServer, string) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	common.Must(err)
+	server := grpc.NewServer()
+	cs := &testConnectionServer{}
+	connectionService.RegisterConnectionServiceServer(server, cs)
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+	return cs, listener.Addr().String()
+}
+
+// runCommand runs the command with args, and returns what it prints.
+func runCommand(run func(cmd *base.Command, args []string), args ...string) string {
+	r, w, err := os.Pipe()
+	common.Must(err)
+	stdout := os.Stdout
+	os.Stdout = w
+	defer func() {
+		os.Stdout = stdout
+	}()
+
+	run(&base.Command{}, args)
+	common.Must(w.Close())
+	out, err := io.ReadAll(r)
+	common.Must(err)
+	return string(out)
+}
+
+func TestListConnections(t *testing.T) {
+	cs, addr := startTestConnectionServer(t)
+
+	out := runCommand(executeListConnections, "-s", addr, "-inbound", "in", "-email", "love@xray.com", "-target", "example.com")
+	expected := &connectionService.ListConnectionsRequest{
+		Filter: &connectionService.ConnectionFilter{
+			InboundTag: "in",
+			Email:      "love@xray.com",
+			Target:     "example.com",
+		},
+	}
+	if !proto.Equal(cs.listRequest, expected) {
+		t.Error("unexpected request: ", cs.listRequest)
+	}
+	for _, s := range []string{`"id": 12`, `"inboundTag": "in"`, `"target": "tcp:example.com:443"`} {
+		if !strings.Contains(out, s) {
+			t.Error("expect ", s, " in output: ", out)
+		}
+	}
+}
+
+func TestKillConnections(t *testing.T) {
+	cs, addr := startTestConnectionServer(t)
+
+	out := runCommand(executeKillConnections, "-s", addr, "12", "13")
+	expected := &connectionService.CloseConnectionsRequest{
+		Ids:    []uint64{12, 13},
+		Filter: &connectionService.ConnectionFilter{},
+	}
+	if !proto.Equal(cs.closeRequest, expected) {
+		t.Error("unexpected request: ", cs.closeRequest)
+	}
+	if !strings.Contains(out, `"closed": 2`) {
+		t.Error("unexpected output: ", out)
+	}
+
+	runCommand(executeKillConnections, "--server="+addr, "-outbound", "direct", "-source", "10.0.0.1")
+	expected = &connectionService.CloseConnectionsRequest{
+		Filter: &connectionService.ConnectionFilter{
+			OutboundTag: "direct",
+			Source:      "10.0.0.1",
+		},
+	}
+	if !proto.Equal(cs.closeRequest, expected) {
+		t.Error("unexpected request: ", cs.closeRequest)
+	}
+}
@@ -0,0 +1,78 @@
+package api
+
+import (
+	handlerService "github.com/xtls/xray-core/app/proxyman/command"
+	"github.com/xtls/xray-core/main/commands/base"
+)
+
+var cmdInboundUserQuota = &base.Command{
+	CustomFlags: true,
+	UsageLine:   "{{.Exec}} api inbounduserquota [--server=127.0.0.1:8080] -tag=tag -email=email [-reset]",
+	Short:       "Retrieve or reset inbound user quota",
+	Long: `
+Retrieve the quota, expiry and used traffic of a user of an inbound, or reset its traffic counters.
+
+Arguments:
+
+	-s, -server <server:port>
+		The API server address. Default 127.0.0.1:8080
+
+	-t, -timeout <seconds>
+		Timeout in seconds for calling API. Default 3
+
+	-tag
+		Inbound tag
+
+	-email
+		User email
+
+	-reset
+		Reset the traffic counters of the user, restoring its full quota
+
+Example:
+
+	{{.Exec}} {{.LongName}} --server=127.0.0.1:8080 -tag="tag name" -email="xray@love.com"
+	{{.Exec}} {{.LongName}} --server=127.0.0.1:8080 -tag="tag name" -email="xray@love.com" -reset
+`,
+	Run: executeInboundUserQuota,
+}
+
+func executeInboundUserQuota(cmd *base.Command, args []string) {
+	setSharedFlags(cmd)
+	var tag, email string
+	var reset bool
+	cmd.Flag.StringVar(&tag, "tag", "", "")
+	cmd.Flag.StringVar(&email, "email", "", "")
+	cmd.Flag.BoolVar(&reset, "reset", false, "")
+	cmd.Flag.Parse(args)
+
+	if email == "" {
+		base.Fatalf("an email is required")
+	}
+
+	conn, ctx, close := dialAPIServer()
+	defer close()
+
+	client := handlerService.NewHandlerServiceClient(conn)
+	if reset {
+		r := &handlerService.ResetUserQuotaRequest{
+			Tag:   tag,
+			Email: email,
+		}
+		resp, err := client.ResetUserQuota(ctx, r)
+		if err != nil {
+			base.Fatalf("failed to reset inbound user quota: %s", err)
+		}
+		showJSONResponse(resp)
+		return
+	}
+	r := &handlerService.GetUserQuotaRequest{
+		Tag:   tag,
+		Email: email,
+	}
+	resp, err := client.GetUserQuota(ctx, r)
+	if err != nil {
+		base.Fatalf("failed to get inbound user quota: %s", err)
+	}
+	showJSONResponse(resp)
+}
@@ -0,0 +1,75 @@
+package api
+
+import (
+	"strings"
+
+	"github.com/xtls/xray-core/app/log"
+	logService "github.com/xtls/xray-core/app/log/command"
+	"github.com/xtls/xray-core/main/commands/base"
+)
+
+var cmdSetLogFormat = &base.Command{
+	CustomFlags: true,
+	UsageLine:   "{{.Exec}} api logformat [--server=127.0.0.1:8080] [-error text|json] [-access text|json]",
+	Short:       "Set the format of logs",
+	Long: `
+Set the format of the error and access logs of Xray.
+
+Arguments:
+
+	-s, -server <server:port>
+		The API server address. Default 127.0.0.1:8080
+
+	-t, -timeout <seconds>
+		Timeout in seconds for calling API. Default 3
+
+	-error
+		The format of the error log, "text" or "json". Default "text"
+
+	-access
+		The format of the access log, "text" or "json". Default "text"
+
+Example:
+
+	{{.Exec}} {{.LongName}} --server=127.0.0.1:8080 -error json -access json
+`,
+	Run: executeSetLogFormat,
+}
+
+func parseLogFormat(s string) log.LogFormat {
+	switch strings.ToLower(s) {
+	case "text":
+		return log.LogFormat_Text
+	case "json":
+		return log.LogFormat_JSON
+	default:
+		base.Fatalf("unknown log format: %s", s)
+		return log.LogFormat_Text
+	}
+}
+
+func executeSetLogFormat(cmd *base.Command, args []string) {
+	var (
+		errorFormat  string
+		accessFormat string
+	)
+	setSharedFlags(cmd)
+	cmd.Flag.StringVar(&errorFormat, "error", "text", "")
+	cmd.Flag.StringVar(&accessFormat, "access", "text", "")
+	cmd.Flag.Parse(args)
+
+	r := &logService.SetLogFormatRequest{
+		ErrorLogFormat:  parseLogFormat(errorFormat),
+		AccessLogFormat: parseLogFormat(accessFormat),
+	}
+
+	conn, ctx, close := dialAPIServer()
+	defer close()
+
+	client := logService.NewLoggerServiceClient(conn)
+	resp, err := client.SetLogFormat(ctx, r)
+	if err != nil {
+		base.Fatalf("failed to set log format: %s", err)
+	}
+	showJSONResponse(resp)
+}
@@ -0,0 +1,80 @@
+package api
+
+import (
+	"github.com/xtls/xray-core/app/policy"
+	handlerService "github.com/xtls/xray-core/app/proxyman/command"
+	"github.com/xtls/xray-core/main/commands/base"
+)
+
+var cmdSetRateLimit = &base.Command{
+	CustomFlags: true,
+	UsageLine:   "{{.Exec}} api ratelimit [--server=127.0.0.1:8080] [-tag=tag | -level=0] [-uplink=0] [-downlink=0]",
+	Short:       "Set bandwidth limit",
+	Long: `
+Set the bandwidth limit of an inbound, or of the users of a level. 
+Connections already limited follow the new limit immediately.
+
+Arguments:
+
+	-s, -server <server:port>
+		The API server address. Default 127.0.0.1:8080
+
+	-t, -timeout <seconds>
+		Timeout in seconds for calling API. Default 3
+
+	-tag
+		Inbound tag. The limit is shared by all connections of the inbound.
+
+	-level
+		User level, used if no tag is given. The limit is shared by all 
+		connections of each user. Default 0
+
+	-uplink, -downlink
+		Limit in KB per second. 0 for unlimited. Default 0
+
+	-uplinkburst, -downlinkburst
+		Burst in KB. 0 for one second of traffic. Default 0
+
+Example:
+
+	{{.Exec}} {{.LongName}} --server=127.0.0.1:8080 -level=0 -uplink=1024 -downlink=4096
+`,
+	Run: executeSetRateLimit,
+}
+
+func executeSetRateLimit(cmd *base.Command, args []string) {
+	setSharedFlags(cmd)
+	var (
+		tag                        string
+		level                      uint
+		uplink, downlink           uint64
+		uplinkBurst, downlinkBurst uint64
+	)
+	cmd.Flag.StringVar(&tag, "tag", "", "")
+	cmd.Flag.UintVar(&level, "level", 0, "")
+	cmd.Flag.Uint64Var(&uplink, "uplink", 0, "")
+	cmd.Flag.Uint64Var(&downlink, "downlink", 0, "")
+	cmd.Flag.Uint64Var(&uplinkBurst, "uplinkburst", 0, "")
+	cmd.Flag.Uint64Var(&downlinkBurst, "downlinkburst", 0, "")
+	cmd.Flag.Parse(args)
+
+	conn, ctx, close := dialAPIServer()
+	defer close()
+
+	client := handlerService.NewHandlerServiceClient(conn)
+	r := &handlerService.SetRateLimitRequest{
+		InboundTag: tag,
+		Level:      uint32(level),
+		RateLimit: &policy.Policy_RateLimit{
+			Uplink:        uplink * 1024,
+			Downlink:      downlink * 1024,
+			UplinkBurst:   uplinkBurst * 1024,
+			DownlinkBurst: downlinkBurst * 1024,
+		},
+	}
+	resp, err := client.SetRateLimit(ctx, r)
+	if err != nil {
+		base.Fatalf("failed to set rate limit: %s", err)
+	}
+	showJSONResponse(resp)
+}
@@ -0,0 +1,62 @@
+package api
+
+import (
+	"fmt"
+
+	reloadService "github.com/xtls/xray-core/app/commander/command"
+	"github.com/xtls/xray-core/common/cmdarg"
+	"github.com/xtls/xray-core/core"
+	"github.com/xtls/xray-core/main/commands/base"
+)
+
+var cmdReloadConfig = &base.Command{
+	CustomFlags: true,
+	UsageLine:   "{{.Exec}} api reload [--server=127.0.0.1:8080] <c1.json> [c2.json]...",
+	Short:       "Reload config",
+	Long: `
+Reload the config of Xray without restarting it. The given files must 
+contain the complete config. Inbounds and outbounds whose settings are 
+not changed keep their connections.
+
+Arguments:
+
+	-s, -server <server:port>
+		The API server address. Default 127.0.0.1:8080
+
+	-t, -timeout <seconds>
+		Timeout in seconds for calling API. Default 3
+
+Example:
+
+	{{.Exec}} {{.LongName}} --server=127.0.0.1:8080 c1.json c2.json
+`,
+	Run: executeReloadConfig,
+}
+
+func executeReloadConfig(cmd *base.Command, args []string) {
+	setSharedFlags(cmd)
+	cmd.Flag.Parse(args)
+	unnamedArgs := cmd.Flag.Args()
+	if len(unnamedArgs) == 0 {
+		fmt.Println("reading from stdin:")
+		unnamedArgs = []string{"stdin:"}
+	}
+
+	c, err := core.LoadConfig("auto", cmdarg.Arg(unnamedArgs))
+	if err != nil {
+		base.Fatalf("failed to load config: %s", err)
+	}
+
+	conn, ctx, close := dialAPIServer()
+	defer close()
+
+	client := reloadService.NewReloadServiceClient(conn)
+	r := &reloadService.ReloadConfigRequest{
+		Config: c,
+	}
+	resp, err := client.ReloadConfig(ctx, r)
+	if err != nil {
+		base.Fatalf("failed to reload config: %s", err)
+	}
+	showJSONResponse(resp)
+}
@@ -0,0 +1,137 @@
+package api
+
+import (
+	"strings"
+
+	routerService "github.com/xtls/xray-core/app/router/command"
+	"github.com/xtls/xray-core/common/net"
+	"github.com/xtls/xray-core/main/commands/base"
+)
+
+var cmdRouteExplain = &base.Command{
+	CustomFlags: true,
+	UsageLine:   "{{.Exec}} api route-explain [--server=127.0.0.1:8080] [-domain domain] [-ip ip] [-port port] [-network tcp|udp] [-inbound tag] [-user email] [-protocol protocol] [-source ip] [-sourcePort port]",
+	Short:       "Explain routing decision",
+	Long: `
+Explain how the router would route a connection, without making one.
+Every rule checked is listed in order, with the result of each of its
+conditions, the DNS resolution done by the domain strategy, and the
+outbound picked.
+
+> Ensure that the "RoutingService" is properly configured under "config.api.services" in the server configuration.
+
+Arguments:
+
+	-s, -server <server:port>
+		The API server address. Default 127.0.0.1:8080
+
+	-t, -timeout <seconds>
+		Timeout in seconds for calling API. Default 3
+
+	-domain
+		The target domain
+
+	-ip
+		The target IP. Multiple IPs are separated by comma
+
+	-port
+		The target port
+
+	-network
+		The network, tcp or udp. Default tcp
+
+	-inbound
+		The inbound tag
+
+	-user
+		The user email
+
+	-protocol
+		The sniffed protocol, e.g. http, tls, quic, bittorrent
+
+	-source
+		The source IP
+
+	-sourcePort
+		The source port
+
+Example:
+
+	{{.Exec}} {{.LongName}} --server=127.0.0.1:8080 -domain www.example.com -port 443 -inbound socks
+`,
+	Run: executeRouteExplain,
+}
+
+func executeRouteExplain(cmd *base.Command, args []string) {
+	var (
+		domain     string
+		ips        string
+		port       uint
+		network    string
+		inbound    string
+		user       string
+		protocol   string
+		source     string
+		sourcePort uint
+	)
+	setSharedFlags(cmd)
+	cmd.Flag.StringVar(&domain, "domain", "", "")
+	cmd.Flag.StringVar(&ips, "ip", "", "")
+	cmd.Flag.UintVar(&port, "port", 0, "")
+	cmd.Flag.StringVar(&network, "network", "tcp", "")
+	cmd.Flag.StringVar(&inbound, "inbound", "", "")
+	cmd.Flag.StringVar(&user, "user", "", "")
+	cmd.Flag.StringVar(&protocol, "protocol", "", "")
+	cmd.Flag.StringVar(&source, "source", "", "")
+	cmd.Flag.UintVar(&sourcePort, "sourcePort", 0, "")
+	cmd.Flag.Parse(args)
+
+	if domain == "" && ips == "" {
+		base.Fatalf("either a target domain or a target IP is required")
+	}
+
+	rc := &routerService.RoutingContext{
+		InboundTag:   inbound,
+		TargetDomain: domain,
+		TargetPort:   uint32(port),
+		Protocol:     protocol,
+		User:         user,
+		SourcePort:   uint32(sourcePort),
+	}
+	switch strings.ToLower(network) {
+	case "tcp":
+		rc.Network = net.Network_TCP
+	case "udp":
+		rc.Network = net.Network_UDP
+	default:
+		base.Fatalf("unknown network: %s", network)
+	}
+	if ips != "" {
+		for _, s := range strings.Split(ips, ",") {
+			ip := net.ParseIP(strings.TrimSpace(s))
+			if ip == nil {
+				base.Fatalf("invalid target IP: %s", s)
+			}
+			rc.TargetIPs = append(rc.TargetIPs, ip)
+		}
+	}
+	if source != "" {
+		ip := net.ParseIP(source)
+		if ip == nil {
+			base.Fatalf("invalid source IP: %s", source)
+		}
+		rc.SourceIPs = [][]byte{ip}
+	}
+
+	conn, ctx, close := dialAPIServer()
+	defer close()
+
+	client := routerService.NewRoutingServiceClient(conn)
+	resp, err := client.ExplainRoute(ctx, &routerService.TestRouteRequest{
+		RoutingContext: rc,
+	})
+	if err != nil {
+		base.Fatalf("failed to explain route: %s", err)
+	}
+	showJSONResponse(resp)
+}
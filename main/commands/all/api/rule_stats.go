@@ -0,0 +1,59 @@
+package api
+
+import (
+	routerService "github.com/xtls/xray-core/app/router/command"
+	"github.com/xtls/xray-core/main/commands/base"
+)
+
+var cmdRuleStats = &base.Command{
+	CustomFlags: true,
+	UsageLine:   "{{.Exec}} api rulestats [--server=127.0.0.1:8080] [-reset] [ruleTag]...",
+	Short:       "Get routing rule statistics",
+	Long: `
+Get the hits, the last hit time and the routed traffic of the tagged routing rules,
+in the order of the rules. Rules that are never hit are listed with zero hits.
+
+The same values are also available as the "rule>>>[ruleTag]>>>hits" and
+"rule>>>[ruleTag]>>>traffic>>>uplink|downlink" counters of "{{.Exec}} api statsquery",
+when "stats" is enabled.
+
+> Ensure that the "RoutingService" is properly configured under "config.api.services" in the server configuration.
+
+Arguments:
+
+	-s, -server <server:port>
+		The API server address. Default 127.0.0.1:8080
+
+	-t, -timeout <seconds>
+		Timeout in seconds for calling API. Default 3
+
+	-reset
+		Reset the statistics after fetching their values. Default false
+
+Example:
+
+	{{.Exec}} {{.LongName}} --server=127.0.0.1:8080
+	{{.Exec}} {{.LongName}} --server=127.0.0.1:8080 -reset ads direct
+`,
+	Run: executeRuleStats,
+}
+
+func executeRuleStats(cmd *base.Command, args []string) {
+	setSharedFlags(cmd)
+	reset := cmd.Flag.Bool("reset", false, "")
+	cmd.Flag.Parse(args)
+
+	conn, ctx, close := dialAPIServer()
+	defer close()
+
+	client := routerService.NewRoutingServiceClient(conn)
+	r := &routerService.GetRuleStatsRequest{
+		RuleTags: cmd.Flag.Args(),
+		Reset_:   *reset,
+	}
+	resp, err := client.GetRuleStats(ctx, r)
+	if err != nil {
+		base.Fatalf("failed to get rule stats: %s", err)
+	}
+	showJSONResponse(resp)
+}
@@ -0,0 +1,64 @@
+package api
+
+import (
+	"context"
+
+	statsService "github.com/xtls/xray-core/app/stats/command"
+	"github.com/xtls/xray-core/main/commands/base"
+)
+
+var cmdSubscribeStats = &base.Command{
+	CustomFlags: true,
+	UsageLine:   "{{.Exec}} api statssubscribe [--server=127.0.0.1:8080] [-pattern ''] [-interval 1]",
+	Short:       "Subscribe to statistics",
+	Long: `
+Subscribe to statistics of Xray. Prints the changes of counters and users
+coming online or going offline as they happen, until interrupted.
+
+Arguments:
+
+	-s, -server <server:port>
+		The API server address. Default 127.0.0.1:8080
+
+	-t, -timeout <seconds>
+		Timeout in seconds for connecting to the API server. Default 3
+
+	-pattern
+		Filter pattern for the statistics.
+
+	-interval <seconds>
+		Interval between updates. Default 1
+
+Example:
+
+	{{.Exec}} {{.LongName}} --server=127.0.0.1:8080 -pattern "user>>>"
+`,
+	Run: executeSubscribeStats,
+}
+
+func executeSubscribeStats(cmd *base.Command, args []string) {
+	setSharedFlags(cmd)
+	pattern := cmd.Flag.String("pattern", "", "")
+	interval := cmd.Flag.Uint("interval", 1, "")
+	cmd.Flag.Parse(args)
+
+	conn, _, close := dialAPIServer()
+	defer close()
+
+	client := statsService.NewStatsServiceClient(conn)
+	r := &statsService.SubscribeStatsRequest{
+		Pattern:  *pattern,
+		Interval: uint32(*interval),
+	}
+	stream, err := client.SubscribeStats(context.Background(), r)
+	if err != nil {
+		base.Fatalf("failed to subscribe stats: %s", err)
+	}
+	for {
+		resp, err := stream.Recv()
+		if err != nil {
+			base.Fatalf("failed to receive stats: %s", err)
+		}
+		showJSONResponse(resp)
+	}
+}
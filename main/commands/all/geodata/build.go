@@ -0,0 +1,170 @@
+package geodata
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/xtls/xray-core/app/router"
+	"github.com/xtls/xray-core/main/commands/base"
+	"google.golang.org/protobuf/proto"
+)
+
+var cmdBuild = &base.Command{
+	CustomFlags: true,
+	UsageLine:   "{{.Exec}} geodata build [-type geoip|geosite] -o <dat file> <text file|directory>...",
+	Short:       "Build a dat file from plain text",
+	Long: `
+Build a geoip.dat or geosite.dat from plain text files, one category per
+file named after the file without extension. Files in a directory are all
+used.
+
+Lines of a geoip category are IPs or CIDRs. Lines of a geosite category are
+domains in the form of "domain:", "full:", "keyword:" or "regexp:", where
+domains without prefix are "domain:". A domain can be followed by attributes
+like "@cn", and a line of "include:name" includes another category. Empty
+lines and anything after "#" are ignored.
+
+Arguments:
+
+	-t, -type
+		The type of the dat file, geoip or geosite. Told by the name of
+		the output file if not specified.
+
+	-o
+		The output dat file.
+
+Example:
+
+	{{.Exec}} {{.LongName}} -o geosite_custom.dat ./data
+	{{.Exec}} {{.LongName}} -type geoip -o private.dat private.txt
+`,
+	Run: executeBuild,
+}
+
+func executeBuild(cmd *base.Command, args []string) {
+	typ := addTypeFlag(cmd)
+	output := cmd.Flag.String("o", "", "")
+	cmd.Flag.Parse(args)
+	if *output == "" {
+		base.Fatalf("the output file is required")
+	}
+	if cmd.Flag.NArg() < 1 {
+		base.Fatalf("at least one text file or directory is required")
+	}
+
+	var files []string
+	for _, arg := range cmd.Flag.Args() {
+		info, err := os.Stat(arg)
+		if err != nil {
+			base.Fatalf("%s", err)
+		}
+		if !info.IsDir() {
+			files = append(files, arg)
+			continue
+		}
+		entries, err := os.ReadDir(arg)
+		if err != nil {
+			base.Fatalf("%s", err)
+		}
+		for _, entry := range entries {
+			if !entry.IsDir() && !strings.HasPrefix(entry.Name(), ".") {
+				files = append(files, filepath.Join(arg, entry.Name()))
+			}
+		}
+	}
+
+	categories := make(map[string][]byte)
+	for _, file := range files {
+		code := strings.ToUpper(strings.TrimSuffix(filepath.Base(file), filepath.Ext(file)))
+		if _, found := categories[code]; found {
+			base.Fatalf("duplicated category %s from %s", code, file)
+		}
+		data, err := os.ReadFile(file)
+		if err != nil {
+			base.Fatalf("%s", err)
+		}
+		categories[code] = data
+	}
+	codes := make([]string, 0, len(categories))
+	for code := range categories {
+		codes = append(codes, code)
+	}
+	slices.Sort(codes)
+
+	var list proto.Message
+	switch fileType(*output, *typ) {
+	case typeGeoIP:
+		list = buildGeoIPList(codes, categories)
+	case typeGeoSite:
+		list = buildGeoSiteList(codes, categories)
+	}
+	data, err := proto.Marshal(list)
+	if err != nil {
+		base.Fatalf("failed to marshal: %s", err)
+	}
+	if err := os.WriteFile(*output, data, 0o644); err != nil {
+		base.Fatalf("failed to write %s: %s", *output, err)
+	}
+}
+
+func buildGeoIPList(codes []string, categories map[string][]byte) *router.GeoIPList {
+	list := &router.GeoIPList{}
+	for _, code := range codes {
+		cidrs, err := router.ParseGeoIPText(categories[code])
+		if err != nil {
+			base.Fatalf("failed to parse category %s: %s", code, err)
+		}
+		list.Entry = append(list.Entry, &router.GeoIP{
+			CountryCode: code,
+			Cidr:        cidrs,
+		})
+	}
+	return list
+}
+
+func buildGeoSiteList(codes []string, categories map[string][]byte) *router.GeoSiteList {
+	domains := make(map[string][]*router.Domain, len(codes))
+	includes := make(map[string][]string, len(codes))
+	for _, code := range codes {
+		var err error
+		domains[code], includes[code], err = router.ParseGeoSiteText(categories[code])
+		if err != nil {
+			base.Fatalf("failed to parse category %s: %s", code, err)
+		}
+	}
+
+	// resolve returns the domains of the category with its includes, which are resolved recursively.
+	resolved := make(map[string][]*router.Domain, len(codes))
+	resolving := make(map[string]bool)
+	var resolve func(code string) []*router.Domain
+	resolve = func(code string) []*router.Domain {
+		if result, found := resolved[code]; found {
+			return result
+		}
+		if resolving[code] {
+			base.Fatalf("circular include of category %s", code)
+		}
+		resolving[code] = true
+		result := domains[code]
+		for _, include := range includes[code] {
+			include = strings.ToUpper(include)
+			if _, found := domains[include]; !found {
+				base.Fatalf("category %s included by %s not found", include, code)
+			}
+			result = append(result, resolve(include)...)
+		}
+		resolved[code] = result
+		return result
+	}
+
+	list := &router.GeoSiteList{}
+	for _, code := range codes {
+		list.Entry = append(list.Entry, &router.GeoSite{
+			CountryCode: code,
+			Domain:      resolve(code),
+		})
+	}
+	return list
+}
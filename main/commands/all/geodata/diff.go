@@ -0,0 +1,99 @@
+package geodata
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/xtls/xray-core/main/commands/base"
+)
+
+var cmdDiff = &base.Command{
+	CustomFlags: true,
+	UsageLine:   "{{.Exec}} geodata diff [-type geoip|geosite] <old dat file> <new dat file>",
+	Short:       "Show differences between two dat files",
+	Long: `
+Show the categories and entries added to or removed from the old dat file in
+the new one. Categories added or removed are marked by "+" or "-", and so
+are their entries under changed categories.
+
+Arguments:
+
+	-t, -type
+		The type of the dat files, geoip or geosite.
+
+Example:
+
+	{{.Exec}} {{.LongName}} geosite.dat geosite.dat.new
+`,
+	Run: executeDiff,
+}
+
+func executeDiff(cmd *base.Command, args []string) {
+	typ := addTypeFlag(cmd)
+	cmd.Flag.Parse(args)
+	if cmd.Flag.NArg() != 2 {
+		base.Fatalf("two dat files are required")
+	}
+	// Both files are of the type of the old one, e.g. when the new one is a download named otherwise.
+	oldFile, newFile := cmd.Flag.Arg(0), cmd.Flag.Arg(1)
+	*typ = fileType(oldFile, *typ)
+	oldEntries := loadGeoData(oldFile, *typ).entries()
+	newEntries := loadGeoData(newFile, *typ).entries()
+
+	var codes []string
+	for code := range oldEntries {
+		codes = append(codes, code)
+	}
+	for code := range newEntries {
+		if _, found := oldEntries[code]; !found {
+			codes = append(codes, code)
+		}
+	}
+	slices.Sort(codes)
+
+	for _, code := range codes {
+		oldList, inOld := oldEntries[code]
+		newList, inNew := newEntries[code]
+		name := strings.ToLower(code)
+		switch {
+		case !inOld:
+			fmt.Printf("+ %s (%d)\n", name, len(newList))
+		case !inNew:
+			fmt.Printf("- %s (%d)\n", name, len(oldList))
+		default:
+			added, removed := diffEntries(oldList, newList)
+			if len(added) == 0 && len(removed) == 0 {
+				continue
+			}
+			fmt.Printf("  %s (+%d -%d)\n", name, len(added), len(removed))
+			for _, entry := range added {
+				fmt.Println("\t+ " + entry)
+			}
+			for _, entry := range removed {
+				fmt.Println("\t- " + entry)
+			}
+		}
+	}
+}
+
+// diffEntries returns the entries only in newList, and the ones only in oldList, in their order.
+func diffEntries(oldList, newList []string) (added, removed []string) {
+	oldSet := make(map[string]bool, len(oldList))
+	for _, entry := range oldList {
+		oldSet[entry] = true
+	}
+	newSet := make(map[string]bool, len(newList))
+	for _, entry := range newList {
+		newSet[entry] = true
+		if !oldSet[entry] {
+			added = append(added, entry)
+		}
+	}
+	for _, entry := range oldList {
+		if !newSet[entry] {
+			removed = append(removed, entry)
+		}
+	}
+	return added, removed
+}
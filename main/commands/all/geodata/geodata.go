@@ -0,0 +1,119 @@
+package geodata
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/xtls/xray-core/app/router"
+	"github.com/xtls/xray-core/common/platform/filesystem"
+	"github.com/xtls/xray-core/main/commands/base"
+	"google.golang.org/protobuf/proto"
+)
+
+// CmdGeodata holds all geodata sub commands
+var CmdGeodata = &base.Command{
+	UsageLine: "{{.Exec}} geodata",
+	Short:     "GeoIP and GeoSite data tools",
+	Long: `{{.Exec}} {{.LongName}} provides tools to inspect and build geoip.dat and geosite.dat.
+
+The type of a dat file is told by its name containing "geoip" or "geosite",
+unless specified by -type. A dat file not found in the working directory is
+looked up in the asset location, like in config.
+`,
+	Commands: []*base.Command{
+		cmdList,
+		cmdLookup,
+		cmdBuild,
+		cmdDiff,
+	},
+}
+
+const (
+	typeGeoIP   = "geoip"
+	typeGeoSite = "geosite"
+)
+
+// geoData is a loaded dat file, of which only one list is set according to its type.
+type geoData struct {
+	geoip   *router.GeoIPList
+	geosite *router.GeoSiteList
+}
+
+// fileType returns the type of the dat file, which is typ if it is not empty.
+func fileType(file, typ string) string {
+	switch strings.ToLower(typ) {
+	case typeGeoIP:
+		return typeGeoIP
+	case typeGeoSite:
+		return typeGeoSite
+	case "":
+	default:
+		base.Fatalf("unknown type: %s", typ)
+	}
+	name := strings.ToLower(filepath.Base(file))
+	switch {
+	case strings.Contains(name, typeGeoSite):
+		return typeGeoSite
+	case strings.Contains(name, typeGeoIP):
+		return typeGeoIP
+	default:
+		base.Fatalf("cannot tell the type of %s, specify it by -type", file)
+		return ""
+	}
+}
+
+func loadGeoData(file, typ string) *geoData {
+	data, err := os.ReadFile(file)
+	if os.IsNotExist(err) && !filepath.IsAbs(file) {
+		data, err = filesystem.ReadAsset(file)
+	}
+	if err != nil {
+		base.Fatalf("failed to read %s: %s", file, err)
+	}
+
+	d := &geoData{}
+	var list proto.Message
+	switch fileType(file, typ) {
+	case typeGeoIP:
+		d.geoip = &router.GeoIPList{}
+		list = d.geoip
+	case typeGeoSite:
+		d.geosite = &router.GeoSiteList{}
+		list = d.geosite
+	}
+	if err := proto.Unmarshal(data, list); err != nil {
+		base.Fatalf("failed to parse %s: %s", file, err)
+	}
+	return d
+}
+
+// entries returns the entries of every category in text form, by category code.
+func (d *geoData) entries() map[string][]string {
+	result := make(map[string][]string)
+	if d.geoip != nil {
+		for _, geoip := range d.geoip.Entry {
+			entries := result[geoip.CountryCode]
+			for _, cidr := range geoip.Cidr {
+				entries = append(entries, router.CIDRString(cidr))
+			}
+			result[geoip.CountryCode] = entries
+		}
+	} else {
+		for _, site := range d.geosite.Entry {
+			entries := result[site.CountryCode]
+			for _, domain := range site.Domain {
+				entries = append(entries, router.DomainString(domain))
+			}
+			result[site.CountryCode] = entries
+		}
+	}
+	return result
+}
+
+func addTypeFlag(cmd *base.Command) *string {
+	typ := new(string)
+	cmd.Flag.StringVar(typ, "t", "", "")
+	cmd.Flag.StringVar(typ, "type", "", "")
+	return typ
+}
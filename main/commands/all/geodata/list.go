@@ -0,0 +1,57 @@
+package geodata
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/xtls/xray-core/main/commands/base"
+)
+
+var cmdList = &base.Command{
+	CustomFlags: true,
+	UsageLine:   "{{.Exec}} geodata list [-type geoip|geosite] [-v] <dat file>",
+	Short:       "List categories of a dat file",
+	Long: `
+List categories of a geoip.dat or geosite.dat, with the number of entries of
+each category.
+
+Arguments:
+
+	-t, -type
+		The type of the dat file, geoip or geosite.
+
+	-v
+		Also list the entries of each category.
+
+Example:
+
+	{{.Exec}} {{.LongName}} geosite.dat
+	{{.Exec}} {{.LongName}} -v -type geoip private.dat
+`,
+	Run: executeList,
+}
+
+func executeList(cmd *base.Command, args []string) {
+	typ := addTypeFlag(cmd)
+	verbose := cmd.Flag.Bool("v", false, "")
+	cmd.Flag.Parse(args)
+	if cmd.Flag.NArg() != 1 {
+		base.Fatalf("a dat file is required")
+	}
+
+	entries := loadGeoData(cmd.Flag.Arg(0), *typ).entries()
+	codes := make([]string, 0, len(entries))
+	for code := range entries {
+		codes = append(codes, code)
+	}
+	slices.Sort(codes)
+	for _, code := range codes {
+		fmt.Printf("%s\t%d\n", strings.ToLower(code), len(entries[code]))
+		if *verbose {
+			for _, entry := range entries[code] {
+				fmt.Println("\t" + entry)
+			}
+		}
+	}
+}
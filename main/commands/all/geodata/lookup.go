@@ -0,0 +1,74 @@
+package geodata
+
+import (
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/xtls/xray-core/app/router"
+	"github.com/xtls/xray-core/main/commands/base"
+)
+
+var cmdLookup = &base.Command{
+	CustomFlags: true,
+	UsageLine:   "{{.Exec}} geodata lookup [-type geoip|geosite] <dat file> <domain|ip>...",
+	Short:       "Find categories containing a domain or IP",
+	Long: `
+Find the categories of a dat file containing each domain or IP, with the
+entries matched. Domains are matched as in routing, i.e. "domain:" matches
+subdomains, "keyword:" matches substrings, and so on.
+
+Each match is printed in a line of the domain or IP, the category and the
+entry, separated by tab.
+
+Arguments:
+
+	-t, -type
+		The type of the dat file, geoip or geosite.
+
+Example:
+
+	{{.Exec}} {{.LongName}} geosite.dat www.google.com
+	{{.Exec}} {{.LongName}} geoip.dat 8.8.8.8 2001:4860:4860::8888
+`,
+	Run: executeLookup,
+}
+
+func executeLookup(cmd *base.Command, args []string) {
+	typ := addTypeFlag(cmd)
+	cmd.Flag.Parse(args)
+	if cmd.Flag.NArg() < 2 {
+		base.Fatalf("a dat file and at least one domain or IP are required")
+	}
+
+	data := loadGeoData(cmd.Flag.Arg(0), *typ)
+	for _, target := range cmd.Flag.Args()[1:] {
+		found := false
+		if data.geoip != nil {
+			ip := net.ParseIP(target)
+			if ip == nil {
+				base.Fatalf("invalid IP: %s", target)
+			}
+			for _, geoip := range data.geoip.Entry {
+				for _, cidr := range router.MatchGeoIP(geoip, ip) {
+					fmt.Printf("%s\t%s\t%s\n", target, strings.ToLower(geoip.CountryCode), router.CIDRString(cidr))
+					found = true
+				}
+			}
+		} else {
+			for _, site := range data.geosite.Entry {
+				domains, err := router.MatchGeoSite(site, target)
+				if err != nil {
+					base.Fatalf("failed to match %s: %s", target, err)
+				}
+				for _, domain := range domains {
+					fmt.Printf("%s\t%s\t%s\n", target, strings.ToLower(site.CountryCode), router.DomainString(domain))
+					found = true
+				}
+			}
+		}
+		if !found {
+			fmt.Printf("%s\tnot found\n", target)
+		}
+	}
+}
@@ -40,6 +40,9 @@ The -test flag tells Xray to test config files only,
 without launching the server.
 
 The -dump flag tells Xray to print the merged config.
+
+Sending SIGHUP to the process reloads the config files. Unchanged 
+inbounds and outbounds keep their connections.
 	`,
 }
 
@@ -55,6 +58,9 @@ var (
 	test        = cmdRun.Flag.Bool("test", false, "Test config file only, without launching Xray server.")
 	format      = cmdRun.Flag.String("format", "auto", "Format of input file.")
 
+	// startConfigFiles are the config files the server is started with, and reloaded from on SIGHUP.
+	startConfigFiles cmdarg.Arg
+
 	/* We have to do this here because Golang's Test will also need to parse flag, before
 	 * main func in this file is run.
 	 */
@@ -105,8 +111,15 @@ func executeRun(cmd *base.Command, args []string) {
 
 	{
 		osSignals := make(chan os.Signal, 1)
-		signal.Notify(osSignals, os.Interrupt, syscall.SIGTERM)
-		<-osSignals
+		signal.Notify(osSignals, os.Interrupt, syscall.SIGTERM, syscall.SIGHUP)
+		for sig := range osSignals {
+			if sig != syscall.SIGHUP {
+				break
+			}
+			if err := reloadXray(server); err != nil {
+				log.Println("Failed to reload:", err)
+			}
+		}
 	}
 }
 
@@ -217,6 +230,7 @@ func getConfigFormat() string {
 
 func startXray() (core.Server, error) {
 	configFiles := getConfigFilePath(true)
+	startConfigFiles = configFiles
 
 	// config, err := core.LoadConfig(getConfigFormat(), configFiles[0], configFiles)
 
@@ -232,3 +246,23 @@ func startXray() (core.Server, error) {
 
 	return server, nil
 }
+
+// reloadXray reads the config files Xray was started with again and applies them to the running server.
+func reloadXray(server core.Server) error {
+	instance, ok := server.(*core.Instance)
+	if !ok {
+		return errors.New("server does not support reloading")
+	}
+	for _, file := range startConfigFiles {
+		if file == "stdin:" {
+			return errors.New("config from STDIN can not be reloaded")
+		}
+	}
+
+	log.Println("Reloading config files: [", startConfigFiles.String(), "]")
+	c, err := core.LoadConfig(getConfigFormat(), startConfigFiles)
+	if err != nil {
+		return errors.New("failed to load config files: [", startConfigFiles.String(), "]").Base(err)
+	}
+	return instance.Reload(c)
+}
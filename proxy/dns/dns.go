@@ -4,6 +4,9 @@ import (
 	"context"
 	go_errors "errors"
 	"io"
+	"slices"
+	"strconv"
+	"strings"
 	"sync"
 	"time"
 
@@ -45,12 +48,16 @@ type ownLinkVerifier interface {
 
 type Handler struct {
 	client          dns.Client
+	recordClient    dns.RecordClient
 	fdns            dns.FakeDNSEngine
 	ownLinkVerifier ownLinkVerifier
 	server          net.Destination
 	timeout         time.Duration
 	nonIPQuery      string
 	blockTypes      []int32
+
+	// handling is read locked by each goroutine answering a query, so that Close can wait for them.
+	handling sync.RWMutex
 }
 
 func (h *Handler) Init(config *Config, dnsClient dns.Client, policyManager policy.Manager) error {
@@ -60,6 +67,9 @@ func (h *Handler) Init(config *Config, dnsClient dns.Client, policyManager polic
 	if v, ok := dnsClient.(ownLinkVerifier); ok {
 		h.ownLinkVerifier = v
 	}
+	if v, ok := dnsClient.(dns.RecordClient); ok {
+		h.recordClient = v
+	}
 
 	if config.Server != nil {
 		h.server = config.Server.AsDestination()
@@ -100,6 +110,86 @@ func parseIPQuery(b []byte) (r bool, domain string, id uint16, qType dnsmessage.
 	return
 }
 
+// Types of records not defined in dnsmessage.
+const (
+	typeSVCB  dnsmessage.Type = 64
+	typeHTTPS dnsmessage.Type = 65
+)
+
+// isResolvableQuery returns whether queries of qType are resolved by the DNS client in the "resolve" mode.
+func isResolvableQuery(qType dnsmessage.Type) bool {
+	switch qType {
+	case typeHTTPS, typeSVCB, dnsmessage.TypeMX, dnsmessage.TypeTXT, dnsmessage.TypeSRV, dnsmessage.TypeCNAME, dnsmessage.TypePTR:
+		return true
+	}
+	return false
+}
+
+// parseReverseName returns the IP address of a reverse DNS name, like 1.0.18.198.in-addr.arpa, or nil if the name
+// is not a reverse DNS name.
+func parseReverseName(name string) net.IP {
+	name = strings.ToLower(strings.TrimSuffix(name, "."))
+	if s, ok := strings.CutSuffix(name, ".in-addr.arpa"); ok {
+		labels := strings.Split(s, ".")
+		if len(labels) != net.IPv4len {
+			return nil
+		}
+		slices.Reverse(labels)
+		return net.ParseIP(strings.Join(labels, ".")).To4()
+	}
+	if s, ok := strings.CutSuffix(name, ".ip6.arpa"); ok {
+		labels := strings.Split(s, ".")
+		if len(labels) != net.IPv6len*2 {
+			return nil
+		}
+		ip := make(net.IP, net.IPv6len)
+		for i, label := range labels {
+			if len(label) != 1 {
+				return nil
+			}
+			nibble, err := strconv.ParseUint(label, 16, 8)
+			if err != nil {
+				return nil
+			}
+			// Labels start from the lowest nibble.
+			j := len(labels) - 1 - i
+			ip[j/2] |= byte(nibble) << (4 * (1 - j%2))
+		}
+		return ip
+	}
+	return nil
+}
+
+// fakeDomainForPTR returns the domain of the fake IP in the reverse DNS name, or an empty string if the name is not
+// of a fake IP.
+func (h *Handler) fakeDomainForPTR(name string) string {
+	fdns, ok := h.fdns.(dns.FakeDNSEngineRev0)
+	if !ok {
+		return ""
+	}
+	ip := parseReverseName(name)
+	if ip == nil || !fdns.IsIPInIPPool(net.IPAddress(ip)) {
+		return ""
+	}
+	return fdns.GetDomainFromFakeDNS(net.IPAddress(ip))
+}
+
+// handle answers a query with f in a new goroutine.
+func (h *Handler) handle(f func()) {
+	h.handling.RLock()
+	go func() {
+		defer h.handling.RUnlock()
+		f()
+	}()
+}
+
+// Close implements common.Closable. It waits for the queries being answered.
+func (h *Handler) Close() error {
+	h.handling.Lock()
+	h.handling.Unlock()
+	return nil
+}
+
 // Process implements proxy.Outbound.
 func (h *Handler) Process(ctx context.Context, link *transport.Link, d internet.Dialer) error {
 	outbounds := session.OutboundsFromContext(ctx)
@@ -191,7 +281,7 @@ func (h *Handler) Process(ctx context.Context, link *transport.Link, d internet.
 					for _, blocktype := range h.blockTypes {
 						if blocktype == int32(qType) {
 							if h.nonIPQuery == "reject" {
-								go h.rejectNonIPQuery(id, qType, domain, writer)
+								h.handle(func() { h.rejectNonIPQuery(id, qType, domain, writer) })
 							}
 							errors.LogInfo(ctx, "blocked type ", qType, " query for domain ", domain)
 							return nil
@@ -199,14 +289,27 @@ func (h *Handler) Process(ctx context.Context, link *transport.Link, d internet.
 					}
 				}
 				if isIPQuery {
-					go h.handleIPQuery(id, qType, domain, writer)
+					h.handle(func() { h.handleIPQuery(id, qType, domain, writer) })
+				}
+				// Fake IPs are known only here, so their PTR queries are always answered.
+				if qType == dnsmessage.TypePTR {
+					if fakeDomain := h.fakeDomainForPTR(domain); fakeDomain != "" {
+						h.handle(func() { h.handleFakePTRQuery(id, domain, fakeDomain, writer) })
+						b.Release()
+						continue
+					}
 				}
 				if isIPQuery || h.nonIPQuery == "drop" {
 					b.Release()
 					continue
 				}
+				if h.nonIPQuery == "resolve" && h.recordClient != nil && isResolvableQuery(qType) {
+					h.handle(func() { h.handleRecordQuery(id, qType, domain, writer) })
+					b.Release()
+					continue
+				}
 				if h.nonIPQuery == "reject" {
-					go h.rejectNonIPQuery(id, qType, domain, writer)
+					h.handle(func() { h.rejectNonIPQuery(id, qType, domain, writer) })
 					b.Release()
 					continue
 				}
@@ -272,6 +375,8 @@ func (h *Handler) handleIPQuery(id uint16, qType dnsmessage.Type, domain string,
 		return
 	}
 
+	// The IPs may be shared with the DNS cache, so they are converted into a new slice.
+	ips = slices.Clone(ips)
 	switch qType {
 	case dnsmessage.TypeA:
 		for i, ip := range ips {
@@ -328,6 +433,78 @@ func (h *Handler) handleIPQuery(id uint16, qType dnsmessage.Type, domain string,
 	}
 }
 
+func (h *Handler) handleRecordQuery(id uint16, qType dnsmessage.Type, domain string, writer dns_proto.MessageWriter) {
+	answers, ttl, err := h.recordClient.LookupRecords(domain, qType)
+
+	rcode := dns.RCodeFromError(err)
+	if rcode == 0 && len(answers) == 0 && !go_errors.Is(err, dns.ErrEmptyResponse) {
+		errors.LogInfoInner(context.Background(), err, qType, " query")
+		return
+	}
+
+	resources := make([]dnsmessage.Resource, 0, len(answers))
+	for _, answer := range answers {
+		answer.Header.TTL = ttl
+		resources = append(resources, answer)
+	}
+	h.writeRecordAnswer(id, qType, domain, dnsmessage.RCode(rcode), resources, writer)
+}
+
+func (h *Handler) handleFakePTRQuery(id uint16, domain string, fakeDomain string, writer dns_proto.MessageWriter) {
+	name, err := dnsmessage.NewName(strings.TrimSuffix(fakeDomain, ".") + ".")
+	if err != nil {
+		errors.LogInfoInner(context.Background(), err, "unexpected fake domain ", fakeDomain)
+		return
+	}
+	h.writeRecordAnswer(id, dnsmessage.TypePTR, domain, dnsmessage.RCodeSuccess, []dnsmessage.Resource{{
+		Header: dnsmessage.ResourceHeader{
+			Name:  dnsmessage.MustNewName(domain),
+			Type:  dnsmessage.TypePTR,
+			Class: dnsmessage.ClassINET,
+			TTL:   1, // fakeIP ttl is 1
+		},
+		Body: &dnsmessage.PTRResource{PTR: name},
+	}}, writer)
+}
+
+func (h *Handler) writeRecordAnswer(id uint16, qType dnsmessage.Type, domain string, rcode dnsmessage.RCode, answers []dnsmessage.Resource, writer dns_proto.MessageWriter) {
+	msg := dnsmessage.Message{
+		Header: dnsmessage.Header{
+			ID:                 id,
+			RCode:              rcode,
+			RecursionAvailable: true,
+			RecursionDesired:   true,
+			Response:           true,
+			Authoritative:      true,
+		},
+		Questions: []dnsmessage.Question{{
+			Name:  dnsmessage.MustNewName(domain),
+			Class: dnsmessage.ClassINET,
+			Type:  qType,
+		}},
+		Answers: answers,
+	}
+
+	b := buf.New()
+	rawBytes := b.Extend(buf.Size)
+	msgBytes, err := msg.AppendPack(rawBytes[:0])
+	if err != nil {
+		errors.LogInfoInner(context.Background(), err, "pack message")
+		b.Release()
+		return
+	}
+	if len(msgBytes) > buf.Size {
+		errors.LogInfo(context.Background(), "too large ", qType, " answer for domain ", domain, ": ", len(msgBytes), " bytes")
+		b.Release()
+		return
+	}
+	b.Resize(0, int32(len(msgBytes)))
+
+	if err := writer.WriteMessage(b); err != nil {
+		errors.LogInfoInner(context.Background(), err, "write ", qType, " answer")
+	}
+}
+
 func (h *Handler) rejectNonIPQuery(id uint16, qType dnsmessage.Type, domain string, writer dns_proto.MessageWriter) {
 	b := buf.New()
 	rawBytes := b.Extend(buf.Size)
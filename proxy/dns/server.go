@@ -0,0 +1,513 @@
+package dns
+
+import (
+	"context"
+	gotls "crypto/tls"
+	"encoding/base64"
+	go_errors "errors"
+	"io"
+	gonet "net"
+	"net/http"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/quic-go/quic-go"
+	"github.com/quic-go/quic-go/http3"
+	"github.com/xtls/xray-core/common"
+	"github.com/xtls/xray-core/common/buf"
+	"github.com/xtls/xray-core/common/errors"
+	"github.com/xtls/xray-core/common/net"
+	dns_proto "github.com/xtls/xray-core/common/protocol/dns"
+	"github.com/xtls/xray-core/common/signal"
+	"github.com/xtls/xray-core/core"
+	"github.com/xtls/xray-core/features/dns"
+	"github.com/xtls/xray-core/features/policy"
+	"github.com/xtls/xray-core/features/routing"
+	"github.com/xtls/xray-core/transport/internet/stat"
+	"github.com/xtls/xray-core/transport/internet/tls"
+	"golang.org/x/net/dns/dnsmessage"
+)
+
+func init() {
+	common.Must(common.RegisterConfig((*ServerConfig)(nil), func(ctx context.Context, config interface{}) (interface{}, error) {
+		s := new(Server)
+		if err := core.RequireFeatures(ctx, func(dnsClient dns.Client, policyManager policy.Manager) error {
+			var fdns dns.FakeDNSEngine
+			core.OptionalFeatures(ctx, func(fd dns.FakeDNSEngine) {
+				fdns = fd
+			})
+			return s.Init(config.(*ServerConfig), dnsClient, fdns, policyManager)
+		}); err != nil {
+			return nil, err
+		}
+		return s, nil
+	}))
+}
+
+const (
+	// nextProtoDoT is the ALPN token of DNS over TLS.
+	nextProtoDoT = "dot"
+	// defaultDoHPath is the path of DNS over HTTPS queries suggested by RFC 8484.
+	defaultDoHPath = "/dns-query"
+	// dohContentType is the media type of DNS over HTTPS messages.
+	dohContentType = "application/dns-message"
+)
+
+// Server is an inbound serving DNS over TLS and DNS over HTTPS, including HTTP/3. It answers queries with the DNS
+// app, as the DNS outbound does in the "resolve" mode.
+type Server struct {
+	handler   *Handler
+	config    *ServerConfig
+	path      string
+	tlsConfig *gotls.Config
+	h3Config  *gotls.Config
+}
+
+// Init initializes the Server with necessary parameters.
+func (s *Server) Init(config *ServerConfig, dnsClient dns.Client, fdns dns.FakeDNSEngine, policyManager policy.Manager) error {
+	switch config.Protocol {
+	case "dot", "doh":
+	default:
+		return errors.New("unknown DNS server protocol: ", config.Protocol)
+	}
+	if len(config.Networks) == 0 {
+		return errors.New("no network specified")
+	}
+	if net.HasNetwork(config.Networks, net.Network_UDP) && (config.Protocol != "doh" || config.TlsSettings == nil) {
+		return errors.New("UDP is only for DNS over HTTP/3, which requires TLS settings")
+	}
+
+	s.handler = new(Handler)
+	if err := s.handler.Init(&Config{UserLevel: config.UserLevel, Non_IPQuery: "resolve"}, dnsClient, policyManager); err != nil {
+		return err
+	}
+	s.handler.fdns = fdns
+	s.config = config
+	s.path = config.Path
+	if s.path == "" {
+		s.path = defaultDoHPath
+	}
+	if config.TlsSettings != nil {
+		if config.Protocol == "dot" {
+			s.tlsConfig = config.TlsSettings.GetTLSConfig(tls.WithNextProto(nextProtoDoT))
+		} else {
+			s.tlsConfig = config.TlsSettings.GetTLSConfig()
+			// QUIC fails to complete handshakes with session tickets disabled.
+			h3Config := s.tlsConfig.Clone()
+			h3Config.SessionTicketsDisabled = false
+			s.h3Config = http3.ConfigureTLSConfig(h3Config)
+		}
+	}
+	return nil
+}
+
+// Network implements proxy.Inbound.
+func (s *Server) Network() []net.Network {
+	return s.config.Networks
+}
+
+// Process implements proxy.Inbound.
+func (s *Server) Process(ctx context.Context, network net.Network, conn stat.Connection, dispatcher routing.Dispatcher) error {
+	errors.LogDebug(ctx, "serving ", s.config.Protocol, " connection from: ", conn.RemoteAddr())
+	if network == net.Network_UDP {
+		return s.serveHTTP3(ctx, conn)
+	}
+
+	var c net.Conn = conn
+	if s.tlsConfig != nil {
+		tlsConn := gotls.Server(conn, s.tlsConfig)
+		if err := tlsConn.HandshakeContext(ctx); err != nil {
+			return errors.New("failed TLS handshake").Base(err)
+		}
+		c = tlsConn
+	}
+	if s.config.Protocol == "dot" {
+		return s.serveTLS(ctx, c)
+	}
+	return s.serveHTTP(ctx, c)
+}
+
+// serveTLS answers DNS over TLS queries of RFC 7858, which may be pipelined on the connection.
+func (s *Server) serveTLS(ctx context.Context, conn net.Conn) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	timer := signal.CancelAfterInactivity(ctx, cancel, s.handler.timeout)
+	go func() {
+		<-ctx.Done()
+		conn.Close()
+	}()
+
+	reader := dns_proto.NewTCPReader(buf.NewReader(conn))
+	writer := &syncWriter{MessageWriter: &dns_proto.TCPWriter{Writer: buf.NewWriter(conn)}}
+	for {
+		b, err := reader.ReadMessage()
+		if err == io.EOF || ctx.Err() != nil {
+			return nil
+		}
+		if err != nil {
+			return errors.New("failed to read DNS message").Base(err)
+		}
+		timer.Update()
+
+		go func() {
+			s.handler.serveQuery(b.Bytes(), writer)
+			b.Release()
+		}()
+	}
+}
+
+// serveHTTP serves DNS over HTTPS of HTTP/1.1 and HTTP/2 on the connection.
+func (s *Server) serveHTTP(ctx context.Context, conn net.Conn) error {
+	listener := newConnListener(conn)
+	protocols := new(http.Protocols)
+	protocols.SetHTTP1(true)
+	protocols.SetHTTP2(true)
+	protocols.SetUnencryptedHTTP2(true)
+	server := &http.Server{
+		Handler:           s,
+		ReadHeaderTimeout: time.Second * 4,
+		IdleTimeout:       s.handler.timeout,
+		MaxHeaderBytes:    8192,
+		Protocols:         protocols,
+		ConnState: func(_ net.Conn, state http.ConnState) {
+			if state == http.StateClosed || state == http.StateHijacked {
+				listener.Close()
+			}
+		},
+	}
+
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-ctx.Done():
+			server.Close()
+		case <-done:
+		}
+	}()
+
+	if err := server.Serve(listener); err != nil && !go_errors.Is(err, gonet.ErrClosed) && err != http.ErrServerClosed {
+		return errors.New("failed to serve DNS over HTTPS").Base(err)
+	}
+	return nil
+}
+
+// serveHTTP3 serves DNS over HTTP/3 on the UDP packets from a single source.
+func (s *Server) serveHTTP3(ctx context.Context, conn stat.Connection) error {
+	pc := newPacketConn(conn)
+	defer pc.Close()
+	server := &http3.Server{
+		Handler:   s,
+		TLSConfig: s.h3Config,
+		QUICConfig: &quic.Config{
+			MaxIdleTimeout: s.handler.timeout,
+		},
+	}
+
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-ctx.Done():
+			server.Close()
+		case <-done:
+		}
+	}()
+
+	if err := server.Serve(pc); err != nil && ctx.Err() == nil && !go_errors.Is(err, gonet.ErrClosed) && err != http.ErrServerClosed {
+		return errors.New("failed to serve DNS over HTTP/3").Base(err)
+	}
+	return nil
+}
+
+// ServeHTTP answers DNS over HTTPS queries of RFC 8484.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != s.path {
+		http.NotFound(w, r)
+		return
+	}
+
+	var msg []byte
+	switch r.Method {
+	case http.MethodGet:
+		b, err := base64.RawURLEncoding.DecodeString(r.URL.Query().Get("dns"))
+		if err != nil || len(b) == 0 {
+			http.Error(w, "invalid dns parameter", http.StatusBadRequest)
+			return
+		}
+		msg = b
+	case http.MethodPost:
+		if r.Header.Get("Content-Type") != dohContentType {
+			http.Error(w, "unsupported content type", http.StatusUnsupportedMediaType)
+			return
+		}
+		b, err := io.ReadAll(io.LimitReader(r.Body, buf.Size+1))
+		if err != nil {
+			http.Error(w, "failed to read body", http.StatusBadRequest)
+			return
+		}
+		msg = b
+	default:
+		w.Header().Set("Allow", "GET, POST")
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if len(msg) > buf.Size {
+		http.Error(w, "message too large", http.StatusRequestEntityTooLarge)
+		return
+	}
+
+	answer := new(answerWriter)
+	s.handler.serveQuery(msg, answer)
+	if answer.msg == nil {
+		http.Error(w, "failed to answer", http.StatusBadGateway)
+		return
+	}
+	defer answer.msg.Release()
+
+	w.Header().Set("Content-Type", dohContentType)
+	if ttl, ok := minAnswerTTL(answer.msg.Bytes()); ok {
+		w.Header().Set("Cache-Control", "max-age="+strconv.FormatUint(uint64(ttl), 10))
+	}
+	w.Write(answer.msg.Bytes())
+}
+
+// minAnswerTTL returns the smallest TTL of the records in the answer section of msg, or of the authority section if
+// the answer section is empty, as the freshness lifetime of the DNS over HTTPS response suggested by RFC 8484.
+func minAnswerTTL(msg []byte) (uint32, bool) {
+	var p dnsmessage.Parser
+	if _, err := p.Start(msg); err != nil {
+		return 0, false
+	}
+	if err := p.SkipAllQuestions(); err != nil {
+		return 0, false
+	}
+	var ttl uint32
+	found := false
+	sections := []struct {
+		header func() (dnsmessage.ResourceHeader, error)
+		skip   func() error
+	}{
+		{p.AnswerHeader, p.SkipAnswer},
+		{p.AuthorityHeader, p.SkipAuthority},
+	}
+	for _, section := range sections {
+		for {
+			h, err := section.header()
+			if err != nil {
+				break
+			}
+			if !found || h.TTL < ttl {
+				ttl = h.TTL
+			}
+			found = true
+			if err := section.skip(); err != nil {
+				return 0, false
+			}
+		}
+		if found {
+			break
+		}
+	}
+	return ttl, found
+}
+
+// serveQuery answers the query with the DNS client. Queries which cannot be resolved are rejected.
+func (h *Handler) serveQuery(msg []byte, writer dns_proto.MessageWriter) {
+	isIPQuery, domain, id, qType := parseIPQuery(msg)
+	if domain == "" {
+		return
+	}
+	if isIPQuery {
+		h.handleIPQuery(id, qType, domain, writer)
+		return
+	}
+	if qType == dnsmessage.TypePTR {
+		if fakeDomain := h.fakeDomainForPTR(domain); fakeDomain != "" {
+			h.handleFakePTRQuery(id, domain, fakeDomain, writer)
+			return
+		}
+	}
+	if h.recordClient != nil && isResolvableQuery(qType) {
+		h.handleRecordQuery(id, qType, domain, writer)
+		return
+	}
+	h.rejectNonIPQuery(id, qType, domain, writer)
+}
+
+// syncWriter serializes answers written concurrently to a stream.
+type syncWriter struct {
+	sync.Mutex
+	dns_proto.MessageWriter
+}
+
+func (w *syncWriter) WriteMessage(b *buf.Buffer) error {
+	w.Lock()
+	defer w.Unlock()
+	return w.MessageWriter.WriteMessage(b)
+}
+
+// answerWriter keeps the answer of a DNS over HTTPS query.
+type answerWriter struct {
+	msg *buf.Buffer
+}
+
+func (w *answerWriter) WriteMessage(b *buf.Buffer) error {
+	w.msg = b
+	return nil
+}
+
+// connListener is the net.Listener of a single connection, so that the connection is served by http.Server.
+type connListener struct {
+	conns chan net.Conn
+	addr  net.Addr
+	done  chan struct{}
+	once  sync.Once
+}
+
+func newConnListener(conn net.Conn) *connListener {
+	l := &connListener{
+		conns: make(chan net.Conn, 1),
+		addr:  conn.LocalAddr(),
+		done:  make(chan struct{}),
+	}
+	l.conns <- conn
+	return l
+}
+
+func (l *connListener) Accept() (net.Conn, error) {
+	select {
+	case conn := <-l.conns:
+		return conn, nil
+	case <-l.done:
+		return nil, gonet.ErrClosed
+	}
+}
+
+func (l *connListener) Close() error {
+	l.once.Do(func() {
+		close(l.done)
+	})
+	return nil
+}
+
+func (l *connListener) Addr() net.Addr {
+	return l.addr
+}
+
+// packetConn is the net.PacketConn of the UDP packets from a single source, on which QUIC connections are served.
+type packetConn struct {
+	conn    stat.Connection
+	packets chan *buf.Buffer
+	done    chan struct{}
+	once    sync.Once
+
+	access   sync.Mutex
+	timer    *time.Timer
+	deadline chan struct{}
+}
+
+func newPacketConn(conn stat.Connection) *packetConn {
+	c := &packetConn{
+		conn:     conn,
+		packets:  make(chan *buf.Buffer, 16),
+		done:     make(chan struct{}),
+		deadline: make(chan struct{}),
+	}
+	go c.readPackets()
+	return c
+}
+
+func (c *packetConn) readPackets() {
+	defer close(c.packets)
+	reader := buf.NewPacketReader(c.conn)
+	for {
+		mb, err := reader.ReadMultiBuffer()
+		if err != nil {
+			return
+		}
+		for i, b := range mb {
+			select {
+			case c.packets <- b:
+			case <-c.done:
+				buf.ReleaseMulti(mb[i:])
+				return
+			}
+		}
+	}
+}
+
+func (c *packetConn) ReadFrom(p []byte) (int, net.Addr, error) {
+	c.access.Lock()
+	deadline := c.deadline
+	c.access.Unlock()
+
+	select {
+	case b, ok := <-c.packets:
+		if !ok {
+			return 0, nil, io.EOF
+		}
+		n := copy(p, b.Bytes())
+		b.Release()
+		return n, c.conn.RemoteAddr(), nil
+	case <-deadline:
+		return 0, nil, os.ErrDeadlineExceeded
+	case <-c.done:
+		return 0, nil, gonet.ErrClosed
+	}
+}
+
+func (c *packetConn) WriteTo(p []byte, _ net.Addr) (int, error) {
+	return c.conn.Write(p)
+}
+
+func (c *packetConn) Close() error {
+	c.once.Do(func() {
+		close(c.done)
+	})
+	return nil
+}
+
+func (c *packetConn) LocalAddr() net.Addr {
+	return c.conn.LocalAddr()
+}
+
+func (c *packetConn) SetDeadline(t time.Time) error {
+	return c.SetReadDeadline(t)
+}
+
+// SetReadDeadline interrupts ReadFrom at t, which QUIC relies on to stop reading.
+func (c *packetConn) SetReadDeadline(t time.Time) error {
+	c.access.Lock()
+	defer c.access.Unlock()
+
+	if c.timer != nil {
+		// The timer may be firing, so its channel is left to it.
+		c.timer.Stop()
+		c.timer = nil
+		c.deadline = make(chan struct{})
+	}
+	select {
+	case <-c.deadline:
+		c.deadline = make(chan struct{})
+	default:
+	}
+	if t.IsZero() {
+		return nil
+	}
+	deadline := c.deadline
+	if d := time.Until(t); d > 0 {
+		c.timer = time.AfterFunc(d, func() {
+			close(deadline)
+		})
+	} else {
+		close(deadline)
+	}
+	return nil
+}
+
+func (c *packetConn) SetWriteDeadline(time.Time) error {
+	return nil
+}
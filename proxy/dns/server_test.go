@@ -0,0 +1,249 @@
+package dns_test
+
+import (
+	"bytes"
+	gotls "crypto/tls"
+	"encoding/base64"
+	"io"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/miekg/dns"
+	"github.com/quic-go/quic-go/http3"
+	"github.com/xtls/xray-core/app/dispatcher"
+	dnsapp "github.com/xtls/xray-core/app/dns"
+	"github.com/xtls/xray-core/app/policy"
+	"github.com/xtls/xray-core/app/proxyman"
+	"github.com/xtls/xray-core/common"
+	"github.com/xtls/xray-core/common/net"
+	"github.com/xtls/xray-core/common/protocol/tls/cert"
+	"github.com/xtls/xray-core/common/serial"
+	"github.com/xtls/xray-core/core"
+	dns_proxy "github.com/xtls/xray-core/proxy/dns"
+	"github.com/xtls/xray-core/proxy/freedom"
+	"github.com/xtls/xray-core/testing/servers/tcp"
+	"github.com/xtls/xray-core/testing/servers/udp"
+	"github.com/xtls/xray-core/transport/internet/tls"
+)
+
+func startDNSServer(t *testing.T, serverPort net.Port, config *dns_proxy.ServerConfig) {
+	port := udp.PickPort()
+
+	dnsServer := dns.Server{
+		Addr:    "127.0.0.1:" + port.String(),
+		Net:     "udp",
+		Handler: &staticHandler{},
+		UDPSize: 1200,
+	}
+	go dnsServer.ListenAndServe()
+	t.Cleanup(func() { dnsServer.Shutdown() })
+	time.Sleep(time.Second)
+
+	config.TlsSettings = &tls.Config{
+		Certificate: []*tls.Certificate{tls.ParseCertificate(cert.MustGenerate(nil, cert.DNSNames("dns.example.com")))},
+	}
+	v, err := core.New(&core.Config{
+		App: []*serial.TypedMessage{
+			serial.ToTypedMessage(&dnsapp.Config{
+				NameServer: []*dnsapp.NameServer{
+					{
+						Address: &net.Endpoint{
+							Network: net.Network_UDP,
+							Address: &net.IPOrDomain{
+								Address: &net.IPOrDomain_Ip{
+									Ip: []byte{127, 0, 0, 1},
+								},
+							},
+							Port: uint32(port),
+						},
+					},
+				},
+			}),
+			serial.ToTypedMessage(&dispatcher.Config{}),
+			serial.ToTypedMessage(&proxyman.OutboundConfig{}),
+			serial.ToTypedMessage(&proxyman.InboundConfig{}),
+			serial.ToTypedMessage(&policy.Config{}),
+		},
+		Inbound: []*core.InboundHandlerConfig{
+			{
+				ProxySettings: serial.ToTypedMessage(config),
+				ReceiverSettings: serial.ToTypedMessage(&proxyman.ReceiverConfig{
+					PortList: &net.PortList{Range: []*net.PortRange{net.SinglePortRange(serverPort)}},
+					Listen:   net.NewIPOrDomain(net.LocalHostIP),
+				}),
+			},
+		},
+		Outbound: []*core.OutboundHandlerConfig{
+			{
+				ProxySettings: serial.ToTypedMessage(&freedom.Config{}),
+			},
+		},
+	})
+	common.Must(err)
+	common.Must(v.Start())
+	t.Cleanup(func() { v.Close() })
+}
+
+func newQuery(name string, qType uint16) *dns.Msg {
+	m := new(dns.Msg)
+	m.Id = dns.Id()
+	m.RecursionDesired = true
+	m.Question = []dns.Question{{Name: name, Qtype: qType, Qclass: dns.ClassINET}}
+	return m
+}
+
+func checkAnswerA(t *testing.T, in *dns.Msg, ip net.IP) {
+	t.Helper()
+	if len(in.Answer) != 1 {
+		t.Fatal("len(answer): ", len(in.Answer))
+	}
+	rr, ok := in.Answer[0].(*dns.A)
+	if !ok {
+		t.Fatal("not A record")
+	}
+	if r := cmp.Diff(rr.A[:], ip); r != "" {
+		t.Error(r)
+	}
+}
+
+func exchangeHTTPS(t *testing.T, client *http.Client, url string, method string, m *dns.Msg) (*dns.Msg, *http.Response) {
+	t.Helper()
+	msg, err := m.Pack()
+	common.Must(err)
+
+	var req *http.Request
+	if method == http.MethodGet {
+		req, err = http.NewRequest(method, url+"?dns="+base64.RawURLEncoding.EncodeToString(msg), nil)
+	} else {
+		req, err = http.NewRequest(method, url, bytes.NewReader(msg))
+		req.Header.Set("Content-Type", "application/dns-message")
+	}
+	common.Must(err)
+	resp, err := client.Do(req)
+	common.Must(err)
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatal("unexpected status: ", resp.Status)
+	}
+	if ct := resp.Header.Get("Content-Type"); ct != "application/dns-message" {
+		t.Error("unexpected content type: ", ct)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	common.Must(err)
+	in := new(dns.Msg)
+	common.Must(in.Unpack(body))
+	return in, resp
+}
+
+func TestDoTServer(t *testing.T) {
+	serverPort := tcp.PickPort()
+	startDNSServer(t, serverPort, &dns_proxy.ServerConfig{
+		Protocol: "dot",
+		Networks: []net.Network{net.Network_TCP},
+	})
+
+	c := &dns.Client{
+		Net: "tcp-tls",
+		TLSConfig: &gotls.Config{
+			InsecureSkipVerify: true,
+			NextProtos:         []string{"dot"},
+		},
+	}
+	conn, err := c.Dial("127.0.0.1:" + serverPort.String())
+	common.Must(err)
+	defer conn.Close()
+
+	if p := conn.Conn.(*gotls.Conn).ConnectionState().NegotiatedProtocol; p != "dot" {
+		t.Error("expect ALPN dot, but got ", p)
+	}
+	for _, name := range []string{"google.com.", "facebook.com."} {
+		in, _, err := c.ExchangeWithConn(newQuery(name, dns.TypeA), conn)
+		common.Must(err)
+		if name == "google.com." {
+			checkAnswerA(t, in, net.IP{8, 8, 8, 8})
+		} else {
+			checkAnswerA(t, in, net.IP{9, 9, 9, 9})
+		}
+	}
+
+	in, _, err := c.ExchangeWithConn(newQuery("notexist.google.com.", dns.TypeAAAA), conn)
+	common.Must(err)
+	if in.Rcode != dns.RcodeNameError {
+		t.Error("expected NameError, but got ", in.Rcode)
+	}
+}
+
+func TestDoHServer(t *testing.T) {
+	serverPort := tcp.PickPort()
+	startDNSServer(t, serverPort, &dns_proxy.ServerConfig{
+		Protocol: "doh",
+		Networks: []net.Network{net.Network_TCP},
+	})
+
+	client := &http.Client{
+		Transport: &http.Transport{
+			TLSClientConfig:   &gotls.Config{InsecureSkipVerify: true},
+			ForceAttemptHTTP2: true,
+		},
+	}
+	url := "https://127.0.0.1:" + serverPort.String() + "/dns-query"
+
+	in, resp := exchangeHTTPS(t, client, url, http.MethodPost, newQuery("google.com.", dns.TypeA))
+	checkAnswerA(t, in, net.IP{8, 8, 8, 8})
+	if resp.ProtoMajor != 2 {
+		t.Error("expect HTTP/2, but got HTTP/", resp.ProtoMajor)
+	}
+	if cc := resp.Header.Get("Cache-Control"); cc != "max-age="+strconv.Itoa(int(in.Answer[0].Header().Ttl)) {
+		t.Error("unexpected Cache-Control: ", cc)
+	}
+
+	in, _ = exchangeHTTPS(t, client, url, http.MethodGet, newQuery("facebook.com.", dns.TypeA))
+	checkAnswerA(t, in, net.IP{9, 9, 9, 9})
+
+	in, _ = exchangeHTTPS(t, client, url, http.MethodGet, newQuery("google.com.", dns.TypeMX))
+	if len(in.Answer) != 1 {
+		t.Fatal("len(answer): ", len(in.Answer))
+	}
+	if rr, ok := in.Answer[0].(*dns.MX); !ok || rr.Mx != "smtp.google.com." {
+		t.Error("unexpected MX answer: ", in.Answer[0])
+	}
+
+	resp, err := client.Get("https://127.0.0.1:" + serverPort.String() + "/")
+	common.Must(err)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusNotFound {
+		t.Error("expect not found, but got ", resp.Status)
+	}
+}
+
+func TestDoH3Server(t *testing.T) {
+	serverPort := udp.PickPort()
+	startDNSServer(t, serverPort, &dns_proxy.ServerConfig{
+		Protocol: "doh",
+		Path:     "/resolve",
+		Networks: []net.Network{net.Network_UDP},
+	})
+
+	transport := &http3.Transport{
+		TLSClientConfig: &gotls.Config{InsecureSkipVerify: true},
+	}
+	defer transport.Close()
+	client := &http.Client{
+		Transport: transport,
+		Timeout:   time.Second * 5,
+	}
+	url := "https://127.0.0.1:" + serverPort.String() + "/resolve"
+
+	in, resp := exchangeHTTPS(t, client, url, http.MethodPost, newQuery("google.com.", dns.TypeA))
+	checkAnswerA(t, in, net.IP{8, 8, 8, 8})
+	if resp.ProtoMajor != 3 {
+		t.Error("expect HTTP/3, but got HTTP/", resp.ProtoMajor)
+	}
+
+	in, _ = exchangeHTTPS(t, client, url, http.MethodGet, newQuery("facebook.com.", dns.TypeA))
+	checkAnswerA(t, in, net.IP{9, 9, 9, 9})
+}
@@ -23,6 +23,7 @@ import (
 	"github.com/xtls/xray-core/common/protocol"
 	"github.com/xtls/xray-core/common/session"
 	"github.com/xtls/xray-core/common/signal"
+	"github.com/xtls/xray-core/features/policy"
 	"github.com/xtls/xray-core/features/routing"
 	"github.com/xtls/xray-core/features/stats"
 	"github.com/xtls/xray-core/proxy/vless/encryption"
@@ -676,7 +677,6 @@ func CopyRawConnIfExist(ctx context.Context, readerConn net.Conn, writerConn net
 		}
 		if splice {
 			errors.LogInfo(ctx, "CopyRawConn splice")
-			statWriter, _ := writer.(*dispatcher.SizeStatWriter)
 			//runtime.Gosched() // necessary
 			time.Sleep(time.Millisecond)    // without this, there will be a rare ssl error for freedom splice
 			timer.SetTimeout(8 * time.Hour) // prevent leak, just in case
@@ -690,9 +690,7 @@ func CopyRawConnIfExist(ctx context.Context, readerConn net.Conn, writerConn net
 			if writeCounter != nil {
 				writeCounter.Add(w) // inbound stats
 			}
-			if statWriter != nil {
-				statWriter.Counter.Add(w) // user stats
-			}
+			dispatcher.AddSplicedSize(writer, w) // user, rule and connection stats
 			if err != nil && errors.Cause(err) != io.EOF {
 				return err
 			}
@@ -735,3 +733,18 @@ func IsRAWTransportWithoutSecurity(conn stat.Connection) bool {
 	_, ok3 := iConn.(*internet.UnixConnWrapper)
 	return ok1 || ok2 || ok3
 }
+
+// AcquireUserConnection registers the connection of the authenticated user of the inbound session with the connection
+// limits of the policy manager. The returned function must be called when the connection ends.
+func AcquireUserConnection(ctx context.Context, pm policy.Manager, conn io.Closer) (func(), error) {
+	limiter, ok := pm.(policy.ConnectionLimiter)
+	inbound := session.InboundFromContext(ctx)
+	if !ok || inbound == nil || inbound.User == nil {
+		return func() {}, nil
+	}
+	var source string
+	if inbound.Source.IsValid() {
+		source = inbound.Source.Address.String()
+	}
+	return limiter.AcquireConnection(inbound.User.Level, inbound.User.Email, source, conn)
+}
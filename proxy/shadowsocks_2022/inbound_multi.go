@@ -24,7 +24,10 @@ import (
 	"github.com/xtls/xray-core/common/session"
 	"github.com/xtls/xray-core/common/singbridge"
 	"github.com/xtls/xray-core/common/uuid"
+	"github.com/xtls/xray-core/core"
+	"github.com/xtls/xray-core/features/policy"
 	"github.com/xtls/xray-core/features/routing"
+	"github.com/xtls/xray-core/proxy"
 	"github.com/xtls/xray-core/transport/internet/stat"
 )
 
@@ -39,6 +42,8 @@ type MultiUserInbound struct {
 	networks []net.Network
 	users    []*protocol.MemoryUser
 	service  *shadowaead_2022.MultiService[int]
+
+	policyManager policy.Manager
 }
 
 func NewMultiServer(ctx context.Context, config *MultiUserServerConfig) (*MultiUserInbound, error) {
@@ -62,9 +67,11 @@ func NewMultiServer(ctx context.Context, config *MultiUserServerConfig) (*MultiU
 		memUsers = append(memUsers, u)
 	}
 
+	v := core.MustFromContext(ctx)
 	inbound := &MultiUserInbound{
-		networks: networks,
-		users:    memUsers,
+		networks:      networks,
+		users:         memUsers,
+		policyManager: v.GetFeature(policy.ManagerType()).(policy.Manager),
 	}
 	if config.Key == "" {
 		return nil, errors.New("missing key")
@@ -229,6 +236,11 @@ func (i *MultiUserInbound) NewConnection(ctx context.Context, conn net.Conn, met
 	userInt, _ := A.UserFromContext[int](ctx)
 	user := i.users[userInt]
 	inbound.User = user
+	release, err := proxy.AcquireUserConnection(ctx, i.policyManager, conn)
+	if err != nil {
+		return errors.New("rejected connection from ", metadata.Source).Base(err).AtInfo()
+	}
+	defer release()
 	ctx = log.ContextWithAccessMessage(ctx, &log.AccessMessage{
 		From:   metadata.Source,
 		To:     metadata.Destination,
@@ -254,6 +266,11 @@ func (i *MultiUserInbound) NewPacketConnection(ctx context.Context, conn N.Packe
 	userInt, _ := A.UserFromContext[int](ctx)
 	user := i.users[userInt]
 	inbound.User = user
+	release, err := proxy.AcquireUserConnection(ctx, i.policyManager, conn)
+	if err != nil {
+		return errors.New("rejected connection from ", metadata.Source).Base(err).AtInfo()
+	}
+	defer release()
 	ctx = log.ContextWithAccessMessage(ctx, &log.AccessMessage{
 		From:   metadata.Source,
 		To:     metadata.Destination,
@@ -482,6 +482,12 @@ func (h *Handler) Process(ctx context.Context, network net.Network, connection s
 	inbound.User = request.User
 	inbound.VlessRoute = net.PortFromBytes(userSentID[6:8])
 
+	release, err := proxy.AcquireUserConnection(ctx, h.policyManager, connection)
+	if err != nil {
+		return errors.New("rejected connection from ", connection.RemoteAddr()).Base(err).AtInfo()
+	}
+	defer release()
+
 	account := request.User.Account.(*vless.MemoryAccount)
 
 	responseAddons := &encoding.Addons{
@@ -46,8 +46,10 @@ func (c *Config) loadSelfCertPool() (*x509.CertPool, error) {
 	return root, nil
 }
 
-// BuildCertificates builds a list of TLS certificates from proto definition.
-func (c *Config) BuildCertificates() []*tls.Certificate {
+// buildCertificates builds a list of TLS certificates from proto definition. The certificates in the list are
+// replaced on reload of their files or OCSP staples, with access locked.
+func (c *Config) buildCertificates() ([]*tls.Certificate, *sync.RWMutex) {
+	access := new(sync.RWMutex)
 	certs := make([]*tls.Certificate, 0, len(c.Certificate))
 	for _, entry := range c.Certificate {
 		if entry.Usage != Certificate_ENCIPHERMENT {
@@ -73,7 +75,9 @@ func (c *Config) BuildCertificates() []*tls.Certificate {
 		}
 		index := len(certs) - 1
 		setupOcspTicker(entry, func(isReloaded, isOcspstapling bool) {
+			access.RLock()
 			cert := certs[index]
+			access.RUnlock()
 			if isReloaded {
 				if newKeyPair := getX509KeyPair(); newKeyPair != nil {
 					cert = newKeyPair
@@ -85,13 +89,18 @@ func (c *Config) BuildCertificates() []*tls.Certificate {
 				if newOCSPData, err := ocsp.GetOCSPForCert(cert.Certificate); err != nil {
 					errors.LogWarningInner(context.Background(), err, "ignoring invalid OCSP")
 				} else if string(newOCSPData) != string(cert.OCSPStaple) {
-					cert.OCSPStaple = newOCSPData
+					// The certificate may be in use by handshakes, so the staple is set on a copy.
+					stapled := *cert
+					stapled.OCSPStaple = newOCSPData
+					cert = &stapled
 				}
 			}
+			access.Lock()
 			certs[index] = cert
+			access.Unlock()
 		})
 	}
-	return certs
+	return certs, access
 }
 
 func setupOcspTicker(entry *Certificate, callback func(isReloaded, isOcspstapling bool)) {
@@ -244,8 +253,11 @@ func getGetCertificateFunc(c *tls.Config, ca []*Certificate) func(hello *tls.Cli
 	}
 }
 
-func getNewGetCertificateFunc(certs []*tls.Certificate, rejectUnknownSNI bool) func(hello *tls.ClientHelloInfo) (*tls.Certificate, error) {
+func getNewGetCertificateFunc(certs []*tls.Certificate, access *sync.RWMutex, rejectUnknownSNI bool) func(hello *tls.ClientHelloInfo) (*tls.Certificate, error) {
 	return func(hello *tls.ClientHelloInfo) (*tls.Certificate, error) {
+		access.RLock()
+		defer access.RUnlock()
+
 		if len(certs) == 0 {
 			return nil, errNoCertificates
 		}
@@ -390,7 +402,8 @@ func (c *Config) GetTLSConfig(opts ...Option) *tls.Config {
 	if len(caCerts) > 0 {
 		config.GetCertificate = getGetCertificateFunc(config, caCerts)
 	} else {
-		config.GetCertificate = getNewGetCertificateFunc(c.BuildCertificates(), c.RejectUnknownSni)
+		certs, access := c.buildCertificates()
+		config.GetCertificate = getNewGetCertificateFunc(certs, access, c.RejectUnknownSni)
 	}
 
 	if sn := c.parseServerName(); len(sn) > 0 {
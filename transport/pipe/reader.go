@@ -39,3 +39,17 @@ func (r *Reader) Recover() (err error) {
 	}
 	return
 }
+
+// UnwrapReader returns the pipe reader which r is, or wraps by implementing Unwrap, e.g. to count its traffic.
+func UnwrapReader(r buf.Reader) (*Reader, bool) {
+	for {
+		switch v := r.(type) {
+		case *Reader:
+			return v, true
+		case interface{ Unwrap() buf.Reader }:
+			r = v.Unwrap()
+		default:
+			return nil, false
+		}
+	}
+}